@@ -2,14 +2,13 @@ package app
 
 import (
 	"cosmossdk.io/errors"
-	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
+	mincommissionkeeper "github.com/CosmWasm/wasmd/x/mincommission/keeper"
 	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
-	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	ibckeeper "github.com/cosmos/ibc-go/v8/modules/core/keeper"
 	ibcante "github.com/cosmos/ibc-go/v8/modules/core/ante"
 )
@@ -19,45 +18,12 @@ import (
 type HandlerOptions struct {
 	ante.HandlerOptions
 
-	IBCKeeper         *ibckeeper.Keeper
-	WasmConfig        *wasmTypes.WasmConfig
-	TXCounterStoreKey storetypes.StoreKey
+	IBCKeeper           *ibckeeper.Keeper
+	WasmConfig          *wasmTypes.WasmConfig
+	TXCounterStoreKey   storetypes.StoreKey
+	MinCommissionKeeper *mincommissionkeeper.Keeper
 }
 
-type MinCommissionDecorator struct{}
-
-func NewMinCommissionDecorator() MinCommissionDecorator {
-	return MinCommissionDecorator{}
-}
-
-func (MinCommissionDecorator) AnteHandle(
-	ctx sdk.Context, tx sdk.Tx,
-	simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
-	msgs := tx.GetMsgs()
-	minCommissionRate := math.LegacyNewDecWithPrec(5, 2)
-	for _, m := range msgs {
-		switch msg := m.(type) {
-		case *stakingtypes.MsgCreateValidator:
-			c := msg.Commission
-			if c.Rate.LT(minCommissionRate) {
-				return ctx, errors.Wrap(sdkerrors.ErrUnauthorized, "commission can't be lower than 5%")
-			}
-		case *stakingtypes.MsgEditValidator:
-			if msg.CommissionRate == nil {
-				continue
-			}
-			if msg.CommissionRate.LT(minCommissionRate) {
-				return ctx, errors.Wrap(sdkerrors.ErrUnauthorized, "commission can't be lower than 5%")
-			}
-		default:
-			continue
-		}
-	}
-	return next(ctx, tx, simulate)
-}
-
-// MEME commission END
-
 func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 	if options.AccountKeeper == nil {
 		return nil, errors.Wrap(sdkerrors.ErrLogic, "account keeper is required for AnteHandler")
@@ -74,6 +40,9 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 	if options.TXCounterStoreKey == nil {
 		return nil, errors.Wrap(sdkerrors.ErrLogic, "tx counter key is required for ante builder")
 	}
+	if options.MinCommissionKeeper == nil {
+		return nil, errors.Wrap(sdkerrors.ErrLogic, "min commission keeper is required for ante builder")
+	}
 
 	var sigGasConsumer = options.SigGasConsumer
 	if sigGasConsumer == nil {
@@ -82,7 +51,7 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 
 	anteDecorators := []sdk.AnteDecorator{
 		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
-		NewMinCommissionDecorator(),
+		mincommissionkeeper.NewMinCommissionDecorator(options.MinCommissionKeeper),
 		wasmkeeper.NewLimitSimulationGasDecorator(options.WasmConfig.SimulationGasLimit), // after setup context to enforce limits early
 		wasmkeeper.NewCountTXDecorator(options.TXCounterStoreKey),
 		ante.NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
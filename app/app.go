@@ -71,7 +71,6 @@ import (
 	mintkeeper "github.com/cosmos/cosmos-sdk/x/mint/keeper"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
-	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
@@ -80,8 +79,20 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	packetforward "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward"
+	packetforwardkeeper "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward/keeper"
+	packetforwardtypes "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward/types"
+	ibccallbacks "github.com/cosmos/ibc-go/modules/apps/callbacks"
 	capabilitykeeper "github.com/cosmos/ibc-go/modules/capability/keeper"
 	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
+	icacontroller "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller"
+	icacontrollerkeeper "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/keeper"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icahost "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host"
+	icahostkeeper "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host/keeper"
+	icahosttypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
 	transfer "github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 	ibctransferkeeper "github.com/cosmos/ibc-go/v8/modules/apps/transfer/keeper"
 	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
@@ -89,11 +100,17 @@ import (
 	ibcclienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 	ibcconnectiontypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
 	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	ibckeeper "github.com/cosmos/ibc-go/v8/modules/core/keeper"
 	"github.com/spf13/cast"
 
 	wasmappparams "github.com/CosmWasm/wasmd/app/params"
+	"github.com/CosmWasm/wasmd/x/mincommission"
+	mincommissionkeeper "github.com/CosmWasm/wasmd/x/mincommission/keeper"
+	mincommissiontypes "github.com/CosmWasm/wasmd/x/mincommission/types"
 	"github.com/CosmWasm/wasmd/x/wasm"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 // MEME APP Start ... You like it!
@@ -103,6 +120,18 @@ const (
 	IBCStoreKey = "ibc" // IBC core store key for SDK 0.50
 )
 
+// Default settings of the packet forward middleware. Both can be set per hop with the
+// `retries` and `timeout` fields of the forward memo.
+var (
+	PacketForwardRetriesOnTimeout uint8 = 0
+	PacketForwardTimeout                = packetforwardkeeper.DefaultForwardTransferPacketTimeoutTimestamp
+)
+
+// MaxIBCCallbackGas is the max gas a contract can consume in a single callback of the transfer or the
+// interchain accounts controller stack. Transfer callbacks can request less gas with the `gas_limit`
+// field of the callback memo.
+var MaxIBCCallbackGas uint64 = 1_000_000
+
 // We pull these out so we can set them with LDFLAGS in the Makefile
 var (
 	MeMeNodeDir  = ".memed"
@@ -165,6 +194,7 @@ var (
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
+		icatypes.ModuleName:            nil,
 		wasm.ModuleName:                {authtypes.Burner},
 	}
 )
@@ -203,13 +233,21 @@ type WasmApp struct {
 	ibcKeeper            *ibckeeper.Keeper // IBC Keeper must be a pointer in the app, so we can SetRouter on it correctly
 	evidenceKeeper       evidencekeeper.Keeper
 	transferKeeper       ibctransferkeeper.Keeper
+	icaControllerKeeper  icacontrollerkeeper.Keeper
+	icaHostKeeper        icahostkeeper.Keeper
+	packetForwardKeeper  *packetforwardkeeper.Keeper
 	FeeGrantKeeper       feegrantkeeper.Keeper
 	AuthzKeeper          authzkeeper.Keeper
 	wasmKeeper           wasm.Keeper
+	minCommissionKeeper  mincommissionkeeper.Keeper
 	scopedIBCKeeper      capabilitykeeper.ScopedKeeper
 	scopedTransferKeeper capabilitykeeper.ScopedKeeper
 	scopedWasmKeeper     capabilitykeeper.ScopedKeeper
 
+	scopedICAControllerKeeper capabilitykeeper.ScopedKeeper
+	scopedICAHostKeeper       capabilitykeeper.ScopedKeeper
+	scopedICS721Keeper        capabilitykeeper.ScopedKeeper
+
 	// the module manager
 	mm *module.Manager
 
@@ -254,7 +292,9 @@ func NewWasmApp(
 		evidencetypes.StoreKey, ibctransfertypes.StoreKey,
 		feegrant.StoreKey, authzkeeper.StoreKey, wasm.StoreKey,
 		IBCStoreKey, consensuskeeper.StoreKey, capabilitytypes.StoreKey,
-		crisistypes.StoreKey,
+		crisistypes.StoreKey, mincommissiontypes.StoreKey,
+		icacontrollertypes.StoreKey, icahosttypes.StoreKey,
+		packetforwardtypes.StoreKey,
 	)
 	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
 	memKeys := storetypes.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
@@ -297,6 +337,9 @@ func NewWasmApp(
 	app.scopedIBCKeeper = app.capabilityKeeper.ScopeToModule(IBCStoreKey)
 	app.scopedTransferKeeper = app.capabilityKeeper.ScopeToModule(ibctransfertypes.ModuleName)
 	app.scopedWasmKeeper = app.capabilityKeeper.ScopeToModule(wasm.ModuleName)
+	app.scopedICAControllerKeeper = app.capabilityKeeper.ScopeToModule(icacontrollertypes.SubModuleName)
+	app.scopedICAHostKeeper = app.capabilityKeeper.ScopeToModule(icahosttypes.SubModuleName)
+	app.scopedICS721Keeper = app.capabilityKeeper.ScopeToModule(wasmtypes.ICS721RouteKey)
 
 	// Seal the capability keeper to prevent further scoped keepers from being created
 	app.capabilityKeeper.Seal()
@@ -429,12 +472,49 @@ func NewWasmApp(
 		app.scopedTransferKeeper,
 		authority,
 	)
-	transferModule := transfer.NewAppModule(app.transferKeeper)
-	transferIBCModule := transfer.NewIBCModule(app.transferKeeper)
+
+	// The packet forward keeper forwards received transfers to the next hop given in the memo with the
+	// transfer keeper, which is set once the transfer stack is built. It writes the acks of the
+	// forwarded packets once the next hop acknowledged or timed out.
+	app.packetForwardKeeper = packetforwardkeeper.NewKeeper(
+		appCodec,
+		keys[packetforwardtypes.StoreKey],
+		nil, // set below with SetTransferKeeper
+		app.ibcKeeper.ChannelKeeper,
+		app.bankKeeper,
+		app.ibcKeeper.ChannelKeeper, // ICS4 wrapper
+		authority,
+	)
+
+	// Create Interchain Accounts Keepers. The host executes the txs of accounts controlled by other chains
+	// for the msg types in its allowlist param. Contracts control accounts on other chains via the controller msgs.
+	app.icaHostKeeper = icahostkeeper.NewKeeper(
+		appCodec,
+		keys[icahosttypes.StoreKey],
+		app.getSubspace(icahosttypes.SubModuleName),
+		app.ibcKeeper.ChannelKeeper, // ICS4 wrapper
+		app.ibcKeeper.ChannelKeeper,
+		app.ibcKeeper.PortKeeper,
+		app.accountKeeper,
+		app.scopedICAHostKeeper,
+		app.MsgServiceRouter(),
+		authority,
+	)
+	app.icaHostKeeper.WithQueryRouter(app.GRPCQueryRouter())
+	app.icaControllerKeeper = icacontrollerkeeper.NewKeeper(
+		appCodec,
+		keys[icacontrollertypes.StoreKey],
+		app.getSubspace(icacontrollertypes.SubModuleName),
+		app.ibcKeeper.ChannelKeeper, // ICS4 wrapper
+		app.ibcKeeper.ChannelKeeper,
+		app.ibcKeeper.PortKeeper,
+		app.scopedICAControllerKeeper,
+		app.MsgServiceRouter(),
+		authority,
+	)
 
 	// create static IBC router, add transfer route, then set and seal it
 	ibcRouter := porttypes.NewRouter()
-	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferIBCModule)
 
 	// create evidence keeper with router
 	evidenceKeeper := evidencekeeper.NewKeeper(
@@ -447,6 +527,8 @@ func NewWasmApp(
 	)
 	app.evidenceKeeper = *evidenceKeeper
 
+	app.minCommissionKeeper = mincommissionkeeper.NewKeeper(appCodec, keys[mincommissiontypes.StoreKey], authority)
+
 	wasmDir := filepath.Join(homePath, "wasm")
 	wasmConfig, err := wasm.ReadWasmConfig(appOpts)
 	if err != nil {
@@ -462,14 +544,16 @@ func NewWasmApp(
 	bankKeeperAdapter := NewBankKeeperAdapter(app.bankKeeper)
 	stakingKeeperAdapter := NewStakingKeeperAdapter(&app.stakingKeeper)
 	distrKeeperAdapter := NewDistributionKeeperAdapter(app.distrKeeper)
-	channelKeeperAdapter := NewChannelKeeperAdapter(&app.ibcKeeper.ChannelKeeper)
-	portKeeperAdapter := NewPortKeeperAdapter(app.ibcKeeper.PortKeeper)
+	channelKeeperAdapter := NewChannelKeeperAdapter(&app.ibcKeeper.ChannelKeeper, app.scopedWasmKeeper, app.scopedICS721Keeper)
+	portKeeperAdapter := NewPortKeeperAdapter(app.ibcKeeper.PortKeeper, app.scopedWasmKeeper)
 	transferPortSourceAdapter := NewICS20TransferPortSourceAdapter(app.scopedWasmKeeper)
 
+	// genesis stream files are resolved in the config directory, custom options take precedence
+	wasmOpts = append([]wasm.Option{wasm.WithGenesisStreamDir(filepath.Join(homePath, "config"))}, wasmOpts...)
+
 	app.wasmKeeper = wasm.NewKeeper(
 		appCodec,
 		keys[wasm.StoreKey],
-		app.getSubspace(wasm.ModuleName),
 		accountKeeperAdapter,
 		bankKeeperAdapter,
 		stakingKeeperAdapter,
@@ -482,6 +566,7 @@ func NewWasmApp(
 		wasmDir,
 		wasmConfig,
 		supportedFeatures,
+		authority,
 		wasmOpts...,
 	)
 
@@ -489,7 +574,41 @@ func NewWasmApp(
 	if len(enabledProposals) != 0 {
 		govRouter.AddRoute(wasm.RouterKey, wasm.NewWasmProposalHandler(app.wasmKeeper, enabledProposals))
 	}
-	ibcRouter.AddRoute(wasm.ModuleName, wasm.NewIBCHandler(app.wasmKeeper, channelKeeperAdapter))
+	// the ibc callbacks middleware notifies contracts about the lifecycle of the ICS-20 transfers they send or receive,
+	// see ADR-008. It wraps the transfer app and is the ICS4 wrapper of the transfer keeper so that it can verify the
+	// callback requests on send, including the transfers of the packet forward middleware.
+	callbacksMiddleware := ibccallbacks.NewIBCMiddleware(
+		transfer.NewIBCModule(app.transferKeeper),
+		app.ibcKeeper.ChannelKeeper,
+		app.wasmKeeper,
+		MaxIBCCallbackGas,
+	)
+	app.transferKeeper.WithICS4Wrapper(callbacksMiddleware)
+	app.packetForwardKeeper.SetTransferKeeper(app.transferKeeper)
+	// the packet forward middleware forwards received transfers with a `forward` memo to the next chain and
+	// refunds the sender when the next hop fails or times out after the retries.
+	transferStack := packetforward.NewIBCMiddleware(
+		callbacksMiddleware,
+		app.packetForwardKeeper,
+		PacketForwardRetriesOnTimeout,
+		PacketForwardTimeout,
+	)
+	transferModule := transfer.NewAppModule(app.transferKeeper)
+
+	// the controller is used via msgs only, without an authentication module. The owner contracts are
+	// notified about the handshake and the tx results via sudo.
+	icaControllerStack := wasm.NewICAControllerCallbacksMiddleware(
+		icacontroller.NewIBCMiddleware(nil, app.icaControllerKeeper),
+		app.wasmKeeper,
+		MaxIBCCallbackGas,
+	)
+
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferStack)
+	ibcRouter.AddRoute(icacontrollertypes.SubModuleName, icaControllerStack)
+	ibcRouter.AddRoute(icahosttypes.SubModuleName, icahost.NewIBCModule(app.icaHostKeeper))
+	ibcRouter.AddRoute(wasm.ModuleName, wasm.NewIBCHandler(app.wasmKeeper, channelKeeperAdapter, app.scopedWasmKeeper))
+	// the ics721 nft-transfer port is served by the contract configured in the wasm params
+	ibcRouter.AddRoute(wasmtypes.ICS721RouteKey, wasm.NewICS721Handler(app.wasmKeeper, channelKeeperAdapter, app.scopedICS721Keeper))
 	app.ibcKeeper.SetRouter(ibcRouter)
 
 	// Gov keeper needs to be initialized with a config for SDK 0.50
@@ -531,7 +650,8 @@ func NewWasmApp(
 		distr.NewAppModule(appCodec, app.distrKeeper, app.accountKeeper, app.bankKeeper, app.stakingKeeper, app.getSubspace(distrtypes.ModuleName)),
 		staking.NewAppModule(appCodec, &app.stakingKeeper, app.accountKeeper, app.bankKeeper, app.getSubspace(stakingtypes.ModuleName)),
 		upgrade.NewAppModule(&app.upgradeKeeper, addressCodec),
-		wasm.NewAppModule(appCodec, &app.wasmKeeper, NewValidatorSetSourceAdapter(&app.stakingKeeper)),
+		wasm.NewAppModule(appCodec, &app.wasmKeeper, NewValidatorSetSourceAdapter(&app.stakingKeeper), app.accountKeeper, app.bankKeeper, app.getSubspace(wasm.ModuleName)),
+		mincommission.NewAppModule(&app.minCommissionKeeper),
 		evidence.NewAppModule(app.evidenceKeeper),
 		feegrantmodule.NewAppModule(appCodec, app.accountKeeper, app.bankKeeper, app.FeeGrantKeeper, app.interfaceRegistry),
 		authzmodule.NewAppModule(appCodec, app.AuthzKeeper, app.accountKeeper, app.bankKeeper, app.interfaceRegistry),
@@ -539,6 +659,8 @@ func NewWasmApp(
 		params.NewAppModule(app.paramsKeeper),
 		consensus.NewAppModule(appCodec, app.consensusKeeper),
 		transferModule,
+		ica.NewAppModule(&app.icaControllerKeeper, &app.icaHostKeeper),
+		packetforward.NewAppModule(app.packetForwardKeeper, app.getSubspace(packetforwardtypes.ModuleName)),
 		crisis.NewAppModule(&app.crisisKeeper, skipGenesisInvariants, app.getSubspace(crisistypes.ModuleName)), // always be last to make sure that it checks for all invariants and not only part of them
 	)
 
@@ -565,6 +687,8 @@ func NewWasmApp(
 		// additional non simd modules
 		IBCStoreKey,
 		ibctransfertypes.ModuleName,
+		icatypes.ModuleName,
+		packetforwardtypes.ModuleName,
 		wasm.ModuleName,
 	)
 
@@ -587,6 +711,8 @@ func NewWasmApp(
 		// additional non simd modules
 		IBCStoreKey,
 		ibctransfertypes.ModuleName,
+		icatypes.ModuleName,
+		packetforwardtypes.ModuleName,
 		wasm.ModuleName,
 	)
 
@@ -616,6 +742,9 @@ func NewWasmApp(
 		// additional non simd modules
 		IBCStoreKey,
 		ibctransfertypes.ModuleName,
+		icatypes.ModuleName,
+		packetforwardtypes.ModuleName,
+		mincommissiontypes.ModuleName,
 		// wasm after ibc transfer
 		wasm.ModuleName,
 	)
@@ -630,9 +759,7 @@ func NewWasmApp(
 	basicOverrides := map[string]module.AppModuleBasic{
 		genutiltypes.ModuleName: genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
 		govtypes.ModuleName: gov.NewAppModuleBasic(
-			[]govclient.ProposalHandler{
-				paramsclient.ProposalHandler,
-			},
+			[]govclient.ProposalHandler{},
 		),
 	}
 	app.basicManager = module.NewBasicManagerFromManager(app.mm, basicOverrides)
@@ -660,7 +787,7 @@ func NewWasmApp(
 		slashing.NewAppModule(appCodec, app.slashingKeeper, app.accountKeeper, app.bankKeeper, app.stakingKeeper, app.getSubspace(slashingtypes.ModuleName), app.interfaceRegistry),
 		params.NewAppModule(app.paramsKeeper),
 		evidence.NewAppModule(app.evidenceKeeper),
-		wasm.NewAppModule(appCodec, &app.wasmKeeper, NewValidatorSetSourceAdapter(&app.stakingKeeper)),
+		wasm.NewAppModule(appCodec, &app.wasmKeeper, NewValidatorSetSourceAdapter(&app.stakingKeeper), app.accountKeeper, app.bankKeeper, app.getSubspace(wasm.ModuleName)),
 		ibc.NewAppModule(app.ibcKeeper),
 		transferModule,
 	)
@@ -681,9 +808,10 @@ func NewWasmApp(
 				SignModeHandler: encodingConfig.TxConfig.SignModeHandler(),
 				SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
 			},
-			IBCKeeper:         app.ibcKeeper,
-			WasmConfig:        &wasmConfig,
-			TXCounterStoreKey: keys[wasm.StoreKey],
+			IBCKeeper:           app.ibcKeeper,
+			WasmConfig:          &wasmConfig,
+			TXCounterStoreKey:   keys[wasm.StoreKey],
+			MinCommissionKeeper: &app.minCommissionKeeper,
 		},
 	)
 	if err != nil {
@@ -701,6 +829,17 @@ func NewWasmApp(
 	// and before LoadLatestVersion (so the store loader is set before loading).
 	app.RegisterUpgradeHandlers()
 
+	// must be before loading the latest version so that the wasm byte code
+	// is restored together with the state on state sync
+	if manager := app.SnapshotManager(); manager != nil {
+		err := manager.RegisterExtensions(
+			wasmkeeper.NewWasmSnapshotter(app.CommitMultiStore(), &app.wasmKeeper),
+		)
+		if err != nil {
+			panic(fmt.Errorf("failed to register snapshot extension: %s", err))
+		}
+	}
+
 	if loadLatest {
 		if err := app.LoadLatestVersion(); err != nil {
 			tmos.Exit(fmt.Sprintf("failed to load latest version: %s", err))
@@ -743,7 +882,23 @@ func (app *WasmApp) InitChainer(ctx sdk.Context, req *abci.RequestInitChain) (*a
 
 	app.upgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
 
-	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	res, err := app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	if err != nil {
+		return nil, err
+	}
+	if err := app.bindICS721Port(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// bindICS721Port binds the ics721 nft-transfer port unless it is bound already
+func (app *WasmApp) bindICS721Port(ctx sdk.Context) error {
+	if _, ok := app.scopedICS721Keeper.GetCapability(ctx, host.PortPath(wasmtypes.ICS721PortID)); ok {
+		return nil
+	}
+	portCap := app.ibcKeeper.PortKeeper.BindPort(ctx, wasmtypes.ICS721PortID)
+	return app.scopedICS721Keeper.ClaimCapability(ctx, portCap, host.PortPath(wasmtypes.ICS721PortID))
 }
 
 // LoadHeight loads a particular height
@@ -839,8 +994,11 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	ibcKeyTable := ibcclienttypes.ParamKeyTable()                                                     //nolint:staticcheck // needed for IBC client migration
 	ibcKeyTable.RegisterParamSet(&ibcconnectiontypes.Params{})                                        //nolint:staticcheck // needed for IBC connection migration
 	paramsKeeper.Subspace(IBCStoreKey).WithKeyTable(ibcKeyTable)
-	paramsKeeper.Subspace(wasm.ModuleName)
+	paramsKeeper.Subspace(icacontrollertypes.SubModuleName).WithKeyTable(icacontrollertypes.ParamKeyTable()) //nolint:staticcheck // needed for ica controller migration
+	paramsKeeper.Subspace(icahosttypes.SubModuleName).WithKeyTable(icahosttypes.ParamKeyTable())             //nolint:staticcheck // needed for ica host migration
+	paramsKeeper.Subspace(wasm.ModuleName).WithKeyTable(wasm.ParamKeyTable())                                //nolint:staticcheck // needed for wasm params migration
 	paramsKeeper.Subspace(baseapp.Paramspace).WithKeyTable(paramstypes.ConsensusParamsKeyTable())
+	paramsKeeper.Subspace(packetforwardtypes.ModuleName)
 
 	return paramsKeeper
 }
@@ -21,9 +21,13 @@ import (
 	feegrantmodule "cosmossdk.io/x/feegrant/module"
 	"cosmossdk.io/x/upgrade"
 	ibc "github.com/cosmos/ibc-go/v8/modules/core"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
 	transfer "github.com/cosmos/ibc-go/v8/modules/apps/transfer"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+	packetforward "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward"
 
 	wasmappparams "github.com/CosmWasm/wasmd/app/params"
+	"github.com/CosmWasm/wasmd/x/mincommission"
 	"github.com/CosmWasm/wasmd/x/wasm"
 )
 
@@ -56,10 +60,14 @@ func MakeEncodingConfig() wasmappparams.EncodingConfig {
 		feegrantmodule.AppModuleBasic{},
 		authzmodule.AppModuleBasic{},
 		ibc.AppModuleBasic{},
+		ibctm.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		evidence.AppModuleBasic{},
 		transfer.AppModuleBasic{},
+		ica.AppModuleBasic{},
+		packetforward.AppModuleBasic{},
 		vesting.AppModuleBasic{},
+		mincommission.AppModuleBasic{},
 		wasm.AppModuleBasic{},
 	)
 	
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	icahosttypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mincommissiontypes "github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+func TestMakeEncodingConfigResolvesGovProposalMsgs(t *testing.T) {
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+	specs := map[string]sdk.Msg{
+		"ica host params": &icahosttypes.MsgUpdateParams{
+			Signer: authority,
+			Params: icahosttypes.NewParams(true, ICAHostAllowMessages),
+		},
+		"min commission params": &mincommissiontypes.MsgUpdateParams{
+			Authority: authority,
+			Params:    mincommissiontypes.DefaultParams(),
+		},
+	}
+	for name, msg := range specs {
+		t.Run(name, func(t *testing.T) {
+			cdc := MakeEncodingConfig().Marshaler
+			proposal, err := govv1.NewMsgSubmitProposal([]sdk.Msg{msg}, sdk.NewCoins(), authority, "", "title", "summary", false)
+			require.NoError(t, err)
+
+			// when
+			bz, err := cdc.MarshalJSON(proposal)
+			require.NoError(t, err)
+			var got govv1.MsgSubmitProposal
+			err = cdc.UnmarshalJSON(bz, &got)
+
+			// then
+			require.NoError(t, err)
+			gotMsgs, err := got.GetMsgs()
+			require.NoError(t, err)
+			require.Len(t, gotMsgs, 1)
+			assert.Equal(t, msg, gotMsgs[0])
+		})
+	}
+}
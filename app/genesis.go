@@ -22,9 +22,12 @@ import (
 	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
+	packetforward "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
 	ibc "github.com/cosmos/ibc-go/v8/modules/core"
 	transfer "github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 
+	"github.com/CosmWasm/wasmd/x/mincommission"
 	"github.com/CosmWasm/wasmd/x/wasm"
 )
 
@@ -69,7 +72,10 @@ func MakeBasicManager() module.BasicManager {
 		upgrade.AppModuleBasic{},
 		evidence.AppModuleBasic{},
 		transfer.AppModuleBasic{},
+		ica.AppModuleBasic{},
+		packetforward.AppModuleBasic{},
 		vesting.AppModuleBasic{},
+		mincommission.AppModuleBasic{},
 		wasm.AppModuleBasic{},
 	)
 }
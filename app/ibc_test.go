@@ -0,0 +1,653 @@
+package app
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	ibccallbackstypes "github.com/cosmos/ibc-go/modules/apps/callbacks/types"
+	capabilitykeeper "github.com/cosmos/ibc-go/modules/capability/keeper"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	ibckeeper "github.com/cosmos/ibc-go/v8/modules/core/keeper"
+	ibctesting "github.com/cosmos/ibc-go/v8/testing"
+	ibctestingtypes "github.com/cosmos/ibc-go/v8/testing/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ibcTestingApp exposes the WasmApp to the ibc-go testing framework
+type ibcTestingApp struct {
+	*WasmApp
+}
+
+func (app ibcTestingApp) GetBaseApp() *baseapp.BaseApp {
+	return app.BaseApp
+}
+
+func (app ibcTestingApp) GetStakingKeeper() ibctestingtypes.StakingKeeper {
+	return app.stakingKeeper
+}
+
+func (app ibcTestingApp) GetIBCKeeper() *ibckeeper.Keeper {
+	return app.ibcKeeper
+}
+
+func (app ibcTestingApp) GetScopedIBCKeeper() capabilitykeeper.ScopedKeeper {
+	return app.scopedIBCKeeper
+}
+
+func (app ibcTestingApp) GetTxConfig() client.TxConfig {
+	return MakeEncodingConfig().TxConfig
+}
+
+// setupIBCCoordinator returns a coordinator for two chains that run the WasmApp.
+func setupIBCCoordinator(t *testing.T) (*ibctesting.Coordinator, *ibctesting.TestChain, *ibctesting.TestChain) {
+	t.Helper()
+	defaultTestingAppInit := ibctesting.DefaultTestingAppInit
+	t.Cleanup(func() { ibctesting.DefaultTestingAppInit = defaultTestingAppInit })
+	ibctesting.DefaultTestingAppInit = func() (ibctesting.TestingApp, map[string]json.RawMessage) {
+		app := newSimApp(log.NewNopLogger(), dbm.NewMemDB(), t.TempDir(), MakeEncodingConfig(), emptyWasmOpts)
+		return ibcTestingApp{app}, simGenesisState(app.AppCodec())
+	}
+	coord := ibctesting.NewCoordinator(t, 2)
+	return coord, coord.GetChain(ibctesting.GetChainID(1)), coord.GetChain(ibctesting.GetChainID(2))
+}
+
+func wasmApp(chain *ibctesting.TestChain) *WasmApp {
+	return chain.App.(ibcTestingApp).WasmApp
+}
+
+// storeAndInstantiate uploads the wasm code to the chain and returns the new contract address.
+func storeAndInstantiate(t *testing.T, chain *ibctesting.TestChain, wasmCode []byte) sdk.AccAddress {
+	t.Helper()
+	sender := chain.SenderAccount.GetAddress().String()
+	res, err := chain.SendMsgs(&wasmtypes.MsgStoreCode{Sender: sender, WASMByteCode: wasmCode})
+	require.NoError(t, err)
+	codeID, err := strconv.ParseUint(eventAttribute(t, res.Events, wasmtypes.EventTypeStoreCode, wasmtypes.AttributeKeyCodeID), 10, 64)
+	require.NoError(t, err)
+
+	res, err = chain.SendMsgs(&wasmtypes.MsgInstantiateContract{Sender: sender, CodeID: codeID, Label: "testing", Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	contractAddr, err := sdk.AccAddressFromBech32(eventAttribute(t, res.Events, wasmtypes.EventTypeInstantiate, wasmtypes.AttributeKeyContractAddr))
+	require.NoError(t, err)
+	return contractAddr
+}
+
+func eventAttribute(t *testing.T, events []abci.Event, eventType, key string) string {
+	t.Helper()
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if a.Key == key {
+				return a.Value
+			}
+		}
+	}
+	t.Fatalf("no %s attribute in %s events", key, eventType)
+	return ""
+}
+
+// ibcContractResponses returns the responses of a contract that implements all IBC entry points,
+// accepts every channel and acknowledges every received packet with the given ack. The packets
+// are acknowledged asynchronously when the ack is nil.
+func ibcContractResponses(t *testing.T, execute wasmvmtypes.ContractResult, ack []byte) map[string][]byte {
+	t.Helper()
+	basic := mustMarshalJSON(t, wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}})
+	return map[string][]byte{
+		"instantiate":         mustMarshalJSON(t, wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}),
+		"execute":             mustMarshalJSON(t, execute),
+		"ibc_channel_open":    mustMarshalJSON(t, wasmvmtypes.IBCChannelOpenResult{}),
+		"ibc_channel_connect": basic,
+		"ibc_channel_close":   basic,
+		"ibc_packet_receive":  mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: ack}}),
+		"ibc_packet_ack":      basic,
+		"ibc_packet_timeout":  basic,
+	}
+}
+
+// sendPacketResult returns the result of a contract execution that sends a packet
+func sendPacketResult(channelID string, data []byte, timeout time.Time) wasmvmtypes.ContractResult {
+	return wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{
+		Msg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{
+			ChannelID: channelID,
+			Data:      data,
+			Timeout:   wasmvmtypes.IBCTimeout{Timestamp: uint64(timeout.UnixNano())},
+		}}},
+		ReplyOn: wasmvmtypes.ReplyNever,
+	}}}}
+}
+
+func mustMarshalJSON(t *testing.T, o any) []byte {
+	t.Helper()
+	bz, err := json.Marshal(o)
+	require.NoError(t, err)
+	return bz
+}
+
+// staticResponseContract builds a wasm contract whose entry points ignore their input and
+// return the given JSON response. The contract has no state.
+func staticResponseContract(responses map[string][]byte) []byte {
+	const (
+		typeAllocate = iota
+		typeDeallocate
+		typeNoop
+		typeEntryPoint2
+		typeEntryPoint3
+
+		dataOffset = 1024
+		heapOffset = 65536
+	)
+	entryPoints := make([]string, 0, len(responses))
+	for name := range responses {
+		entryPoints = append(entryPoints, name)
+	}
+	sort.Strings(entryPoints)
+
+	var functions, exports, code, data []byte
+	exportFunc := func(name string, index int) {
+		exports = append(exports, wasmBytes([]byte(name))...)
+		exports = append(exports, 0x00)
+		exports = wasmU32(exports, uint32(index))
+	}
+	addFunc := func(name string, typeIndex int, body []byte) {
+		exportFunc(name, len(functions))
+		functions = append(functions, byte(typeIndex))
+		code = append(code, wasmBytes(body)...)
+	}
+	// allocate(size) returns a region {offset, capacity, length} that is followed by the data
+	addFunc("allocate", typeAllocate, []byte{
+		0x01, 0x01, 0x7f, // one i32 local
+		0x23, 0x00, 0x21, 0x01, // region = heap
+		0x20, 0x01, 0x20, 0x01, 0x41, 0x0c, 0x6a, 0x36, 0x02, 0x00, // region.offset = region + 12
+		0x20, 0x01, 0x20, 0x00, 0x36, 0x02, 0x04, // region.capacity = size
+		0x20, 0x01, 0x41, 0x00, 0x36, 0x02, 0x08, // region.length = 0
+		0x20, 0x01, 0x41, 0x0c, 0x6a, 0x20, 0x00, 0x6a, 0x24, 0x00, // heap = region + 12 + size
+		0x20, 0x01, 0x0b, // return region
+	})
+	addFunc("deallocate", typeDeallocate, []byte{0x00, 0x0b})
+	addFunc("interface_version_8", typeNoop, []byte{0x00, 0x0b})
+	for _, name := range entryPoints {
+		region := dataOffset + len(data)
+		response := responses[name]
+		data = binary.LittleEndian.AppendUint32(data, uint32(region+12))
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(response)))
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(response)))
+		data = append(data, response...)
+
+		typeIndex := typeEntryPoint2
+		if name == "instantiate" || name == "execute" {
+			typeIndex = typeEntryPoint3
+		}
+		body := append([]byte{0x00, 0x41}, wasmI32(nil, int32(region))...)
+		addFunc(name, typeIndex, append(body, 0x0b))
+	}
+	exports = append(exports, wasmBytes([]byte("memory"))...)
+	exports = append(exports, 0x02, 0x00)
+
+	var module bytes.Buffer
+	module.Write([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00})
+	writeSection := func(id byte, count int, content []byte) {
+		module.WriteByte(id)
+		module.Write(wasmBytes(append(wasmU32(nil, uint32(count)), content...)))
+	}
+	writeSection(0x01, 5, []byte{
+		0x60, 0x01, 0x7f, 0x01, 0x7f, // (i32) -> i32
+		0x60, 0x01, 0x7f, 0x00, // (i32) -> ()
+		0x60, 0x00, 0x00, // () -> ()
+		0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f, // (i32, i32) -> i32
+		0x60, 0x03, 0x7f, 0x7f, 0x7f, 0x01, 0x7f, // (i32, i32, i32) -> i32
+	})
+	writeSection(0x03, len(functions), functions)
+	writeSection(0x05, 1, []byte{0x00, 0x10}) // 16 pages
+	writeSection(0x06, 1, append(append([]byte{0x7f, 0x01, 0x41}, wasmI32(nil, heapOffset)...), 0x0b))
+	writeSection(0x07, len(entryPoints)+4, exports)
+	writeSection(0x0a, len(functions), code)
+	segment := append(append([]byte{0x00, 0x41}, wasmI32(nil, dataOffset)...), 0x0b)
+	writeSection(0x0b, 1, append(segment, wasmBytes(data)...))
+	return module.Bytes()
+}
+
+// wasmBytes returns the length prefixed bytes
+func wasmBytes(bz []byte) []byte {
+	return append(wasmU32(nil, uint32(len(bz))), bz...)
+}
+
+// wasmU32 appends the unsigned LEB128 encoding of v
+func wasmU32(dst []byte, v uint32) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(dst, byte(v))
+}
+
+// wasmI32 appends the signed LEB128 encoding of v
+func wasmI32(dst []byte, v int32) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(dst, b)
+		}
+		dst = append(dst, b|0x80)
+	}
+}
+
+func TestContractWritesAsyncAcknowledgement(t *testing.T) {
+	coord, chainA, chainB := setupIBCCoordinator(t)
+
+	sendPacket := sendPacketResult("channel-0", []byte(`{"ping":{}}`), coord.CurrentTime.Add(time.Hour))
+	myAck := []byte(`{"result":"cG9uZw=="}`)
+	writeAck := wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{
+		Msg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{WriteAcknowledgement: &wasmvmtypes.WriteAcknowledgementMsg{
+			ChannelID:      "channel-0",
+			PacketSequence: 1,
+			Ack:            wasmvmtypes.IBCAcknowledgement{Data: myAck},
+		}}},
+		ReplyOn: wasmvmtypes.ReplyNever,
+	}}}}
+	senderContract := storeAndInstantiate(t, chainA, staticResponseContract(ibcContractResponses(t, sendPacket, nil)))
+	receiverContract := storeAndInstantiate(t, chainB, staticResponseContract(ibcContractResponses(t, writeAck, nil)))
+
+	path := ibctesting.NewPath(chainA, chainB)
+	path.EndpointA.ChannelConfig.PortID = wasmApp(chainA).wasmKeeper.GetContractInfo(chainA.GetContext(), senderContract).IBCPortID
+	path.EndpointB.ChannelConfig.PortID = wasmApp(chainB).wasmKeeper.GetContractInfo(chainB.GetContext(), receiverContract).IBCPortID
+	coord.Setup(path)
+	require.Equal(t, "channel-0", path.EndpointB.ChannelID)
+
+	// when the sender contract sends a packet
+	res, err := chainA.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainA.SenderAccount.GetAddress().String(), Contract: senderContract.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	packet, err := ibctesting.ParsePacketFromEvents(res.Events)
+	require.NoError(t, err)
+	require.NoError(t, path.EndpointB.UpdateClient())
+
+	// and the receiver contract does not acknowledge it on receive
+	require.NoError(t, path.EndpointB.RecvPacket(packet))
+	_, found := chainB.App.GetIBCKeeper().ChannelKeeper.GetPacketAcknowledgement(chainB.GetContext(), packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+	require.False(t, found)
+
+	// then the receiver contract writes the acknowledgement later
+	res, err = chainB.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainB.SenderAccount.GetAddress().String(), Contract: receiverContract.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	ack, err := ibctesting.ParseAckFromEvents(res.Events)
+	require.NoError(t, err)
+	require.Equal(t, myAck, ack)
+	_, found = chainB.App.GetIBCKeeper().ChannelKeeper.GetPacketAcknowledgement(chainB.GetContext(), packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+	require.True(t, found)
+	_, err = wasmApp(chainB).wasmKeeper.LoadAsyncAckPacket(chainB.GetContext(), packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+	require.Error(t, err)
+
+	// and the acknowledgement is relayed back to the sender
+	require.NoError(t, path.EndpointA.UpdateClient())
+	require.NoError(t, path.EndpointA.AcknowledgePacket(packet, ack))
+	require.Nil(t, chainA.App.GetIBCKeeper().ChannelKeeper.GetPacketCommitment(chainA.GetContext(), packet.SourcePort, packet.SourceChannel, packet.Sequence))
+}
+
+// sendNFTResult returns the result of a contract execution that sends the tokens over ICS-721
+func sendNFTResult(t *testing.T, timeout time.Time, classID string, tokenIDs ...string) wasmvmtypes.ContractResult {
+	t.Helper()
+	data := mustMarshalJSON(t, wasmtypes.NonFungibleTokenPacketData{ClassID: classID, TokenIDs: tokenIDs, Sender: "sender", Receiver: "receiver"})
+	return sendPacketResult("channel-0", data, timeout)
+}
+
+// setICS721Contract sets the contract that handles the packets of the nft-transfer port
+func setICS721Contract(t *testing.T, coord *ibctesting.Coordinator, chain *ibctesting.TestChain, contractAddr sdk.AccAddress) {
+	t.Helper()
+	ctx := chain.GetContext()
+	params := wasmApp(chain).wasmKeeper.GetParams(ctx)
+	params.ICS721Contract = contractAddr.String()
+	require.NoError(t, wasmApp(chain).wasmKeeper.SetParams(ctx, params))
+	coord.CommitBlock(chain)
+}
+
+// setupICS721Path opens a channel between the nft-transfer ports of both chains
+func setupICS721Path(coord *ibctesting.Coordinator, chainA, chainB *ibctesting.TestChain) *ibctesting.Path {
+	path := ibctesting.NewPath(chainA, chainB)
+	path.EndpointA.ChannelConfig.PortID = wasmtypes.ICS721PortID
+	path.EndpointA.ChannelConfig.Version = wasmtypes.ICS721Version
+	path.EndpointB.ChannelConfig.PortID = wasmtypes.ICS721PortID
+	path.EndpointB.ChannelConfig.Version = wasmtypes.ICS721Version
+	coord.Setup(path)
+	return path
+}
+
+func allClassTraces(chain *ibctesting.TestChain) []wasmtypes.ClassTrace {
+	var r []wasmtypes.ClassTrace
+	wasmApp(chain).wasmKeeper.IterateClassTraces(chain.GetContext(), func(trace wasmtypes.ClassTrace) bool {
+		r = append(r, trace)
+		return false
+	})
+	return r
+}
+
+func allTokenTraces(chain *ibctesting.TestChain) []wasmtypes.TokenTrace {
+	var r []wasmtypes.TokenTrace
+	wasmApp(chain).wasmKeeper.IterateTokenTraces(chain.GetContext(), func(trace wasmtypes.TokenTrace) bool {
+		r = append(r, trace)
+		return false
+	})
+	return r
+}
+
+func TestICS721ContractTransfersTokens(t *testing.T) {
+	coord, chainA, chainB := setupIBCCoordinator(t)
+	successAck := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+	timeout := coord.CurrentTime.Add(time.Hour)
+
+	const receivedClassID = "nft-transfer/channel-0/myClass"
+	contractA := storeAndInstantiate(t, chainA, staticResponseContract(ibcContractResponses(t, sendNFTResult(t, timeout, "myClass", "t1"), successAck)))
+	contractB := storeAndInstantiate(t, chainB, staticResponseContract(ibcContractResponses(t, sendNFTResult(t, timeout, receivedClassID, "t1"), successAck)))
+	setICS721Contract(t, coord, chainA, contractA)
+	setICS721Contract(t, coord, chainB, contractB)
+	path := setupICS721Path(coord, chainA, chainB)
+
+	// when the contract on chain A sends the token
+	res, err := chainA.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainA.SenderAccount.GetAddress().String(), Contract: contractA.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	packet, err := ibctesting.ParsePacketFromEvents(res.Events)
+	require.NoError(t, err)
+	require.Equal(t, wasmtypes.ICS721PortID, packet.SourcePort)
+	require.NoError(t, path.RelayPacket(packet))
+
+	// then the token is traced on chain B
+	keeperB := wasmApp(chainB).wasmKeeper
+	trace, found := keeperB.GetClassTrace(chainB.GetContext(), receivedClassID)
+	require.True(t, found)
+	require.Equal(t, wasmtypes.ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "myClass"}, trace)
+	require.True(t, keeperB.HasTokenTrace(chainB.GetContext(), receivedClassID, "t1"))
+
+	// when the contract on chain B returns the token
+	res, err = chainB.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainB.SenderAccount.GetAddress().String(), Contract: contractB.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	packet, err = ibctesting.ParsePacketFromEvents(res.Events)
+	require.NoError(t, err)
+	require.NoError(t, path.RelayPacket(packet))
+
+	// then the token trace is removed on chain B
+	require.False(t, keeperB.HasTokenTrace(chainB.GetContext(), receivedClassID, "t1"))
+	// and the native class is not traced on chain A
+	assert.Empty(t, allClassTraces(chainA))
+	assert.Empty(t, allTokenTraces(chainA))
+}
+
+func TestICS721ContractReceivesTokens(t *testing.T) {
+	successAck := channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+	specs := map[string]struct {
+		classID        string
+		recvResult     []byte
+		notConfigured  bool
+		classTraces    []wasmtypes.ClassTrace
+		expAsync       bool
+		expSuccess     bool
+		expClassTraces []wasmtypes.ClassTrace
+		expTokenTraces []wasmtypes.TokenTrace
+	}{
+		"foreign class received": {
+			classID:        "myClass",
+			expSuccess:     true,
+			expClassTraces: []wasmtypes.ClassTrace{{Path: "nft-transfer/channel-0", BaseClassID: "myClass"}},
+			expTokenTraces: []wasmtypes.TokenTrace{
+				{ClassID: "nft-transfer/channel-0/myClass", TokenID: "t1"},
+				{ClassID: "nft-transfer/channel-0/myClass", TokenID: "t2"},
+			},
+		},
+		"foreign class received over multiple hops": {
+			classID:        "nft-transfer/channel-7/myClass",
+			expSuccess:     true,
+			expClassTraces: []wasmtypes.ClassTrace{{Path: "nft-transfer/channel-0/nft-transfer/channel-7", BaseClassID: "myClass"}},
+			expTokenTraces: []wasmtypes.TokenTrace{
+				{ClassID: "nft-transfer/channel-0/nft-transfer/channel-7/myClass", TokenID: "t1"},
+				{ClassID: "nft-transfer/channel-0/nft-transfer/channel-7/myClass", TokenID: "t2"},
+			},
+		},
+		"native class returned": {
+			classID:    "nft-transfer/channel-0/myClass",
+			expSuccess: true,
+		},
+		"foreign class returned": {
+			classID:     "nft-transfer/channel-0/nft-transfer/channel-3/myClass",
+			classTraces: []wasmtypes.ClassTrace{{Path: "nft-transfer/channel-3", BaseClassID: "myClass"}},
+			expSuccess:  true,
+			expTokenTraces: []wasmtypes.TokenTrace{
+				{ClassID: "nft-transfer/channel-3/myClass", TokenID: "t1"},
+				{ClassID: "nft-transfer/channel-3/myClass", TokenID: "t2"},
+			},
+		},
+		"error ack": {
+			classID:    "myClass",
+			recvResult: mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Err: "testing"}),
+		},
+		"contract error": {
+			classID: "myClass",
+			// the contract has no funds to send
+			recvResult: mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{
+				Acknowledgement: successAck,
+				Messages: []wasmvmtypes.SubMsg{{
+					Msg:     wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: "meme1", Amount: wasmvmtypes.Array[wasmvmtypes.Coin]{wasmvmtypes.NewCoin(1, sdk.DefaultBondDenom)}}}},
+					ReplyOn: wasmvmtypes.ReplyNever,
+				}},
+			}}),
+		},
+		"async ack": {
+			classID:    "myClass",
+			recvResult: mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{}}),
+			expAsync:   true,
+		},
+		"contract not configured": {
+			classID:       "myClass",
+			notConfigured: true,
+		},
+		"class id too long with trace path": {
+			classID: strings.Repeat("a", wasmtypes.MaxClassIDLength-len("nft-transfer/channel-0/")+1),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			coord, chainA, chainB := setupIBCCoordinator(t)
+			timeout := coord.CurrentTime.Add(time.Hour)
+			contractA := storeAndInstantiate(t, chainA, staticResponseContract(ibcContractResponses(t, sendNFTResult(t, timeout, spec.classID, "t1", "t2"), successAck)))
+			responsesB := ibcContractResponses(t, wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, successAck)
+			if spec.recvResult != nil {
+				responsesB["ibc_packet_receive"] = spec.recvResult
+			}
+			contractB := storeAndInstantiate(t, chainB, staticResponseContract(responsesB))
+			setICS721Contract(t, coord, chainA, contractA)
+			setICS721Contract(t, coord, chainB, contractB)
+			for _, trace := range spec.classTraces {
+				wasmApp(chainB).wasmKeeper.SetClassTrace(chainB.GetContext(), trace)
+			}
+			coord.CommitBlock(chainB)
+			path := setupICS721Path(coord, chainA, chainB)
+			if spec.notConfigured {
+				// the contract is removed after the channel was opened
+				setICS721Contract(t, coord, chainB, nil)
+			}
+
+			res, err := chainA.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainA.SenderAccount.GetAddress().String(), Contract: contractA.String(), Msg: []byte(`{}`)})
+			require.NoError(t, err)
+			packet, err := ibctesting.ParsePacketFromEvents(res.Events)
+			require.NoError(t, err)
+			require.NoError(t, path.EndpointB.UpdateClient())
+
+			// when
+			res, err = path.EndpointB.RecvPacketWithResult(packet)
+
+			// then
+			require.NoError(t, err)
+			ackBz, err := ibctesting.ParseAckFromEvents(res.Events)
+			if spec.expAsync {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				var ack channeltypes.Acknowledgement
+				require.NoError(t, channeltypes.SubModuleCdc.UnmarshalJSON(ackBz, &ack))
+				assert.Equal(t, spec.expSuccess, ack.Success())
+			}
+			expClassTraces := append(spec.classTraces, spec.expClassTraces...)
+			assert.ElementsMatch(t, expClassTraces, allClassTraces(chainB))
+			assert.ElementsMatch(t, spec.expTokenTraces, allTokenTraces(chainB))
+		})
+	}
+}
+
+func TestICS721ContractAcknowledgesTokens(t *testing.T) {
+	const classID = "nft-transfer/channel-0/myClass"
+	allTokens := []wasmtypes.TokenTrace{
+		{ClassID: classID, TokenID: "t1"},
+		{ClassID: classID, TokenID: "t2"},
+		{ClassID: classID, TokenID: "t3"},
+	}
+	specs := map[string]struct {
+		recvResult     []byte
+		ackResult      []byte
+		expErr         bool
+		expTokenTraces []wasmtypes.TokenTrace
+	}{
+		"success ack": {
+			recvResult:     mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()}}),
+			expTokenTraces: []wasmtypes.TokenTrace{{ClassID: classID, TokenID: "t3"}},
+		},
+		"error ack": {
+			recvResult:     mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Err: "testing"}),
+			expTokenTraces: allTokens,
+		},
+		"contract error": {
+			recvResult:     mustMarshalJSON(t, wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: channeltypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()}}),
+			ackResult:      mustMarshalJSON(t, wasmvmtypes.IBCBasicResult{Err: "testing"}),
+			expErr:         true,
+			expTokenTraces: allTokens,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			coord, chainA, chainB := setupIBCCoordinator(t)
+			timeout := coord.CurrentTime.Add(time.Hour)
+			responsesA := ibcContractResponses(t, wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, nil)
+			responsesA["ibc_packet_receive"] = spec.recvResult
+			contractA := storeAndInstantiate(t, chainA, staticResponseContract(responsesA))
+			responsesB := ibcContractResponses(t, sendNFTResult(t, timeout, classID, "t1", "t2"), nil)
+			if spec.ackResult != nil {
+				responsesB["ibc_packet_ack"] = spec.ackResult
+			}
+			contractB := storeAndInstantiate(t, chainB, staticResponseContract(responsesB))
+			setICS721Contract(t, coord, chainA, contractA)
+			setICS721Contract(t, coord, chainB, contractB)
+			for _, trace := range allTokens {
+				wasmApp(chainB).wasmKeeper.SetTokenTrace(chainB.GetContext(), trace)
+			}
+			coord.CommitBlock(chainB)
+			path := setupICS721Path(coord, chainA, chainB)
+
+			res, err := chainB.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainB.SenderAccount.GetAddress().String(), Contract: contractB.String(), Msg: []byte(`{}`)})
+			require.NoError(t, err)
+			packet, err := ibctesting.ParsePacketFromEvents(res.Events)
+			require.NoError(t, err)
+
+			// when
+			gotErr := path.RelayPacket(packet)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			assert.ElementsMatch(t, spec.expTokenTraces, allTokenTraces(chainB))
+		})
+	}
+}
+
+func TestICS721HandlerRejectsOtherPorts(t *testing.T) {
+	_, chainA, _ := setupIBCCoordinator(t)
+	contractAddr := wasmkeeper.BuildContractAddress(1, 1)
+	ics721Handler, ok := wasmApp(chainA).ibcKeeper.Router.GetRoute(wasmtypes.ICS721RouteKey)
+	require.True(t, ok)
+	data := mustMarshalJSON(t, wasmtypes.NonFungibleTokenPacketData{ClassID: "myClass", TokenIDs: []string{"t1"}, Sender: "sender", Receiver: "receiver"})
+	packet := channeltypes.NewPacket(data, 1, wasmtypes.ICS721PortID, "channel-0", "wasm."+contractAddr.String(), "channel-1", clienttypes.NewHeight(1, 100), 0)
+
+	gotAck := ics721Handler.OnRecvPacket(chainA.GetContext(), packet, nil)
+	require.NotNil(t, gotAck)
+	assert.False(t, gotAck.Success())
+}
+
+func TestContractReceivesIBCTransferCallbacks(t *testing.T) {
+	coord, chainA, chainB := setupIBCCoordinator(t)
+
+	// the contract is the first instance of the first code on both chains
+	contractAddr := wasmkeeper.BuildContractAddress(1, 1)
+	memo := fmt.Sprintf(`{"src_callback":{"address":%q},"dest_callback":{"address":%q}}`, contractAddr.String(), contractAddr.String())
+	transfer := wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{
+		Msg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{
+			ChannelID: "channel-0",
+			ToAddress: contractAddr.String(),
+			Amount:    wasmvmtypes.NewCoin(100, sdk.DefaultBondDenom),
+			Timeout:   wasmvmtypes.IBCTimeout{Timestamp: uint64(coord.CurrentTime.Add(time.Hour).UnixNano())},
+			Memo:      memo,
+		}}},
+		ReplyOn: wasmvmtypes.ReplyNever,
+	}}}}
+	callbackResult := func(value string) []byte {
+		return mustMarshalJSON(t, wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{
+			Attributes: []wasmvmtypes.EventAttribute{{Key: "callback", Value: value}},
+		}})
+	}
+	wasmCode := staticResponseContract(map[string][]byte{
+		"instantiate":              mustMarshalJSON(t, wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}),
+		"execute":                  mustMarshalJSON(t, transfer),
+		"ibc_source_callback":      callbackResult("source"),
+		"ibc_destination_callback": callbackResult("destination"),
+	})
+	for _, chain := range []*ibctesting.TestChain{chainA, chainB} {
+		require.Equal(t, contractAddr, storeAndInstantiate(t, chain, wasmCode))
+	}
+	_, err := chainA.SendMsgs(banktypes.NewMsgSend(chainA.SenderAccount.GetAddress(), contractAddr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))))
+	require.NoError(t, err)
+
+	path := ibctesting.NewTransferPath(chainA, chainB)
+	coord.Setup(path)
+
+	// when the contract sends a transfer with callbacks
+	res, err := chainA.SendMsgs(&wasmtypes.MsgExecuteContract{Sender: chainA.SenderAccount.GetAddress().String(), Contract: contractAddr.String(), Msg: []byte(`{}`)})
+	require.NoError(t, err)
+	packet, err := ibctesting.ParsePacketFromEvents(res.Events)
+	require.NoError(t, err)
+
+	// then the receiving contract gets the destination callback
+	require.NoError(t, path.EndpointB.UpdateClient())
+	res, err = path.EndpointB.RecvPacketWithResult(packet)
+	require.NoError(t, err)
+	assert.Equal(t, ibccallbackstypes.AttributeValueCallbackSuccess, eventAttribute(t, res.Events, ibccallbackstypes.EventTypeDestinationCallback, ibccallbackstypes.AttributeKeyCallbackResult))
+	assert.Equal(t, "destination", eventAttribute(t, res.Events, wasmtypes.WasmModuleEventType, "callback"))
+
+	// and the sending contract gets the source callback for the acknowledgement
+	ack, err := ibctesting.ParseAckFromEvents(res.Events)
+	require.NoError(t, err)
+	require.NoError(t, path.EndpointA.UpdateClient())
+	proof, proofHeight := chainB.QueryProof(host.PacketAcknowledgementKey(packet.DestinationPort, packet.DestinationChannel, packet.Sequence))
+	res, err = chainA.SendMsgs(channeltypes.NewMsgAcknowledgement(packet, ack, proof, proofHeight, chainA.SenderAccount.GetAddress().String()))
+	require.NoError(t, err)
+	assert.Equal(t, ibccallbackstypes.AttributeValueCallbackSuccess, eventAttribute(t, res.Events, ibccallbackstypes.EventTypeSourceCallback, ibccallbackstypes.AttributeKeyCallbackResult))
+	assert.Equal(t, "source", eventAttribute(t, res.Events, wasmtypes.WasmModuleEventType, "callback"))
+}
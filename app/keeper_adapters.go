@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 
+	"cosmossdk.io/errors"
 	abci "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
@@ -17,8 +18,13 @@ import (
 	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 	channelkeeper "github.com/cosmos/ibc-go/v8/modules/core/04-channel/keeper"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	portkeeper "github.com/cosmos/ibc-go/v8/modules/core/05-port/keeper"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 // AccountKeeperAdapter adapts SDK 0.50 AccountKeeper to wasmd expectations
@@ -186,51 +192,82 @@ func (d DistributionKeeperAdapter) DelegationRewards(c context.Context, req *dis
 	return d.querier.DelegationRewards(c, req)
 }
 
-// ChannelKeeperAdapter adapts SDK 0.50 ChannelKeeper to wasmd expectations
+// ChannelKeeperAdapter adapts SDK 0.50 ChannelKeeper to wasmd expectations. The channel
+// capabilities that IBC v8 requires are looked up in the scoped keeper of the contract ports,
+// or in the ICS-721 scoped keeper for the channels of the nft-transfer port.
 type ChannelKeeperAdapter struct {
 	*channelkeeper.Keeper
+	scopedKeeper       capabilitykeeper.ScopedKeeper
+	ics721ScopedKeeper capabilitykeeper.ScopedKeeper
 }
 
-func NewChannelKeeperAdapter(ck *channelkeeper.Keeper) ChannelKeeperAdapter {
-	return ChannelKeeperAdapter{Keeper: ck}
+func NewChannelKeeperAdapter(ck *channelkeeper.Keeper, sk, ics721sk capabilitykeeper.ScopedKeeper) ChannelKeeperAdapter {
+	return ChannelKeeperAdapter{Keeper: ck, scopedKeeper: sk, ics721ScopedKeeper: ics721sk}
 }
 
-// ChanCloseInit adapts by dropping the capability parameter
+// ChanCloseInit adapts by looking up the channel capability
 func (c ChannelKeeperAdapter) ChanCloseInit(ctx sdk.Context, portID, channelID string) error {
-	// In SDK 0.50/IBC v8, ChanCloseInit requires a capability parameter
-	// wasmd expects the old signature without capability
-	// We need to get the capability from the capability keeper
-	// For now, we pass nil as the capability - this may need adjustment
-	return c.Keeper.ChanCloseInit(ctx, portID, channelID, nil)
+	chanCap, err := c.channelCapability(ctx, portID, channelID)
+	if err != nil {
+		return err
+	}
+	return c.Keeper.ChanCloseInit(ctx, portID, channelID, chanCap)
 }
 
 // SendPacket adapts the signature - SDK 0.50 has a different signature than wasmd expects
 func (c ChannelKeeperAdapter) SendPacket(ctx sdk.Context, packet ibcexported.PacketI) error {
-	// In SDK 0.50/IBC v8, SendPacket has a different signature
-	// We need to extract the packet fields and call the keeper method
-	// Get channel capability - this is a simplified approach
-	// In production, the capability should be properly retrieved
-	_, err := c.Keeper.SendPacket(ctx, nil, packet.GetSourcePort(), packet.GetSourceChannel(),
+	chanCap, err := c.channelCapability(ctx, packet.GetSourcePort(), packet.GetSourceChannel())
+	if err != nil {
+		return err
+	}
+	_, err = c.Keeper.SendPacket(ctx, chanCap, packet.GetSourcePort(), packet.GetSourceChannel(),
 		packet.GetTimeoutHeight().(clienttypes.Height), packet.GetTimeoutTimestamp(), packet.GetData())
 	return err
 }
 
-// PortKeeperAdapter adapts SDK 0.50 PortKeeper to wasmd expectations
+// WriteAcknowledgement adapts by looking up the channel capability
+func (c ChannelKeeperAdapter) WriteAcknowledgement(ctx sdk.Context, packet ibcexported.PacketI, acknowledgement ibcexported.Acknowledgement) error {
+	chanCap, err := c.channelCapability(ctx, packet.GetDestPort(), packet.GetDestChannel())
+	if err != nil {
+		return err
+	}
+	return c.Keeper.WriteAcknowledgement(ctx, chanCap, packet, acknowledgement)
+}
+
+// channelCapability returns the capability of the channel that was claimed on channel open
+func (c ChannelKeeperAdapter) channelCapability(ctx sdk.Context, portID, channelID string) (*capabilitytypes.Capability, error) {
+	scopedKeeper := c.scopedKeeper
+	if portID == wasmtypes.ICS721PortID {
+		scopedKeeper = c.ics721ScopedKeeper
+	}
+	chanCap, ok := scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(portID, channelID))
+	if !ok {
+		return nil, errors.Wrapf(channeltypes.ErrChannelCapabilityNotFound, "port ID (%s) channel ID (%s)", portID, channelID)
+	}
+	return chanCap, nil
+}
+
+// PortKeeperAdapter adapts SDK 0.50 PortKeeper to wasmd expectations. The capabilities of the
+// bound ports are claimed by the scoped keeper so that IBC routes the port to the module.
 type PortKeeperAdapter struct {
 	*portkeeper.Keeper
+	scopedKeeper capabilitykeeper.ScopedKeeper
 }
 
-func NewPortKeeperAdapter(pk *portkeeper.Keeper) PortKeeperAdapter {
-	return PortKeeperAdapter{Keeper: pk}
+func NewPortKeeperAdapter(pk *portkeeper.Keeper, sk capabilitykeeper.ScopedKeeper) PortKeeperAdapter {
+	return PortKeeperAdapter{Keeper: pk, scopedKeeper: sk}
 }
 
-// BindPort adapts return type from *Capability to error
+// BindPort adapts return type from *Capability to error and claims the port capability
 func (p PortKeeperAdapter) BindPort(ctx sdk.Context, portID string) error {
-	capability := p.Keeper.BindPort(ctx, portID)
-	if capability == nil {
-		return capabilitytypes.ErrCapabilityNotOwned
+	if _, ok := p.scopedKeeper.GetCapability(ctx, host.PortPath(portID)); ok {
+		return errors.Wrapf(porttypes.ErrPortExists, "port %s", portID)
+	}
+	if p.Keeper.IsBound(ctx, portID) {
+		return errors.Wrapf(porttypes.ErrInvalidPort, "port %s is bound by another module", portID)
 	}
-	return nil
+	portCap := p.Keeper.BindPort(ctx, portID)
+	return p.scopedKeeper.ClaimCapability(ctx, portCap, host.PortPath(portID))
 }
 
 // ICS20TransferPortSourceAdapter adapts to provide GetPort method
@@ -9,14 +9,49 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	consensuskeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	packetforwardtypes "github.com/cosmos/ibc-apps/middleware/packet-forward-middleware/v8/packetforward/types"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icahosttypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+
+	mincommissiontypes "github.com/CosmWasm/wasmd/x/mincommission/types"
+	"github.com/CosmWasm/wasmd/x/wasm"
 )
 
 // UpgradeName defines the on-chain upgrade name for the SDK 0.50 migration.
 // This must match the name used in the governance upgrade proposal.
 const UpgradeName = "sdk50"
 
+// ModulesUpgradeName defines the on-chain upgrade name that adds the modules
+// introduced after the SDK 0.50 migration: x/mincommission, the interchain
+// accounts controller and host, the packet forward middleware and the ICS-721
+// nft-transfer port. They share a single plan because only one upgrade can be
+// applied at a height and each of them adds new stores.
+const ModulesUpgradeName = "modules"
+
+// ICAHostAllowMessages is the initial allowlist of msg types that interchain
+// accounts controlled by other chains can execute on this chain. It is the
+// allow_messages param of the ica host module and can be changed by governance.
+var ICAHostAllowMessages = []string{
+	sdk.MsgTypeURL(&banktypes.MsgSend{}),
+	sdk.MsgTypeURL(&stakingtypes.MsgDelegate{}),
+	sdk.MsgTypeURL(&stakingtypes.MsgUndelegate{}),
+	sdk.MsgTypeURL(&stakingtypes.MsgBeginRedelegate{}),
+	sdk.MsgTypeURL(&distrtypes.MsgWithdrawDelegatorReward{}),
+	sdk.MsgTypeURL(&distrtypes.MsgSetWithdrawAddress{}),
+	sdk.MsgTypeURL(&govv1.MsgVote{}),
+	sdk.MsgTypeURL(&ibctransfertypes.MsgTransfer{}),
+	sdk.MsgTypeURL(&wasm.MsgExecuteContract{}),
+}
+
 // RegisterUpgradeHandlers registers the upgrade handler that migrates state
 // from Cosmos SDK 0.47.x to 0.50.x. This includes:
 //   - Migrating consensus params from the legacy x/params subspace to the new
@@ -62,6 +97,45 @@ func (app *WasmApp) RegisterUpgradeHandlers() {
 		},
 	)
 
+	// The modules upgrade initializes the interchain accounts controller and
+	// host with the initial allowlist instead of the module defaults that allow
+	// all msg types, binds the nft-transfer port and raises the commission of
+	// every validator below the floor so that existing validators comply with
+	// the new governance parameter. ICS-721 packets are rejected until the
+	// ics721 contract is set in the wasm params by governance. RunMigrations
+	// initializes x/mincommission and the packet forward middleware with their
+	// default genesis.
+	app.upgradeKeeper.SetUpgradeHandler(
+		ModulesUpgradeName,
+		func(ctx context.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+			sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+			icaModule, ok := app.mm.Modules[icatypes.ModuleName].(ica.AppModule)
+			if !ok {
+				return nil, fmt.Errorf("unexpected %s module type", icatypes.ModuleName)
+			}
+			// skip InitGenesis as the module is initialized here
+			fromVM[icatypes.ModuleName] = icaModule.ConsensusVersion()
+			icaModule.InitModule(
+				sdkCtx,
+				icacontrollertypes.NewParams(true),
+				icahosttypes.NewParams(true, ICAHostAllowMessages),
+			)
+
+			if err := app.bindICS721Port(sdkCtx); err != nil {
+				return nil, err
+			}
+
+			// the default min commission rate applies until the module params
+			// are set by RunMigrations
+			if err := app.minCommissionKeeper.BumpValidatorCommissions(sdkCtx, &app.stakingKeeper); err != nil {
+				return nil, err
+			}
+
+			return app.mm.RunMigrations(ctx, app.configurator, fromVM)
+		},
+	)
+
 	// Configure the store loader to add new store keys that didn't exist in
 	// SDK 0.47. The "Consensus" and "crisis" store keys are new in SDK 0.50.
 	upgradeInfo, err := app.upgradeKeeper.ReadUpgradeInfoFromDisk()
@@ -75,4 +149,16 @@ func (app *WasmApp) RegisterUpgradeHandlers() {
 		}
 		app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(upgradeInfo.Height, &storeUpgrades))
 	}
+
+	if upgradeInfo.Name == ModulesUpgradeName && !app.upgradeKeeper.IsSkipHeight(upgradeInfo.Height) {
+		storeUpgrades := storetypes.StoreUpgrades{
+			Added: []string{
+				mincommissiontypes.StoreKey,
+				icacontrollertypes.StoreKey,
+				icahosttypes.StoreKey,
+				packetforwardtypes.StoreKey,
+			},
+		}
+		app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(upgradeInfo.Height, &storeUpgrades))
+	}
 }
@@ -172,6 +172,9 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 	appExporterFunc := makeAppExporter(encodingConfig)
 
 	server.AddCommands(rootCmd, app.DefaultNodeHome, appCreatorFunc, appExporterFunc, addModuleInitFlags)
+	if exportCmd, _, err := rootCmd.Find([]string{"export"}); err == nil {
+		wasm.AddExportFlags(exportCmd)
+	}
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
@@ -403,7 +406,6 @@ func makeAppExporter(encodingConfig params.EncodingConfig) servertypes.AppExport
 		}
 
 		loadLatest := height == -1
-		var emptyWasmOpts []wasm.Option
 		wasmApp = app.NewWasmApp(
 			logger,
 			db,
@@ -415,7 +417,7 @@ func makeAppExporter(encodingConfig params.EncodingConfig) servertypes.AppExport
 			encodingConfig,
 			app.GetEnabledProposals(),
 			appOpts,
-			emptyWasmOpts,
+			wasm.ReadExportOptions(appOpts),
 		)
 
 		if height != -1 {
@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// maxNestedMsgsDepth limits the recursion into wrapped messages like authz MsgExec
+const maxNestedMsgsDepth = 6
+
+// MinCommissionDecorator ante handler to reject validator creation or commission edits
+// with a commission rate below the min commission rate param.
+type MinCommissionDecorator struct {
+	keeper *Keeper
+}
+
+// NewMinCommissionDecorator constructor
+func NewMinCommissionDecorator(k *Keeper) MinCommissionDecorator {
+	return MinCommissionDecorator{keeper: k}
+}
+
+// AnteHandle checks all messages of the tx including the ones wrapped in an authz MsgExec.
+func (d MinCommissionDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	minRate := d.keeper.GetMinCommissionRate(ctx)
+	if err := checkMsgs(tx.GetMsgs(), minRate, 0); err != nil {
+		return ctx, err
+	}
+	return next(ctx, tx, simulate)
+}
+
+func checkMsgs(msgs []sdk.Msg, minRate math.LegacyDec, depth int) error {
+	if depth > maxNestedMsgsDepth {
+		return errors.Wrapf(sdkerrors.ErrUnauthorized, "max nested msgs depth %d exceeded", maxNestedMsgsDepth)
+	}
+	for _, m := range msgs {
+		switch msg := m.(type) {
+		case *stakingtypes.MsgCreateValidator:
+			if msg.Commission.Rate.LT(minRate) {
+				return errors.Wrapf(sdkerrors.ErrUnauthorized, "commission can't be lower than %s", minRate)
+			}
+		case *stakingtypes.MsgEditValidator:
+			if msg.CommissionRate != nil && msg.CommissionRate.LT(minRate) {
+				return errors.Wrapf(sdkerrors.ErrUnauthorized, "commission can't be lower than %s", minRate)
+			}
+		case *authz.MsgExec:
+			nested, err := msg.GetMessages()
+			if err != nil {
+				return err
+			}
+			if err := checkMsgs(nested, minRate, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+func setupKeeper(t *testing.T) (sdk.Context, Keeper) {
+	t.Helper()
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+	k := NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, "authority")
+	return ctx, k
+}
+
+func TestMinCommissionDecorator(t *testing.T) {
+	grantee := sdk.AccAddress([]byte("grantee_____________"))
+	createMsg := func(rate math.LegacyDec) sdk.Msg {
+		return &stakingtypes.MsgCreateValidator{Commission: stakingtypes.CommissionRates{Rate: rate}}
+	}
+	editMsg := func(rate *math.LegacyDec) sdk.Msg {
+		return &stakingtypes.MsgEditValidator{CommissionRate: rate}
+	}
+	execMsg := func(msgs ...sdk.Msg) sdk.Msg {
+		exec := authz.NewMsgExec(grantee, msgs)
+		return &exec
+	}
+	lowRate := math.LegacyNewDecWithPrec(1, 2)
+	floorRate := types.DefaultMinCommissionRate
+
+	specs := map[string]struct {
+		msgs   []sdk.Msg
+		expErr bool
+	}{
+		"create validator at floor": {
+			msgs: []sdk.Msg{createMsg(floorRate)},
+		},
+		"create validator below floor": {
+			msgs:   []sdk.Msg{createMsg(lowRate)},
+			expErr: true,
+		},
+		"edit validator without rate": {
+			msgs: []sdk.Msg{editMsg(nil)},
+		},
+		"edit validator below floor": {
+			msgs:   []sdk.Msg{editMsg(&lowRate)},
+			expErr: true,
+		},
+		"authz exec wrapped below floor": {
+			msgs:   []sdk.Msg{execMsg(editMsg(&lowRate))},
+			expErr: true,
+		},
+		"nested authz exec below floor": {
+			msgs:   []sdk.Msg{execMsg(execMsg(createMsg(lowRate)))},
+			expErr: true,
+		},
+		"authz exec wrapped at floor": {
+			msgs: []sdk.Msg{execMsg(createMsg(floorRate))},
+		},
+		"max nesting depth exceeded": {
+			msgs:   []sdk.Msg{execMsg(execMsg(execMsg(execMsg(execMsg(execMsg(execMsg(createMsg(floorRate))))))))},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, k := setupKeeper(t)
+			var nextCalled bool
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+			_, gotErr := NewMinCommissionDecorator(&k).AnteHandle(ctx, mockTx{msgs: spec.msgs}, false, next)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.True(t, nextCalled)
+		})
+	}
+}
+
+func TestMinCommissionDecoratorUsesParam(t *testing.T) {
+	ctx, k := setupKeeper(t)
+	require.NoError(t, k.SetParams(ctx, types.NewParams(math.LegacyNewDecWithPrec(10, 2))))
+
+	msg := &stakingtypes.MsgCreateValidator{Commission: stakingtypes.CommissionRates{Rate: types.DefaultMinCommissionRate}}
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) { return ctx, nil }
+	_, err := NewMinCommissionDecorator(&k).AnteHandle(ctx, mockTx{msgs: []sdk.Msg{msg}}, false, next)
+	require.Error(t, err)
+}
+
+type mockTx struct {
+	sdk.Tx
+	msgs []sdk.Msg
+}
+
+func (m mockTx) GetMsgs() []sdk.Msg {
+	return m.msgs
+}
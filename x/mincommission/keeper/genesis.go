@@ -0,0 +1,17 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+// InitGenesis sets the module params from the genesis state
+func InitGenesis(ctx sdk.Context, k *Keeper, data types.GenesisState) error {
+	return k.SetParams(ctx, data.Params)
+}
+
+// ExportGenesis returns the module state for export
+func ExportGenesis(ctx sdk.Context, k *Keeper) *types.GenesisState {
+	return &types.GenesisState{Params: k.GetParams(ctx)}
+}
@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+// Keeper manages the min commission params
+type Keeper struct {
+	storeKey storetypes.StoreKey
+	cdc      codec.Codec
+	// authority is the address capable of executing a MsgUpdateParams.
+	// Typically, this should be the x/gov module account.
+	authority string
+}
+
+// NewKeeper constructor
+func NewKeeper(cdc codec.Codec, storeKey storetypes.StoreKey, authority string) Keeper {
+	return Keeper{
+		storeKey:  storeKey,
+		cdc:       cdc,
+		authority: authority,
+	}
+}
+
+// GetAuthority returns the module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetParams returns the total set of min commission parameters.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	var params types.Params
+	bz := ctx.KVStore(k.storeKey).Get(types.ParamsKey)
+	if bz == nil {
+		return params
+	}
+	k.cdc.MustUnmarshal(bz, &params)
+	return params
+}
+
+// SetParams sets all min commission parameters.
+func (k Keeper) SetParams(ctx sdk.Context, ps types.Params) error {
+	if err := ps.ValidateBasic(); err != nil {
+		return err
+	}
+	bz, err := k.cdc.Marshal(&ps)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set(types.ParamsKey, bz)
+	return nil
+}
+
+// GetMinCommissionRate returns the current floor for validator commission rates.
+// The default rate is returned when no params were stored, yet.
+func (k Keeper) GetMinCommissionRate(ctx sdk.Context) math.LegacyDec {
+	if r := k.GetParams(ctx).MinCommissionRate; !r.IsNil() {
+		return r
+	}
+	return types.DefaultMinCommissionRate
+}
+
+// BumpValidatorCommissions raises the commission rate of all validators below the
+// current floor to the floor. The max rate is raised as well when it would be exceeded.
+func (k Keeper) BumpValidatorCommissions(ctx sdk.Context, stakingKeeper types.StakingKeeper) error {
+	minRate := k.GetMinCommissionRate(ctx)
+	validators, err := stakingKeeper.GetAllValidators(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range validators {
+		if v.Commission.Rate.GTE(minRate) {
+			continue
+		}
+		v.Commission.Rate = minRate
+		if v.Commission.MaxRate.LT(minRate) {
+			v.Commission.MaxRate = minRate
+		}
+		v.Commission.UpdateTime = ctx.BlockTime()
+		if err := stakingKeeper.SetValidator(ctx, v); err != nil {
+			return err
+		}
+		k.Logger(ctx).Info("bumped validator commission", "validator", v.OperatorAddress, "rate", minRate)
+	}
+	return nil
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+func TestGetMinCommissionRate(t *testing.T) {
+	ctx, k := setupKeeper(t)
+	// default when not set
+	assert.Equal(t, types.DefaultMinCommissionRate, k.GetMinCommissionRate(ctx))
+
+	// and when set
+	myRate := math.LegacyNewDecWithPrec(7, 2)
+	require.NoError(t, k.SetParams(ctx, types.NewParams(myRate)))
+	assert.Equal(t, myRate, k.GetMinCommissionRate(ctx))
+
+	// invalid params rejected
+	require.Error(t, k.SetParams(ctx, types.NewParams(math.LegacyNewDec(2))))
+}
+
+func TestBumpValidatorCommissions(t *testing.T) {
+	ctx, k := setupKeeper(t)
+	now := time.Now().UTC()
+	ctx = ctx.WithBlockTime(now)
+
+	validator := func(op string, rate, maxRate math.LegacyDec) stakingtypes.Validator {
+		return stakingtypes.Validator{
+			OperatorAddress: op,
+			Commission:      stakingtypes.NewCommission(rate, maxRate, math.LegacyNewDecWithPrec(1, 2)),
+		}
+	}
+	sk := &mockStakingKeeper{validators: []stakingtypes.Validator{
+		validator("below", math.LegacyZeroDec(), math.LegacyNewDecWithPrec(1, 2)),
+		validator("below_max_ok", math.LegacyNewDecWithPrec(1, 2), math.LegacyOneDec()),
+		validator("above", math.LegacyNewDecWithPrec(10, 2), math.LegacyOneDec()),
+	}}
+
+	require.NoError(t, k.BumpValidatorCommissions(ctx, sk))
+
+	floor := types.DefaultMinCommissionRate
+	require.Len(t, sk.updated, 2)
+	assert.Equal(t, "below", sk.updated[0].OperatorAddress)
+	assert.Equal(t, floor, sk.updated[0].Commission.Rate)
+	assert.Equal(t, floor, sk.updated[0].Commission.MaxRate)
+	assert.Equal(t, now, sk.updated[0].Commission.UpdateTime)
+	assert.Equal(t, "below_max_ok", sk.updated[1].OperatorAddress)
+	assert.Equal(t, floor, sk.updated[1].Commission.Rate)
+	assert.Equal(t, math.LegacyOneDec(), sk.updated[1].Commission.MaxRate)
+}
+
+type mockStakingKeeper struct {
+	validators []stakingtypes.Validator
+	updated    []stakingtypes.Validator
+}
+
+func (m *mockStakingKeeper) GetAllValidators(_ context.Context) ([]stakingtypes.Validator, error) {
+	return m.validators, nil
+}
+
+func (m *mockStakingKeeper) SetValidator(_ context.Context, v stakingtypes.Validator) error {
+	m.updated = append(m.updated, v)
+	return nil
+}
@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/mincommission/types"
+)
+
+var _ types.QueryServer = &grpcQuerier{}
+
+type grpcQuerier struct {
+	keeper *Keeper
+}
+
+// NewQuerier creates a new grpc querier instance
+func NewQuerier(k *Keeper) types.QueryServer {
+	return &grpcQuerier{keeper: k}
+}
+
+// Params returns the module params
+func (q grpcQuerier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.ErrInvalidRequest
+	}
+	params := q.keeper.GetParams(sdk.UnwrapSDKContext(c))
+	return &types.QueryParamsResponse{Params: params}, nil
+}
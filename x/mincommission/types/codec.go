@@ -0,0 +1,39 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// RegisterLegacyAminoCodec registers the module types on the given amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "mincommission/MsgUpdateParams", nil)
+}
+
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	// see wasm types.RegisterInterfaces: register with explicit type URLs
+	type customRegistry interface {
+		RegisterCustomTypeURL(iface interface{}, typeURL string, impl proto.Message)
+	}
+	cr, ok := registry.(customRegistry)
+	if !ok {
+		panic("InterfaceRegistry does not implement RegisterCustomTypeURL - cannot register mincommission messages")
+	}
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/meme.mincommission.v1.MsgUpdateParams", &MsgUpdateParams{})
+}
+
+var (
+	amino = codec.NewLegacyAmino()
+
+	// ModuleCdc references the global x/mincommission module codec.
+	ModuleCdc = codec.NewAminoCodec(amino)
+)
+
+func init() {
+	RegisterLegacyAminoCodec(amino)
+	cryptocodec.RegisterCrypto(amino)
+	amino.Seal()
+}
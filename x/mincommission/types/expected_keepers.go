@@ -0,0 +1,13 @@
+package types
+
+import (
+	"context"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// StakingKeeper defines a subset of methods implemented by the cosmos-sdk staking keeper
+type StakingKeeper interface {
+	GetAllValidators(ctx context.Context) ([]stakingtypes.Validator, error)
+	SetValidator(ctx context.Context, validator stakingtypes.Validator) error
+}
@@ -0,0 +1,11 @@
+package types
+
+// DefaultGenesisState returns the genesis state with default params
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// ValidateBasic validates the genesis state
+func (s GenesisState) ValidateBasic() error {
+	return s.Params.ValidateBasic()
+}
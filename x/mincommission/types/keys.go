@@ -0,0 +1,15 @@
+package types
+
+const (
+	// ModuleName is the name of the min commission module
+	ModuleName = "mincommission"
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	// RouterKey is the msg router key for the min commission module
+	RouterKey = ModuleName
+)
+
+// ParamsKey is the store key for the module params
+var ParamsKey = []byte{0x01}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultMinCommissionRate is the 5% floor that was enforced before the rate became a param
+var DefaultMinCommissionRate = math.LegacyNewDecWithPrec(5, 2)
+
+// NewParams constructor
+func NewParams(minCommissionRate math.LegacyDec) Params {
+	return Params{MinCommissionRate: minCommissionRate}
+}
+
+// DefaultParams returns default min commission parameters
+func DefaultParams() Params {
+	return NewParams(DefaultMinCommissionRate)
+}
+
+func (p Params) String() string {
+	out, err := yaml.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return string(out)
+}
+
+// ValidateBasic performs basic validation on min commission parameters.
+func (p Params) ValidateBasic() error {
+	switch r := p.MinCommissionRate; {
+	case r.IsNil():
+		return errors.Wrap(sdkerrors.ErrInvalidRequest, "min commission rate must not be empty")
+	case r.IsNegative():
+		return errors.Wrapf(sdkerrors.ErrInvalidRequest, "min commission rate must not be negative: %s", r)
+	case r.GT(math.LegacyOneDec()):
+		return errors.Wrapf(sdkerrors.ErrInvalidRequest, "min commission rate must not exceed 100%%: %s", r)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ const (
 	RouterKey                       = types.RouterKey
 	MaxWasmSize                     = types.MaxWasmSize
 	MaxLabelSize                    = types.MaxLabelSize
+	MaxSaltSize                     = types.MaxSaltSize
 	WasmModuleEventType             = types.WasmModuleEventType
 	AttributeKeyContractAddr        = types.AttributeKeyContractAddr
 	ProposalTypeStoreCode           = types.ProposalTypeStoreCode
@@ -52,6 +53,7 @@ var (
 	NewWasmCoins              = types.NewWasmCoins
 	DefaultWasmConfig         = types.DefaultWasmConfig
 	DefaultParams             = types.DefaultParams
+	ParamKeyTable             = types.ParamKeyTable
 	InitGenesis               = keeper.InitGenesis
 	ExportGenesis             = keeper.ExportGenesis
 	NewMessageHandler         = keeper.NewDefaultMessageHandler
@@ -61,6 +63,7 @@ var (
 	EncodeStakingMsg          = keeper.EncodeStakingMsg
 	EncodeWasmMsg             = keeper.EncodeWasmMsg
 	NewKeeper                 = keeper.NewKeeper
+	BuildContractAddress      = keeper.BuildContractAddress
 	NewLegacyQuerier          = keeper.NewLegacyQuerier
 	DefaultQueryPlugins       = keeper.DefaultQueryPlugins
 	BankQuerier               = keeper.BankQuerier
@@ -69,8 +72,13 @@ var (
 	WasmQuerier               = keeper.WasmQuerier
 	NewWasmProposalHandler    = keeper.NewWasmProposalHandler
 	NewQuerier                = keeper.Querier
+	NewDebugQuerier           = keeper.DebugQuerier
 	ContractFromPortID        = keeper.ContractFromPortID
 	WithWasmEngine            = keeper.WithWasmEngine
+	WithGenesisStreamDir      = keeper.WithGenesisStreamDir
+	WithGenesisStreamExport   = keeper.WithGenesisStreamExport
+	WithGenesisHistoryExport  = keeper.WithGenesisHistoryExport
+	WithUniqueContractLabels  = keeper.WithUniqueContractLabels
 	NewCountTXDecorator       = keeper.NewCountTXDecorator
 
 	// variable aliases
@@ -95,39 +103,55 @@ var (
 )
 
 type (
-	ProposalType                   = types.ProposalType
-	GenesisState                   = types.GenesisState
-	Code                           = types.Code
-	Contract                       = types.Contract
-	MsgStoreCode                   = types.MsgStoreCode
-	MsgStoreCodeResponse           = types.MsgStoreCodeResponse
-	MsgInstantiateContract         = types.MsgInstantiateContract
-	MsgInstantiateContractResponse = types.MsgInstantiateContractResponse
-	MsgExecuteContract             = types.MsgExecuteContract
-	MsgExecuteContractResponse     = types.MsgExecuteContractResponse
-	MsgMigrateContract             = types.MsgMigrateContract
-	MsgMigrateContractResponse     = types.MsgMigrateContractResponse
-	MsgUpdateAdmin                 = types.MsgUpdateAdmin
-	MsgUpdateAdminResponse         = types.MsgUpdateAdminResponse
-	MsgClearAdmin                  = types.MsgClearAdmin
-	MsgWasmIBCCall                 = types.MsgIBCSend
-	MsgClearAdminResponse          = types.MsgClearAdminResponse
-	MsgServer                      = types.MsgServer
-	Model                          = types.Model
-	CodeInfo                       = types.CodeInfo
-	ContractInfo                   = types.ContractInfo
-	CreatedAt                      = types.AbsoluteTxPosition
-	Config                         = types.WasmConfig
-	CodeInfoResponse               = types.CodeInfoResponse
-	MessageHandler                 = keeper.SDKMessageHandler
-	BankEncoder                    = keeper.BankEncoder
-	CustomEncoder                  = keeper.CustomEncoder
-	StakingEncoder                 = keeper.StakingEncoder
-	WasmEncoder                    = keeper.WasmEncoder
-	MessageEncoders                = keeper.MessageEncoders
-	Keeper                         = keeper.Keeper
-	QueryHandler                   = keeper.QueryHandler
-	CustomQuerier                  = keeper.CustomQuerier
-	QueryPlugins                   = keeper.QueryPlugins
-	Option                         = keeper.Option
+	ProposalType                    = types.ProposalType
+	GenesisState                    = types.GenesisState
+	Code                            = types.Code
+	Contract                        = types.Contract
+	MsgStoreCode                    = types.MsgStoreCode
+	MsgStoreCodeResponse            = types.MsgStoreCodeResponse
+	MsgInstantiateContract          = types.MsgInstantiateContract
+	MsgInstantiateContractResponse  = types.MsgInstantiateContractResponse
+	MsgInstantiateContract2         = types.MsgInstantiateContract2
+	MsgInstantiateContract2Response = types.MsgInstantiateContract2Response
+	MsgExecuteContract              = types.MsgExecuteContract
+	MsgExecuteContractResponse      = types.MsgExecuteContractResponse
+	MsgMigrateContract              = types.MsgMigrateContract
+	MsgMigrateContractResponse      = types.MsgMigrateContractResponse
+	MsgUpdateAdmin                  = types.MsgUpdateAdmin
+	MsgUpdateAdminResponse          = types.MsgUpdateAdminResponse
+	MsgClearAdmin                   = types.MsgClearAdmin
+	MsgWasmIBCCall                  = types.MsgIBCSend
+	MsgClearAdminResponse           = types.MsgClearAdminResponse
+	MsgUpdateContractLabel          = types.MsgUpdateContractLabel
+	MsgUpdateInstantiateConfig      = types.MsgUpdateInstantiateConfig
+	MsgUpdateParams                 = types.MsgUpdateParams
+	MsgUpdateParamsResponse         = types.MsgUpdateParamsResponse
+	MsgSudoContract                 = types.MsgSudoContract
+	MsgSudoContractResponse         = types.MsgSudoContractResponse
+	MsgPinCodes                     = types.MsgPinCodes
+	MsgPinCodesResponse             = types.MsgPinCodesResponse
+	MsgUnpinCodes                   = types.MsgUnpinCodes
+	MsgUnpinCodesResponse           = types.MsgUnpinCodesResponse
+	MsgRemoveCodes                  = types.MsgRemoveCodes
+	MsgRemoveCodesResponse          = types.MsgRemoveCodesResponse
+	MsgStoreAndInstantiateContract  = types.MsgStoreAndInstantiateContract
+	MsgStoreAndMigrateContract      = types.MsgStoreAndMigrateContract
+	MsgServer                       = types.MsgServer
+	Model                           = types.Model
+	CodeInfo                        = types.CodeInfo
+	ContractInfo                    = types.ContractInfo
+	CreatedAt                       = types.AbsoluteTxPosition
+	Config                          = types.WasmConfig
+	CodeInfoResponse                = types.CodeInfoResponse
+	MessageHandler                  = keeper.SDKMessageHandler
+	BankEncoder                     = keeper.BankEncoder
+	CustomEncoder                   = keeper.CustomEncoder
+	StakingEncoder                  = keeper.StakingEncoder
+	WasmEncoder                     = keeper.WasmEncoder
+	MessageEncoders                 = keeper.MessageEncoders
+	Keeper                          = keeper.Keeper
+	QueryHandler                    = keeper.QueryHandler
+	CustomQuerier                   = keeper.CustomQuerier
+	QueryPlugins                    = keeper.QueryPlugins
+	Option                          = keeper.Option
 )
@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -35,10 +36,64 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
 		GetCmdListPinnedCode(),
+		GetCmdListUnreferencedCode(),
+		GetCmdBuildAddress(),
+		GetCmdQueryCodeInfoByChecksum(),
+		GetCmdListContractsByCreator(),
+		GetCmdListContractsByLabel(),
+		GetCmdQueryClassTrace(),
+		GetCmdListClassTraces(),
+		GetCmdQueryTokenTrace(),
+		GetCmdQueryTxTrace(),
+		GetCmdQueryGasSchedule(),
 	)
 	return queryCmd
 }
 
+// GetCmdBuildAddress build a contract address
+func GetCmdBuildAddress() *cobra.Command {
+	decoder := newArgDecoder(hex.DecodeString)
+	cmd := &cobra.Command{
+		Use:     "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)]",
+		Short:   "build contract address",
+		Aliases: []string{"address"},
+		Args:    cobra.RangeArgs(3, 4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			codeHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("code-hash: %s", err)
+			}
+			if len(codeHash) != 32 {
+				return errors.New("code-hash: must be 32 bytes")
+			}
+			creator, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return fmt.Errorf("creator: %s", err)
+			}
+			salt, err := decoder.DecodeString(args[2])
+			switch {
+			case err != nil:
+				return fmt.Errorf("salt: %s", err)
+			case len(salt) == 0:
+				return errors.New("empty salt")
+			}
+
+			if len(args) == 3 {
+				cmd.Println(keeper.BuildContractAddressPredictable(codeHash, creator, salt, []byte{}).String())
+				return nil
+			}
+			msg := types.RawContractMessage(args[3])
+			if err := msg.ValidateBasic(); err != nil {
+				return fmt.Errorf("init message: %s", err)
+			}
+			cmd.Println(keeper.BuildContractAddressPredictable(codeHash, creator, salt, msg).String())
+			return nil
+		},
+	}
+	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	return cmd
+}
+
 // GetCmdListCode lists all wasm code uploaded
 func GetCmdListCode() *cobra.Command {
 	cmd := &cobra.Command{
@@ -117,6 +172,130 @@ func GetCmdListContractByCode() *cobra.Command {
 	return cmd
 }
 
+// GetCmdListContractsByCreator lists all contracts instantiated by the given creator address
+func GetCmdListContractsByCreator() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-contracts-by-creator [creator]",
+		Short:   "List all contracts instantiated by the given creator address",
+		Long:    "List all contracts instantiated by the given creator address",
+		Aliases: []string{"lcc"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByCreator(
+				context.Background(),
+				&types.QueryContractsByCreatorRequest{
+					CreatorAddress: args[0],
+					Pagination:     pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list contracts by creator")
+	return cmd
+}
+
+// GetCmdListContractsByLabel lists all contracts with the given label
+func GetCmdListContractsByLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-contracts-by-label [label]",
+		Short:   "List all contracts with the given label",
+		Long:    "List all contracts with the given label",
+		Aliases: []string{"lcl"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractByLabel(
+				context.Background(),
+				&types.QueryContractByLabelRequest{
+					Label:      args[0],
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list contracts by label")
+	return cmd
+}
+
+// GetCmdQueryCodeInfoByChecksum lists the metadata of all wasm codes stored with the given checksum
+func GetCmdQueryCodeInfoByChecksum() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code-info-by-checksum [checksum]",
+		Short:   "Prints out metadata of all codes stored with the given hex encoded checksum",
+		Long:    "Prints out metadata of all codes stored with the given hex encoded checksum",
+		Aliases: []string{"code-by-checksum", "cbc"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			checksum, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("checksum: %s", err)
+			}
+			if len(checksum) != types.ChecksumLen {
+				return fmt.Errorf("checksum: expected %d bytes", types.ChecksumLen)
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeInfoByChecksum(
+				context.Background(),
+				&types.QueryCodeInfoByChecksumRequest{
+					Checksum:   args[0],
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "code info by checksum")
+	return cmd
+}
+
 // GetCmdQueryCode returns the bytecode for a given contract
 func GetCmdQueryCode() *cobra.Command {
 	cmd := &cobra.Command{
@@ -421,6 +600,42 @@ func GetCmdListPinnedCode() *cobra.Command {
 	return cmd
 }
 
+// GetCmdListUnreferencedCode lists all wasm codes without contract instances
+func GetCmdListUnreferencedCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unreferenced",
+		Short:   "List all codes without contract instances with their byte sizes",
+		Long:    "List all codes without contract instances with their byte sizes. These codes can be removed by governance.",
+		Aliases: []string{"list-unreferenced-codes", "luc"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.UnreferencedCodes(
+				context.Background(),
+				&types.QueryUnreferencedCodesRequest{
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list unreferenced codes")
+	return cmd
+}
+
 type argumentDecoder struct {
 	// dec is the default decoder
 	dec                func(string) ([]byte, error)
@@ -480,3 +695,161 @@ func withPageKeyDecoded(flagSet *flag.FlagSet) *flag.FlagSet {
 	}
 	return flagSet
 }
+
+// GetCmdQueryClassTrace queries the trace of an ICS-721 class
+func GetCmdQueryClassTrace() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "class-trace [class_id]",
+		Short: "Prints out the trace of an ICS-721 nft class received over IBC",
+		Long:  "Prints out the trace path and base class id of an ICS-721 nft class received over IBC",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ClassTrace(
+				context.Background(),
+				&types.QueryClassTraceRequest{
+					ClassId: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdListClassTraces lists the traces of all ICS-721 classes
+func GetCmdListClassTraces() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "class-traces",
+		Short: "List the traces of all ICS-721 nft classes received over IBC",
+		Long:  "List the traces of all ICS-721 nft classes received over IBC",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ClassTraces(
+				context.Background(),
+				&types.QueryClassTracesRequest{
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "class traces")
+	return cmd
+}
+
+// GetCmdQueryTokenTrace queries the trace of an ICS-721 token
+func GetCmdQueryTokenTrace() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token-trace [class_id] [token_id]",
+		Short: "Prints out the class trace of an ICS-721 nft received over IBC",
+		Long:  "Prints out the class trace of an ICS-721 nft that was received over IBC and is held on this chain",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.TokenTrace(
+				context.Background(),
+				&types.QueryTokenTraceRequest{
+					ClassId: args[0],
+					TokenId: args[1],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryTxTrace prints the contract calls of a tx that were traced by the node
+func GetCmdQueryTxTrace() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace [tx-hash]",
+		Short: "Prints out the contract calls with the gas used of a tx",
+		Long: "Prints out the tree of contract calls of a tx with the gas used, store reads and writes and events. " +
+			"The traces are node local and only available when the queried node has contract tracing enabled.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := hex.DecodeString(args[0]); err != nil {
+				return fmt.Errorf("tx hash: %s", err)
+			}
+
+			queryClient := types.NewDebugClient(clientCtx)
+			res, err := queryClient.TraceTx(
+				context.Background(),
+				&types.QueryTraceTxRequest{
+					TxHash: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryGasSchedule prints the gas schedule for contract interactions
+func GetCmdQueryGasSchedule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-schedule",
+		Short: "Prints out the gas schedule that is active for contract interactions",
+		Long:  "Prints out the gas schedule that is active for contract interactions. All costs are in SDK gas.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.GasSchedule(
+				context.Background(),
+				&types.QueryGasScheduleRequest{},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
@@ -3,6 +3,8 @@ package wasm
 import (
 	"testing"
 
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
@@ -32,3 +34,12 @@ func parseInitResponse(t *testing.T, data []byte) string {
 	require.NoError(t, err)
 	return addr
 }
+
+func callbacksTestContext(gasLimit storetypes.Gas) (sdk.Context, storetypes.StoreKey) {
+	storeKey := storetypes.NewKVStoreKey("test")
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	if gasLimit != 0 {
+		ctx = ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit))
+	}
+	return ctx, storeKey
+}
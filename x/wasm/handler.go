@@ -16,7 +16,7 @@ import (
 
 // NewHandler returns a handler for "wasm" type messages.
 // Note: In SDK 0.50+, the sdk.Handler type is removed. This function now returns keeper.Handler type.
-func NewHandler(k types.ContractOpsKeeper) keeper.Handler {
+func NewHandler(k *keeper.Keeper) keeper.Handler {
 	msgServer := keeper.NewMsgServerImpl(k)
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
@@ -31,6 +31,8 @@ func NewHandler(k types.ContractOpsKeeper) keeper.Handler {
 			res, err = msgServer.StoreCode(sdk.WrapSDKContext(ctx), msg)
 		case *MsgInstantiateContract:
 			res, err = msgServer.InstantiateContract(sdk.WrapSDKContext(ctx), msg)
+		case *MsgInstantiateContract2:
+			res, err = msgServer.InstantiateContract2(sdk.WrapSDKContext(ctx), msg)
 		case *MsgExecuteContract:
 			res, err = msgServer.ExecuteContract(sdk.WrapSDKContext(ctx), msg)
 		case *MsgMigrateContract:
@@ -39,6 +41,24 @@ func NewHandler(k types.ContractOpsKeeper) keeper.Handler {
 			res, err = msgServer.UpdateAdmin(sdk.WrapSDKContext(ctx), msg)
 		case *MsgClearAdmin:
 			res, err = msgServer.ClearAdmin(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateContractLabel:
+			res, err = msgServer.UpdateContractLabel(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateInstantiateConfig:
+			res, err = msgServer.UpdateInstantiateConfig(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateParams:
+			res, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), msg)
+		case *MsgSudoContract:
+			res, err = msgServer.SudoContract(sdk.WrapSDKContext(ctx), msg)
+		case *MsgPinCodes:
+			res, err = msgServer.PinCodes(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUnpinCodes:
+			res, err = msgServer.UnpinCodes(sdk.WrapSDKContext(ctx), msg)
+		case *MsgRemoveCodes:
+			res, err = msgServer.RemoveCodes(sdk.WrapSDKContext(ctx), msg)
+		case *MsgStoreAndInstantiateContract:
+			res, err = msgServer.StoreAndInstantiateContract(sdk.WrapSDKContext(ctx), msg)
+		case *MsgStoreAndMigrateContract:
+			res, err = msgServer.StoreAndMigrateContract(sdk.WrapSDKContext(ctx), msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized wasm message type: %T", msg)
 			return nil, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
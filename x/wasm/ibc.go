@@ -10,6 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
 	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
 
 	types "github.com/CosmWasm/wasmd/x/wasm/types"
@@ -20,10 +21,16 @@ var _ porttypes.IBCModule = IBCHandler{}
 type IBCHandler struct {
 	keeper        types.IBCContractKeeper
 	channelKeeper types.ChannelKeeper
+	// capabilityKeeper claims the capabilities of the channels that were opened on the port
+	capabilityKeeper types.CapabilityKeeper
+	// contractFromPortID resolves the contract that owns the port
+	contractFromPortID func(ctx sdk.Context, portID string) (sdk.AccAddress, error)
 }
 
-func NewIBCHandler(k types.IBCContractKeeper, ck types.ChannelKeeper) IBCHandler {
-	return IBCHandler{keeper: k, channelKeeper: ck}
+func NewIBCHandler(k types.IBCContractKeeper, ck types.ChannelKeeper, capKeeper types.CapabilityKeeper) IBCHandler {
+	return IBCHandler{keeper: k, channelKeeper: ck, capabilityKeeper: capKeeper, contractFromPortID: func(_ sdk.Context, portID string) (sdk.AccAddress, error) {
+		return ContractFromPortID(portID)
+	}}
 }
 
 // OnChanOpenInit implements the IBCModule interface
@@ -41,7 +48,7 @@ func (i IBCHandler) OnChanOpenInit(
 	if err := ValidateChannelParams(channelID); err != nil {
 		return "", err
 	}
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return "", errors.Wrapf(err, "contract port id")
 	}
@@ -61,6 +68,10 @@ func (i IBCHandler) OnChanOpenInit(
 	if err != nil {
 		return "", err
 	}
+	// Claim channel capability passed back by IBC module
+	if err := i.capabilityKeeper.ClaimCapability(ctx, chanCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
+		return "", errors.Wrap(err, "claim capability")
+	}
 	return version, nil
 }
 
@@ -79,7 +90,7 @@ func (i IBCHandler) OnChanOpenTry(
 		return "", err
 	}
 
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return "", errors.Wrapf(err, "contract port id")
 	}
@@ -101,6 +112,10 @@ func (i IBCHandler) OnChanOpenTry(
 	if err != nil {
 		return "", err
 	}
+	// Claim channel capability passed back by IBC module
+	if err := i.capabilityKeeper.ClaimCapability(ctx, chanCap, host.ChannelCapabilityPath(portID, channelID)); err != nil {
+		return "", errors.Wrap(err, "claim capability")
+	}
 	return counterpartyVersion, nil
 }
 
@@ -112,7 +127,7 @@ func (i IBCHandler) OnChanOpenAck(
 	counterpartyVersion string,
 ) error {
 	// Note: counterpartyChannelID parameter added for IBC-go v8 compatibility
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
@@ -131,7 +146,7 @@ func (i IBCHandler) OnChanOpenAck(
 
 // OnChanOpenConfirm implements the IBCModule interface
 func (i IBCHandler) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string) error {
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
@@ -149,7 +164,7 @@ func (i IBCHandler) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string)
 
 // OnChanCloseInit implements the IBCModule interface
 func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) error {
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
@@ -173,7 +188,7 @@ func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) e
 // OnChanCloseConfirm implements the IBCModule interface
 func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string) error {
 	// counterparty has closed the channel
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.contractFromPortID(ctx, portID)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
@@ -210,29 +225,23 @@ func (i IBCHandler) OnRecvPacket(
 	packet channeltypes.Packet,
 	relayer sdk.AccAddress,
 ) ibcexported.Acknowledgement {
-	contractAddr, err := ContractFromPortID(packet.DestinationPort)
+	contractAddr, err := i.contractFromPortID(ctx, packet.DestinationPort)
 	if err != nil {
 		return channeltypes.NewErrorAcknowledgement(errors.Wrapf(err, "contract port id"))
 	}
 	msg := wasmvmtypes.IBCPacketReceiveMsg{Packet: newIBCPacket(packet)}
 	ack, err := i.keeper.OnRecvPacket(ctx, contractAddr, msg)
 	if err != nil {
-		return channeltypes.NewErrorAcknowledgement(err)
+		// the contract state was not committed, error details are redacted in the ack
+		ack = channeltypes.NewErrorAcknowledgement(err)
 	}
-	return ContractConfirmStateAck(ack)
+	types.EmitAcknowledgementEvent(ctx, contractAddr, ack, err)
+	// a nil ack means that the contract writes the acknowledgement asynchronously
+	return ack
 }
 
-var _ ibcexported.Acknowledgement = ContractConfirmStateAck{}
-
-type ContractConfirmStateAck []byte
-
-func (w ContractConfirmStateAck) Success() bool {
-	return true // always commit state
-}
-
-func (w ContractConfirmStateAck) Acknowledgement() []byte {
-	return w
-}
+// ContractConfirmStateAck is kept for backwards compatibility
+type ContractConfirmStateAck = types.ContractConfirmStateAck
 
 // OnAcknowledgementPacket implements the IBCModule interface
 func (i IBCHandler) OnAcknowledgementPacket(
@@ -241,7 +250,7 @@ func (i IBCHandler) OnAcknowledgementPacket(
 	acknowledgement []byte,
 	relayer sdk.AccAddress,
 ) error {
-	contractAddr, err := ContractFromPortID(packet.SourcePort)
+	contractAddr, err := i.contractFromPortID(ctx, packet.SourcePort)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
@@ -258,7 +267,7 @@ func (i IBCHandler) OnAcknowledgementPacket(
 
 // OnTimeoutPacket implements the IBCModule interface
 func (i IBCHandler) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
-	contractAddr, err := ContractFromPortID(packet.SourcePort)
+	contractAddr, err := i.contractFromPortID(ctx, packet.SourcePort)
 	if err != nil {
 		return errors.Wrapf(err, "contract port id")
 	}
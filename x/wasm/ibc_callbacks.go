@@ -0,0 +1,42 @@
+package wasm
+
+import (
+	"cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// processCallback executes the contract callback with the given gas limit.
+// State changes are committed on success only. Errors are emitted as events and do not fail the packet lifecycle.
+// When the relayer did not provide enough gas to cover the callback gas limit, an out of gas panic is not
+// recovered so that the tx fails and the packet can be relayed again with more gas.
+func processCallback(ctx sdk.Context, callbackType string, contractAddr sdk.AccAddress, gasLimit uint64, callback func(ctx sdk.Context) error) {
+	relayerOutOfGas := false
+	if remaining := ctx.GasMeter().GasRemaining(); remaining < gasLimit {
+		gasLimit, relayerOutOfGas = remaining, true
+	}
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(storetypes.NewGasMeter(gasLimit))
+
+	err := runCallback(cacheCtx, callback, relayerOutOfGas)
+	ctx.GasMeter().ConsumeGas(cacheCtx.GasMeter().GasConsumedToLimit(), "ibc callback")
+	if err == nil {
+		writeCache()
+	}
+	types.EmitIBCCallbackEvent(ctx, callbackType, contractAddr, err)
+}
+
+func runCallback(ctx sdk.Context, callback func(ctx sdk.Context) error, relayerOutOfGas bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok || relayerOutOfGas {
+				panic(r)
+			}
+			err = errors.Wrap(sdkerrors.ErrOutOfGas, "callback")
+		}
+	}()
+	return callback(ctx)
+}
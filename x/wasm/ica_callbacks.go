@@ -0,0 +1,225 @@
+package wasm
+
+import (
+	"encoding/json"
+	"strings"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// callback types of the interchain account events
+const (
+	callbackTypeICAOpenAck  = "ica_open_ack"
+	callbackTypeICAResponse = "ica_response"
+	callbackTypeICATimeout  = "ica_timeout"
+)
+
+var (
+	_ porttypes.IBCModule        = ICAControllerCallbacksMiddleware{}
+	_ porttypes.UpgradableModule = ICAControllerCallbacksMiddleware{}
+)
+
+// ICAControllerCallbacksMiddleware wraps the interchain accounts controller and notifies contracts that own
+// an interchain account about the channel handshake and the tx results via sudo with an ICASudoMsg.
+// Contracts register accounts and send txs with the controller's MsgRegisterInterchainAccount and MsgSendTx.
+// Callbacks are executed with a limited amount of gas and their state changes are reverted on failure
+// without affecting the packet lifecycle.
+type ICAControllerCallbacksMiddleware struct {
+	app            porttypes.IBCModule
+	keeper         types.ICAControllerCallbackKeeper
+	maxCallbackGas uint64
+}
+
+// NewICAControllerCallbacksMiddleware constructor
+func NewICAControllerCallbacksMiddleware(
+	app porttypes.IBCModule,
+	k types.ICAControllerCallbackKeeper,
+	maxCallbackGas uint64,
+) ICAControllerCallbacksMiddleware {
+	if app == nil {
+		panic("underlying application cannot be nil")
+	}
+	if k == nil {
+		panic("contract keeper cannot be nil")
+	}
+	if maxCallbackGas == 0 {
+		panic("max callback gas cannot be zero")
+	}
+	return ICAControllerCallbacksMiddleware{app: app, keeper: k, maxCallbackGas: maxCallbackGas}
+}
+
+// OnChanOpenInit implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanOpenInit(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID string,
+	channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) (string, error) {
+	return m.app.OnChanOpenInit(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, version)
+}
+
+// OnChanOpenTry implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanOpenTry(
+	ctx sdk.Context,
+	order channeltypes.Order,
+	connectionHops []string,
+	portID, channelID string,
+	chanCap *capabilitytypes.Capability,
+	counterparty channeltypes.Counterparty,
+	counterpartyVersion string,
+) (string, error) {
+	return m.app.OnChanOpenTry(ctx, order, connectionHops, portID, channelID, chanCap, counterparty, counterpartyVersion)
+}
+
+// OnChanOpenAck implements the IBCModule interface. The owner contract is notified with the
+// address of the new interchain account.
+func (m ICAControllerCallbacksMiddleware) OnChanOpenAck(ctx sdk.Context, portID, channelID, counterpartyChannelID, counterpartyVersion string) error {
+	if err := m.app.OnChanOpenAck(ctx, portID, channelID, counterpartyChannelID, counterpartyVersion); err != nil {
+		return err
+	}
+	contractAddr, ok := m.ownerContract(ctx, portID)
+	if !ok {
+		return nil
+	}
+	openAck := &types.ICAOpenAck{
+		PortID:                portID,
+		ChannelID:             channelID,
+		CounterpartyChannelID: counterpartyChannelID,
+		CounterpartyVersion:   counterpartyVersion,
+	}
+	if metadata, err := icatypes.MetadataFromVersion(counterpartyVersion); err == nil {
+		openAck.Address = metadata.Address
+	}
+	m.sudo(ctx, callbackTypeICAOpenAck, contractAddr, types.ICACallback{OpenAck: openAck})
+	return nil
+}
+
+// OnChanOpenConfirm implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string) error {
+	return m.app.OnChanOpenConfirm(ctx, portID, channelID)
+}
+
+// OnChanCloseInit implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanCloseInit(ctx sdk.Context, portID, channelID string) error {
+	return m.app.OnChanCloseInit(ctx, portID, channelID)
+}
+
+// OnChanCloseConfirm implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string) error {
+	return m.app.OnChanCloseConfirm(ctx, portID, channelID)
+}
+
+// OnChanUpgradeInit implements the UpgradableModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanUpgradeInit(ctx sdk.Context, portID, channelID string, proposedOrder channeltypes.Order, proposedConnectionHops []string, proposedVersion string) (string, error) {
+	cbs, ok := m.app.(porttypes.UpgradableModule)
+	if !ok {
+		return "", errors.Wrap(porttypes.ErrInvalidRoute, "upgrade route not found to module in application callstack")
+	}
+	return cbs.OnChanUpgradeInit(ctx, portID, channelID, proposedOrder, proposedConnectionHops, proposedVersion)
+}
+
+// OnChanUpgradeTry implements the UpgradableModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanUpgradeTry(ctx sdk.Context, portID, channelID string, proposedOrder channeltypes.Order, proposedConnectionHops []string, counterpartyVersion string) (string, error) {
+	cbs, ok := m.app.(porttypes.UpgradableModule)
+	if !ok {
+		return "", errors.Wrap(porttypes.ErrInvalidRoute, "upgrade route not found to module in application callstack")
+	}
+	return cbs.OnChanUpgradeTry(ctx, portID, channelID, proposedOrder, proposedConnectionHops, counterpartyVersion)
+}
+
+// OnChanUpgradeAck implements the UpgradableModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanUpgradeAck(ctx sdk.Context, portID, channelID, counterpartyVersion string) error {
+	cbs, ok := m.app.(porttypes.UpgradableModule)
+	if !ok {
+		return errors.Wrap(porttypes.ErrInvalidRoute, "upgrade route not found to module in application callstack")
+	}
+	return cbs.OnChanUpgradeAck(ctx, portID, channelID, counterpartyVersion)
+}
+
+// OnChanUpgradeOpen implements the UpgradableModule interface
+func (m ICAControllerCallbacksMiddleware) OnChanUpgradeOpen(ctx sdk.Context, portID, channelID string, proposedOrder channeltypes.Order, proposedConnectionHops []string, proposedVersion string) {
+	cbs, ok := m.app.(porttypes.UpgradableModule)
+	if !ok {
+		panic(errors.Wrap(porttypes.ErrInvalidRoute, "upgrade route not found to module in application callstack"))
+	}
+	cbs.OnChanUpgradeOpen(ctx, portID, channelID, proposedOrder, proposedConnectionHops, proposedVersion)
+}
+
+// OnRecvPacket implements the IBCModule interface. The controller does not receive packets.
+func (m ICAControllerCallbacksMiddleware) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	return m.app.OnRecvPacket(ctx, packet, relayer)
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface. The owner contract is notified with
+// the tx result or the error returned by the host chain.
+func (m ICAControllerCallbacksMiddleware) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	if err := m.app.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	contractAddr, ok := m.ownerContract(ctx, packet.SourcePort)
+	if !ok {
+		return nil
+	}
+	var cb types.ICACallback
+	var ack channeltypes.Acknowledgement
+	switch err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); {
+	case err != nil:
+		cb.Error = &types.ICAError{Packet: newIBCPacket(packet), Details: "cannot unmarshal acknowledgement: " + err.Error()}
+	case ack.Success():
+		cb.Response = &types.ICAResponse{Packet: newIBCPacket(packet), Data: ack.GetResult()}
+	default:
+		cb.Error = &types.ICAError{Packet: newIBCPacket(packet), Details: ack.GetError()}
+	}
+	m.sudo(ctx, callbackTypeICAResponse, contractAddr, cb)
+	return nil
+}
+
+// OnTimeoutPacket implements the IBCModule interface
+func (m ICAControllerCallbacksMiddleware) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	if err := m.app.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	contractAddr, ok := m.ownerContract(ctx, packet.SourcePort)
+	if !ok {
+		return nil
+	}
+	m.sudo(ctx, callbackTypeICATimeout, contractAddr, types.ICACallback{Timeout: &types.ICATimeout{Packet: newIBCPacket(packet)}})
+	return nil
+}
+
+// sudo sends the callback to the contract with the max callback gas
+func (m ICAControllerCallbacksMiddleware) sudo(ctx sdk.Context, callbackType string, contractAddr sdk.AccAddress, cb types.ICACallback) {
+	msg, err := json.Marshal(types.ICASudoMsg{ICA: cb})
+	if err != nil {
+		panic(err) // can not happen with the static types
+	}
+	processCallback(ctx, callbackType, contractAddr, m.maxCallbackGas, func(ctx sdk.Context) error {
+		_, err := m.keeper.Sudo(ctx, contractAddr, msg)
+		return err
+	})
+}
+
+// ownerContract returns the contract address when the interchain account on the controller port is owned by a contract
+func (m ICAControllerCallbacksMiddleware) ownerContract(ctx sdk.Context, portID string) (sdk.AccAddress, bool) {
+	owner, ok := strings.CutPrefix(portID, icatypes.ControllerPortPrefix)
+	if !ok {
+		return nil, false
+	}
+	addr, err := sdk.AccAddressFromBech32(owner)
+	if err != nil || !m.keeper.HasContractInfo(ctx, addr) {
+		return nil, false
+	}
+	return addr, true
+}
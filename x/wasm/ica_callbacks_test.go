@@ -0,0 +1,216 @@
+package wasm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const testMaxCallbackGas = 1_000_000
+
+func TestICAControllerCallbacksOnAcknowledgementPacket(t *testing.T) {
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{2}, types.SDKAddrLen))
+	relayer := sdk.AccAddress(bytes.Repeat([]byte{3}, types.SDKAddrLen))
+	errAck := channeltypes.NewErrorAcknowledgement(errors.New("testing"))
+	specs := map[string]struct {
+		owner        sdk.AccAddress
+		ack          []byte
+		sudoErr      error
+		expCallback  *types.ICACallback
+		expCommitted bool
+	}{
+		"success ack to contract": {
+			owner: contractAddr,
+			ack:   channeltypes.NewResultAcknowledgement([]byte("myResult")).Acknowledgement(),
+			expCallback: &types.ICACallback{Response: &types.ICAResponse{
+				Data: []byte("myResult"),
+			}},
+			expCommitted: true,
+		},
+		"error ack to contract": {
+			owner: contractAddr,
+			ack:   errAck.Acknowledgement(),
+			expCallback: &types.ICACallback{Error: &types.ICAError{
+				Details: errAck.GetError(),
+			}},
+			expCommitted: true,
+		},
+		"sudo error reverts state": {
+			owner:   contractAddr,
+			ack:     channeltypes.NewResultAcknowledgement([]byte("myResult")).Acknowledgement(),
+			sudoErr: errors.New("testing"),
+			expCallback: &types.ICACallback{Response: &types.ICAResponse{
+				Data: []byte("myResult"),
+			}},
+		},
+		"owner not a contract": {
+			owner: otherAddr,
+			ack:   channeltypes.NewResultAcknowledgement([]byte("myResult")).Acknowledgement(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, storeKey := callbacksTestContext(0)
+			packet := icaPacketFixture(t, spec.owner)
+
+			var gotCallback *types.ICACallback
+			k := &mockICACallbackKeeper{
+				contracts: []sdk.AccAddress{contractAddr},
+				SudoFn: func(ctx sdk.Context, addr sdk.AccAddress, msg []byte) ([]byte, error) {
+					assert.Equal(t, contractAddr, addr)
+					var sudoMsg types.ICASudoMsg
+					require.NoError(t, json.Unmarshal(msg, &sudoMsg))
+					gotCallback = &sudoMsg.ICA
+					ctx.KVStore(storeKey).Set([]byte("foo"), []byte("bar"))
+					return nil, spec.sudoErr
+				},
+			}
+			m := NewICAControllerCallbacksMiddleware(mockControllerApp{}, k, testMaxCallbackGas)
+
+			// when
+			err := m.OnAcknowledgementPacket(ctx, packet, spec.ack, relayer)
+
+			// then
+			require.NoError(t, err)
+			if spec.expCallback == nil {
+				assert.Nil(t, gotCallback)
+				assert.Empty(t, ctx.EventManager().Events())
+				return
+			}
+			// the original packet is always passed
+			if spec.expCallback.Response != nil {
+				spec.expCallback.Response.Packet = newIBCPacket(packet)
+			}
+			if spec.expCallback.Error != nil {
+				spec.expCallback.Error.Packet = newIBCPacket(packet)
+			}
+			assert.Equal(t, spec.expCallback, gotCallback)
+			if spec.expCommitted {
+				assert.Equal(t, []byte("bar"), ctx.KVStore(storeKey).Get([]byte("foo")))
+			} else {
+				assert.Nil(t, ctx.KVStore(storeKey).Get([]byte("foo")))
+			}
+			events := ctx.EventManager().Events()
+			require.Len(t, events, 1)
+			assert.Equal(t, types.EventTypeIBCCallback, events[0].Type)
+		})
+	}
+}
+
+func TestICAControllerCallbacksOnTimeoutPacket(t *testing.T) {
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	ctx, _ := callbacksTestContext(0)
+	packet := icaPacketFixture(t, contractAddr)
+
+	var gotCallback *types.ICACallback
+	k := &mockICACallbackKeeper{
+		contracts: []sdk.AccAddress{contractAddr},
+		SudoFn: func(ctx sdk.Context, addr sdk.AccAddress, msg []byte) ([]byte, error) {
+			var sudoMsg types.ICASudoMsg
+			require.NoError(t, json.Unmarshal(msg, &sudoMsg))
+			gotCallback = &sudoMsg.ICA
+			return nil, nil
+		},
+	}
+	m := NewICAControllerCallbacksMiddleware(mockControllerApp{}, k, testMaxCallbackGas)
+
+	// when
+	err := m.OnTimeoutPacket(ctx, packet, contractAddr)
+
+	// then
+	require.NoError(t, err)
+	exp := &types.ICACallback{Timeout: &types.ICATimeout{Packet: newIBCPacket(packet)}}
+	assert.Equal(t, exp, gotCallback)
+}
+
+func TestICAControllerCallbacksOnChanOpenAck(t *testing.T) {
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	ctx, _ := callbacksTestContext(0)
+	portID, err := icatypes.NewControllerPortID(contractAddr.String())
+	require.NoError(t, err)
+	metadata := icatypes.NewMetadata(icatypes.Version, "connection-0", "connection-1", "myICAAddress", icatypes.EncodingProtobuf, icatypes.TxTypeSDKMultiMsg)
+	version := string(icatypes.ModuleCdc.MustMarshalJSON(&metadata))
+
+	var gotCallback *types.ICACallback
+	k := &mockICACallbackKeeper{
+		contracts: []sdk.AccAddress{contractAddr},
+		SudoFn: func(ctx sdk.Context, addr sdk.AccAddress, msg []byte) ([]byte, error) {
+			var sudoMsg types.ICASudoMsg
+			require.NoError(t, json.Unmarshal(msg, &sudoMsg))
+			gotCallback = &sudoMsg.ICA
+			return nil, nil
+		},
+	}
+	m := NewICAControllerCallbacksMiddleware(mockControllerApp{}, k, testMaxCallbackGas)
+
+	// when
+	err = m.OnChanOpenAck(ctx, portID, "channel-0", "channel-1", version)
+
+	// then
+	require.NoError(t, err)
+	exp := &types.ICACallback{OpenAck: &types.ICAOpenAck{
+		PortID:                portID,
+		ChannelID:             "channel-0",
+		CounterpartyChannelID: "channel-1",
+		CounterpartyVersion:   version,
+		Address:               "myICAAddress",
+	}}
+	assert.Equal(t, exp, gotCallback)
+}
+
+func icaPacketFixture(t *testing.T, owner sdk.AccAddress) channeltypes.Packet {
+	t.Helper()
+	portID, err := icatypes.NewControllerPortID(owner.String())
+	require.NoError(t, err)
+	data := icatypes.InterchainAccountPacketData{Type: icatypes.EXECUTE_TX, Data: []byte("myTx")}
+	return channeltypes.NewPacket(data.GetBytes(), 1, portID, "channel-0", icatypes.HostPortID, "channel-1", clienttypes.NewHeight(1, 100), 0)
+}
+
+type mockControllerApp struct {
+	porttypes.IBCModule
+}
+
+func (m mockControllerApp) OnChanOpenAck(ctx sdk.Context, portID, channelID, counterpartyChannelID, counterpartyVersion string) error {
+	return nil
+}
+
+func (m mockControllerApp) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	return nil
+}
+
+func (m mockControllerApp) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	return nil
+}
+
+type mockICACallbackKeeper struct {
+	contracts []sdk.AccAddress
+	SudoFn    func(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
+
+func (m *mockICACallbackKeeper) HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool {
+	for _, c := range m.contracts {
+		if c.Equals(contractAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockICACallbackKeeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+	if m.SudoFn == nil {
+		panic("not expected to be called")
+	}
+	return m.SudoFn(ctx, contractAddress, msg)
+}
@@ -0,0 +1,116 @@
+package wasm
+
+import (
+	"strings"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ porttypes.IBCModule = ICS721Handler{}
+
+// ICS721Handler routes the packets of the ICS-721 `nft-transfer` port to the contract that is
+// configured in the ICS721Contract param. The contract implements the ICS-721 protocol with
+// the IBC entry points like any other IBC contract, while the handler keeps track of the class
+// and token traces of the NFTs that were received from other chains.
+//
+// Traces are recorded for synchronous success acknowledgements only.
+type ICS721Handler struct {
+	IBCHandler
+	keeper types.ICS721Keeper
+}
+
+// NewICS721Handler constructor
+func NewICS721Handler(k types.ICS721Keeper, ck types.ChannelKeeper, capKeeper types.CapabilityKeeper) ICS721Handler {
+	h := NewIBCHandler(k, ck, capKeeper)
+	h.contractFromPortID = func(ctx sdk.Context, portID string) (sdk.AccAddress, error) {
+		if portID != types.ICS721PortID {
+			return nil, errors.Wrapf(types.ErrInvalid, "not the ics721 port: %s", portID)
+		}
+		ics721Contract := k.GetParams(ctx).ICS721Contract
+		if ics721Contract == "" {
+			return nil, errors.Wrap(types.ErrNotFound, "ics721 contract not configured")
+		}
+		return sdk.AccAddressFromBech32(ics721Contract)
+	}
+	return ICS721Handler{IBCHandler: h, keeper: k}
+}
+
+// OnRecvPacket implements the IBCModule interface. The traces of the received tokens are
+// stored when the contract acknowledged the packet successfully. Packets with a class id that
+// is too long to be traced are rejected before they reach the contract.
+func (h ICS721Handler) OnRecvPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+) ibcexported.Acknowledgement {
+	data, dataErr := types.ParseNonFungibleTokenPacketData(packet.GetData())
+	var classID string
+	if dataErr == nil {
+		classID = receivedClassID(packet, data.ClassID)
+		if err := types.ValidateClassIDLength(classID); err != nil {
+			return channeltypes.NewErrorAcknowledgement(err)
+		}
+	}
+	ack := h.IBCHandler.OnRecvPacket(ctx, packet, relayer)
+	if ack == nil || !ack.Success() || dataErr != nil {
+		// nothing to trace when the contract accepted a packet that is not ICS-721 data
+		return ack
+	}
+	if types.ReceiverChainIsSource(packet.SourcePort, packet.SourceChannel, data.ClassID) {
+		// the tokens return to this chain, the last hop was unwound
+		if _, ok := h.keeper.GetClassTrace(ctx, classID); !ok {
+			return ack // native class
+		}
+		h.setTokenTraces(ctx, classID, data.TokenIDs)
+		return ack
+	}
+	h.keeper.SetClassTrace(ctx, types.ParseClassTrace(classID))
+	h.setTokenTraces(ctx, classID, data.TokenIDs)
+	return ack
+}
+
+// receivedClassID returns the class id on this chain for the class id of a received packet
+func receivedClassID(packet channeltypes.Packet, classID string) string {
+	if types.ReceiverChainIsSource(packet.SourcePort, packet.SourceChannel, classID) {
+		return strings.TrimPrefix(classID, packet.SourcePort+"/"+packet.SourceChannel+"/")
+	}
+	return packet.DestinationPort + "/" + packet.DestinationChannel + "/" + classID
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface. The token traces are removed
+// when the tokens were sent to another chain successfully.
+func (h ICS721Handler) OnAcknowledgementPacket(
+	ctx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+) error {
+	if err := h.IBCHandler.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement, &ack); err != nil || !ack.Success() {
+		return nil
+	}
+	data, err := types.ParseNonFungibleTokenPacketData(packet.GetData())
+	if err != nil || types.ValidateClassIDLength(data.ClassID) != nil {
+		// no tokens of the class can be traced
+		return nil
+	}
+	for _, tokenID := range data.TokenIDs {
+		h.keeper.DeleteTokenTrace(ctx, data.ClassID, tokenID)
+	}
+	return nil
+}
+
+func (h ICS721Handler) setTokenTraces(ctx sdk.Context, classID string, tokenIDs []string) {
+	for _, tokenID := range tokenIDs {
+		h.keeper.SetTokenTrace(ctx, types.TokenTrace{ClassID: classID, TokenID: tokenID})
+	}
+}
@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildContractAddressPredictable(t *testing.T) {
+	myChecksum := bytes.Repeat([]byte{0x13}, 32)
+	myCreator := sdk.AccAddress(bytes.Repeat([]byte{0x9}, 20))
+	specs := map[string]struct {
+		checksum     []byte
+		creator      sdk.AccAddress
+		salt         []byte
+		initMsg      []byte
+		expectedAddr string
+		expPanic     bool
+	}{
+		"without init msg": {
+			checksum:     myChecksum,
+			creator:      myCreator,
+			salt:         []byte{0x61},
+			initMsg:      []byte{},
+			expectedAddr: "cosmos144ykdp4k9scsfrphclevtxr9ddfcxj5xxm66zmftu6hykxzcutzs8xglwp",
+		},
+		"with init msg": {
+			checksum:     myChecksum,
+			creator:      myCreator,
+			salt:         []byte{0x61},
+			initMsg:      []byte(`{}`),
+			expectedAddr: "cosmos19k7z3wedqtdzdhdrmk09h9v4srlzrkja7nlrq5f3vjvsepdqth5sr8h4dc",
+		},
+		"invalid checksum length": {
+			checksum: []byte{0x1},
+			creator:  myCreator,
+			salt:     []byte{0x61},
+			expPanic: true,
+		},
+		"empty salt": {
+			checksum: myChecksum,
+			creator:  myCreator,
+			expPanic: true,
+		},
+		"invalid init msg": {
+			checksum: myChecksum,
+			creator:  myCreator,
+			salt:     []byte{0x61},
+			initMsg:  []byte("not-json"),
+			expPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					BuildContractAddressPredictable(spec.checksum, spec.creator, spec.salt, spec.initMsg)
+				})
+				return
+			}
+			gotAddr := BuildContractAddressPredictable(spec.checksum, spec.creator, spec.salt, spec.initMsg)
+			require.NotNil(t, gotAddr)
+			assert.Nil(t, sdk.VerifyAddressFormat(gotAddr))
+			assert.Equal(t, spec.expectedAddr, gotAddr.String())
+		})
+	}
+}
+
+func TestPredicableAddressGenerator(t *testing.T) {
+	myChecksum := bytes.Repeat([]byte{0x13}, 32)
+	myCreator := sdk.AccAddress(bytes.Repeat([]byte{0x9}, 20))
+	mySalt := []byte{0x61}
+	myInitMsg := []byte(`{"foo":"bar"}`)
+
+	// when msg is not fixed, the init msg must not have any impact on the address
+	gotAddr := PredicableAddressGenerator(myCreator, mySalt, myInitMsg, false)(sdk.Context{}, 1, myChecksum)
+	assert.Equal(t, BuildContractAddressPredictable(myChecksum, myCreator, mySalt, []byte{}), gotAddr)
+
+	// when fixed, the init msg is part of the address
+	gotAddr = PredicableAddressGenerator(myCreator, mySalt, myInitMsg, true)(sdk.Context{}, 1, myChecksum)
+	assert.Equal(t, BuildContractAddressPredictable(myChecksum, myCreator, mySalt, myInitMsg), gotAddr)
+
+	// code id is not part of the address
+	otherAddr := PredicableAddressGenerator(myCreator, mySalt, myInitMsg, true)(sdk.Context{}, 2, myChecksum)
+	assert.Equal(t, gotAddr, otherAddr)
+}
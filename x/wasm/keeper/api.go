@@ -4,6 +4,8 @@ import (
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 const (
@@ -18,29 +20,34 @@ const (
 	DefaultDeserializationCostPerByte = 1
 )
 
-var (
-	costHumanize            = DefaultGasCostHumanAddress * DefaultGasMultiplier
-	costCanonical           = DefaultGasCostCanonicalAddress * DefaultGasMultiplier
-	costValidate            = DefaultGasCostValidateAddress * DefaultGasMultiplier
-	costJSONDeserialization = wasmvmtypes.UFraction{
-		Numerator:   DefaultDeserializationCostPerByte * DefaultGasMultiplier,
-		Denominator: 1,
+// apiCosts are the costs of the address api in CosmWasm gas
+type apiCosts struct {
+	humanize     uint64
+	canonicalize uint64
+}
+
+// newAPICosts returns the api costs for the gas schedule
+func newAPICosts(s types.GasSchedule) apiCosts {
+	return apiCosts{
+		humanize:     s.HumanizeAddressCost * s.GasMultiplier,
+		canonicalize: s.CanonicalizeAddressCost * s.GasMultiplier,
 	}
-)
+}
 
-func humanizeAddress(canon []byte) (string, uint64, error) {
+func (c apiCosts) humanizeAddress(canon []byte) (string, uint64, error) {
 	if err := sdk.VerifyAddressFormat(canon); err != nil {
-		return "", costHumanize, err
+		return "", c.humanize, err
 	}
-	return sdk.AccAddress(canon).String(), costHumanize, nil
+	return sdk.AccAddress(canon).String(), c.humanize, nil
 }
 
-func canonicalizeAddress(human string) ([]byte, uint64, error) {
+func (c apiCosts) canonicalizeAddress(human string) ([]byte, uint64, error) {
 	bz, err := sdk.AccAddressFromBech32(human)
-	return bz, costCanonical, err
+	return bz, c.canonicalize, err
 }
 
-func validateAddress(human string) (uint64, error) {
+func (c apiCosts) validateAddress(human string) (uint64, error) {
+	costValidate := c.humanize + c.canonicalize
 	canonicalized, err := sdk.AccAddressFromBech32(human)
 	if err != nil {
 		return costValidate, err
@@ -52,8 +59,18 @@ func validateAddress(human string) (uint64, error) {
 	return costValidate, nil
 }
 
-var cosmwasmAPI = wasmvm.GoAPI{
-	HumanizeAddress:     humanizeAddress,
-	CanonicalizeAddress: canonicalizeAddress,
-	ValidateAddress:     validateAddress,
+func (c apiCosts) goAPI() wasmvm.GoAPI {
+	return wasmvm.GoAPI{
+		HumanizeAddress:     c.humanizeAddress,
+		CanonicalizeAddress: c.canonicalizeAddress,
+		ValidateAddress:     c.validateAddress,
+	}
+}
+
+// newDeserializationCost returns the CosmWasm gas per byte of the gas schedule
+func newDeserializationCost(s types.GasSchedule) wasmvmtypes.UFraction {
+	return wasmvmtypes.UFraction{
+		Numerator:   s.DeserializationCostPerByte * s.GasMultiplier,
+		Denominator: 1,
+	}
 }
@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ types.DebugServer = &debugQuerier{}
+
+// debugQuerier serves the node local contract traces
+type debugQuerier struct {
+	tracer *contractTracer
+}
+
+// DebugQuerier creates a new grpc debug querier instance
+func DebugQuerier(k *Keeper) *debugQuerier { //nolint:revive
+	return &debugQuerier{tracer: k.tracer}
+}
+
+func (q debugQuerier) TraceTx(_ context.Context, req *types.QueryTraceTxRequest) (*types.QueryTraceTxResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if q.tracer == nil {
+		return nil, status.Error(codes.Unavailable, "contract tracing not enabled on this node")
+	}
+	trace, ok := q.tracer.TxTrace(strings.ToUpper(req.TxHash))
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no trace for tx")
+	}
+	return &types.QueryTraceTxResponse{Trace: trace}, nil
+}
@@ -0,0 +1,27 @@
+package keeper
+
+import (
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// errorDetailsSource provides the version of the error details registry that is used for the
+// errors passed to contracts
+type errorDetailsSource interface {
+	errorDetailsVersion(ctx sdk.Context) uint32
+}
+
+var _ errorDetailsSource = Keeper{}
+
+// errorDetailsVersion returns the error details registry version from the params
+func (k Keeper) errorDetailsVersion(ctx sdk.Context) uint32 {
+	// reading the params is not charged to the contract
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	return k.GetParams(ctx).ErrorDetailsVersion
+}
+
+// logRedactedError logs the full error node-locally. The log is keyed by the tx hash so that
+// operators can correlate it with the redacted error that was passed to the contract.
+func logRedactedError(ctx sdk.Context, msg string, err error) {
+	moduleLogger(ctx).Info(msg, "cause", err, "tx_hash", txHash(ctx), "height", ctx.BlockHeight())
+}
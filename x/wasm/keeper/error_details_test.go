@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRedactError(t *testing.T) {
+	specs := map[string]struct {
+		err     error
+		version uint32
+		exp     string
+	}{
+		"registered error": {
+			err:     errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "1stake is smaller than 2stake"),
+			version: 1,
+			exp:     "codespace: sdk, code: 5, details: insufficient funds",
+		},
+		"registered wasm error": {
+			err:     errorsmod.Wrap(types.ErrNotFound, "contract"),
+			version: 1,
+			exp:     "codespace: wasm, code: 8, details: not found",
+		},
+		"unregistered error": {
+			err:     errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "anything"),
+			version: 1,
+			exp:     "codespace: sdk, code: 18",
+		},
+		"details disabled": {
+			err:     errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "1stake is smaller than 2stake"),
+			version: 0,
+			exp:     "codespace: sdk, code: 5",
+		},
+		"unknown version": {
+			err:     errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "1stake is smaller than 2stake"),
+			version: types.LatestErrorDetailsVersion + 1,
+			exp:     "codespace: sdk, code: 5",
+		},
+		"non sdk error": {
+			err:     errors.New("non deterministic"),
+			version: 1,
+			exp:     "codespace: undefined, code: 1",
+		},
+		"system error not redacted": {
+			err:     wasmvmtypes.NoSuchContract{Addr: "myAddr"},
+			version: 1,
+			exp:     wasmvmtypes.NoSuchContract{Addr: "myAddr"}.Error(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, redactError(spec.err, spec.version).Error())
+		})
+	}
+}
+
+func TestDispatchSubmessagesErrorDetails(t *testing.T) {
+	specs := map[string]struct {
+		replyer replyer
+		expErr  string
+	}{
+		"with error details": {
+			replyer: &errorDetailsReplyer{version: 1},
+			expErr:  "codespace: sdk, code: 5, details: insufficient funds",
+		},
+		"without error details": {
+			replyer: &errorDetailsReplyer{version: 0},
+			expErr:  "codespace: sdk, code: 5",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ctx := submsgTestContext().WithTxBytes([]byte("myTx")).WithLogger(log.NewLogger(&buf))
+			messenger := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, _ sdk.AccAddress, _ string, _ wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+					return nil, nil, nil, errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "1stake is smaller than 2stake")
+				},
+			}
+			d := NewMessageDispatcher(messenger, spec.replyer)
+			msgs := []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyError}}
+
+			// when
+			_, err := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "", msgs)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expErr, spec.replyer.(*errorDetailsReplyer).got.Result.Err)
+			// the full error is logged with the tx hash
+			assert.Contains(t, buf.String(), "1stake is smaller than 2stake")
+			assert.Contains(t, buf.String(), "tx_hash=")
+		})
+	}
+}
+
+type errorDetailsReplyer struct {
+	version uint32
+	got     wasmvmtypes.Reply
+}
+
+func (r *errorDetailsReplyer) reply(_ sdk.Context, _ sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+	r.got = reply
+	return nil, nil
+}
+
+func (r *errorDetailsReplyer) errorDetailsVersion(sdk.Context) uint32 {
+	return r.version
+}
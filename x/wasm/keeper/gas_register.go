@@ -4,7 +4,9 @@ import (
 	"cosmossdk.io/errors"
 	sdkmath "cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -99,16 +101,75 @@ type WasmGasRegisterConfig struct {
 
 // DefaultGasRegisterConfig default values
 func DefaultGasRegisterConfig() WasmGasRegisterConfig {
-	return WasmGasRegisterConfig{
+	return NewGasRegisterConfig(DefaultGasSchedule())
+}
+
+// DefaultGasSchedule is the gas schedule that is used when none is set in the params
+func DefaultGasSchedule() types.GasSchedule {
+	return types.GasSchedule{
 		InstanceCost:               DefaultInstanceCost,
 		CompileCost:                DefaultCompileCost,
 		GasMultiplier:              DefaultGasMultiplier,
 		EventPerAttributeCost:      DefaultPerAttributeCost,
-		CustomEventCost:            DefaultPerCustomEventCost,
 		EventAttributeDataCost:     DefaultEventAttributeDataCost,
 		EventAttributeDataFreeTier: DefaultEventAttributeDataFreeTier,
 		ContractMessageDataCost:    DefaultContractMessageDataCost,
+		CustomEventCost:            DefaultPerCustomEventCost,
+		HumanizeAddressCost:        DefaultGasCostHumanAddress,
+		CanonicalizeAddressCost:    DefaultGasCostCanonicalAddress,
+		DeserializationCostPerByte: DefaultDeserializationCostPerByte,
+	}
+}
+
+// NewGasRegisterConfig returns the gas register config for the gas schedule
+func NewGasRegisterConfig(s types.GasSchedule) WasmGasRegisterConfig {
+	return WasmGasRegisterConfig{
+		InstanceCost:               s.InstanceCost,
+		CompileCost:                s.CompileCost,
+		GasMultiplier:              s.GasMultiplier,
+		EventPerAttributeCost:      s.EventPerAttributeCost,
+		CustomEventCost:            s.CustomEventCost,
+		EventAttributeDataCost:     s.EventAttributeDataCost,
+		EventAttributeDataFreeTier: s.EventAttributeDataFreeTier,
+		ContractMessageDataCost:    s.ContractMessageDataCost,
+	}
+}
+
+// GetGasSchedule returns the gas schedule from the params or the default gas schedule when
+// none is set. Changes by governance apply to all following contract interactions.
+func (k Keeper) GetGasSchedule(ctx sdk.Context) types.GasSchedule {
+	// reading the params is not charged to the contract
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	if s := k.GetParams(ctx).GasSchedule; s != nil {
+		return *s
+	}
+	return DefaultGasSchedule()
+}
+
+// runtimeCosts are the costs of the active gas schedule. They are resolved once per contract
+// entry point and passed down, so that the params are not read for every gas conversion.
+type runtimeCosts struct {
+	gasRegister     GasRegister
+	api             wasmvm.GoAPI
+	deserialization wasmvmtypes.UFraction
+}
+
+// runtimeCosts returns the costs of the active gas schedule with the custom gas register and
+// api costs set via the WithGasRegister and WithAPICosts options taking precedence
+func (k Keeper) runtimeCosts(ctx sdk.Context) runtimeCosts {
+	s := k.GetGasSchedule(ctx)
+	r := runtimeCosts{
+		gasRegister:     k.gasRegister,
+		api:             newAPICosts(s).goAPI(),
+		deserialization: newDeserializationCost(s),
+	}
+	if r.gasRegister == nil {
+		r.gasRegister = NewWasmGasRegister(NewGasRegisterConfig(s))
+	}
+	if k.apiCosts != nil {
+		r.api = k.apiCosts.goAPI()
 	}
+	return r
 }
 
 // WasmGasRegister implements GasRegister interface
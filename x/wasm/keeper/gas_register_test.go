@@ -9,6 +9,9 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestCompileCosts(t *testing.T) {
@@ -382,3 +385,77 @@ func TestFromWasmVMGasConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestGasScheduleFromParams(t *testing.T) {
+	customSchedule := DefaultGasSchedule()
+	customSchedule.InstanceCost = 1
+	customSchedule.GasMultiplier = 2
+	customSchedule.HumanizeAddressCost = 3
+	customSchedule.CanonicalizeAddressCost = 4
+	customSchedule.DeserializationCostPerByte = 5
+	specs := map[string]struct {
+		schedule        *types.GasSchedule
+		opts            []Option
+		expSchedule     types.GasSchedule
+		expInstanceCost storetypes.Gas
+		expAPICosts     [3]uint64
+		expDeserialize  uint64
+	}{
+		"default schedule": {
+			expSchedule:     DefaultGasSchedule(),
+			expInstanceCost: DefaultInstanceCost,
+			expAPICosts: [3]uint64{
+				DefaultGasCostHumanAddress * DefaultGasMultiplier,
+				DefaultGasCostCanonicalAddress * DefaultGasMultiplier,
+				DefaultGasCostValidateAddress * DefaultGasMultiplier,
+			},
+			expDeserialize: DefaultDeserializationCostPerByte * DefaultGasMultiplier,
+		},
+		"schedule from params": {
+			schedule:        &customSchedule,
+			expSchedule:     customSchedule,
+			expInstanceCost: 1,
+			expAPICosts:     [3]uint64{6, 8, 14},
+			expDeserialize:  10,
+		},
+		"custom gas register and api costs": {
+			schedule:        &customSchedule,
+			opts:            []Option{WithGasRegister(NewDefaultWasmGasRegister()), WithAPICosts(7, 8)},
+			expSchedule:     customSchedule,
+			expInstanceCost: DefaultInstanceCost,
+			expAPICosts:     [3]uint64{7, 8, 15},
+			expDeserialize:  10,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateDefaultTestInput(t)
+			k := keepers.WasmKeeper
+			for _, o := range spec.opts {
+				o.apply(k)
+			}
+			params := types.DefaultParams()
+			params.GasSchedule = spec.schedule
+			require.NoError(t, k.SetParams(ctx, params))
+			ctx = ctx.WithGasMeter(storetypes.NewGasMeter(1_000_000))
+
+			// when
+			gotSchedule := k.GetGasSchedule(ctx)
+			costs := k.runtimeCosts(ctx)
+			gotInstanceCost := costs.gasRegister.InstantiateContractCosts(false, 0)
+			api := costs.api
+			_, gotHumanize, _ := api.HumanizeAddress(make([]byte, types.ContractAddrLen))
+			_, gotCanonicalize, _ := api.CanonicalizeAddress("")
+			gotValidate, _ := api.ValidateAddress("")
+			gotDeserialize := costs.deserialization
+
+			// then
+			assert.Equal(t, spec.expSchedule, gotSchedule)
+			assert.Equal(t, spec.expInstanceCost, gotInstanceCost)
+			assert.Equal(t, spec.expAPICosts, [3]uint64{gotHumanize, gotCanonicalize, gotValidate})
+			assert.Equal(t, wasmvmtypes.UFraction{Numerator: spec.expDeserialize, Denominator: 1}, gotDeserialize)
+			// reading the params is not charged
+			assert.Equal(t, storetypes.Gas(0), ctx.GasMeter().GasConsumed())
+		})
+	}
+}
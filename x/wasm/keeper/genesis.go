@@ -23,34 +23,52 @@ type Handler func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error)
 // CONTRACT: all types of accounts must have been already initialized/created
 func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, stakingKeeper ValidatorSetSource, msgHandler Handler) ([]abci.ValidatorUpdate, error) {
 	contractKeeper := NewGovPermissionKeeper(keeper)
-	keeper.SetParams(ctx, data.Params)
+	if err := keeper.SetParams(ctx, data.Params); err != nil {
+		return nil, errors.Wrap(err, "set params")
+	}
 	var maxCodeID uint64
-	for i, code := range data.Codes {
+	importCode := func(code types.Code) error {
 		err := keeper.importCode(ctx, code.CodeID, code.CodeInfo, code.CodeBytes)
 		if err != nil {
-			return nil, errors.Wrapf(err, "code %d with id: %d", i, code.CodeID)
+			return err
 		}
 		if code.CodeID > maxCodeID {
 			maxCodeID = code.CodeID
 		}
 		if code.Pinned {
 			if err := contractKeeper.PinCode(ctx, code.CodeID); err != nil {
-				return nil, errors.Wrapf(err, "contract number %d", i)
+				return errors.Wrap(err, "pin")
 			}
 		}
+		return nil
+	}
+	for i, code := range data.Codes {
+		if err := importCode(code); err != nil {
+			return nil, errors.Wrapf(err, "code %d with id: %d", i, code.CodeID)
+		}
 	}
 
 	var maxContractID int
+	importContract := func(contractAddr sdk.AccAddress, contract types.Contract) error {
+		if err := keeper.importContract(ctx, contractAddr, &contract.ContractInfo, contract.ContractState, contract.ContractCodeHistory); err != nil {
+			return err
+		}
+		maxContractID++ // not ideal but max(contractID) is not persisted otherwise
+		return nil
+	}
+	if data.Stream != nil {
+		if err := keeper.importGenesisStream(ctx, *data.Stream, importCode, importContract); err != nil {
+			return nil, errors.Wrap(err, "stream")
+		}
+	}
 	for i, contract := range data.Contracts {
 		contractAddr, err := sdk.AccAddressFromBech32(contract.ContractAddress)
 		if err != nil {
 			return nil, errors.Wrapf(err, "address in contract number %d", i)
 		}
-		err = keeper.importContract(ctx, contractAddr, &contract.ContractInfo, contract.ContractState)
-		if err != nil {
+		if err := importContract(contractAddr, contract); err != nil {
 			return nil, errors.Wrapf(err, "contract number %d", i)
 		}
-		maxContractID = i + 1 // not ideal but max(contractID) is not persisted otherwise
 	}
 
 	for i, seq := range data.Sequences {
@@ -60,6 +78,13 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		}
 	}
 
+	for _, trace := range data.ClassTraces {
+		keeper.SetClassTrace(ctx, trace)
+	}
+	for _, trace := range data.TokenTraces {
+		keeper.SetTokenTrace(ctx, trace)
+	}
+
 	// sanity check seq values
 	seqVal := keeper.PeekAutoIncrementID(ctx, types.KeyLastCodeID)
 	if seqVal <= maxCodeID {
@@ -92,35 +117,39 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 
 	genState.Params = keeper.GetParams(ctx)
 
-	keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
-		bytecode, err := keeper.GetByteCode(ctx, codeID)
+	if keeper.genesisStreamExport != "" {
+		stream, err := keeper.exportGenesisStream(ctx, keeper.genesisStreamExport)
 		if err != nil {
-			panic(err)
+			panic(errors.Wrap(err, "genesis stream"))
 		}
-		genState.Codes = append(genState.Codes, types.Code{
-			CodeID:    codeID,
-			CodeInfo:  info,
-			CodeBytes: bytecode,
-			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+		genState.Stream = stream
+	} else {
+		keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+			bytecode, err := keeper.GetByteCode(ctx, codeID)
+			if err != nil {
+				panic(err)
+			}
+			genState.Codes = append(genState.Codes, types.Code{
+				CodeID:    codeID,
+				CodeInfo:  info,
+				CodeBytes: bytecode,
+				Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			})
+			return false
 		})
-		return false
-	})
 
-	keeper.IterateContractInfo(ctx, func(addr sdk.AccAddress, contract types.ContractInfo) bool {
-		var state []types.Model
-		keeper.IterateContractState(ctx, addr, func(key, value []byte) bool {
-			state = append(state, types.Model{Key: key, Value: value})
+		keeper.IterateContractInfo(ctx, func(addr sdk.AccAddress, contract types.ContractInfo) bool {
+			var state []types.Model
+			keeper.IterateContractState(ctx, addr, func(key, value []byte) bool {
+				state = append(state, types.Model{Key: key, Value: value})
+				return false
+			})
+			c := keeper.genesisContract(ctx, addr, contract)
+			c.ContractState = state
+			genState.Contracts = append(genState.Contracts, c)
 			return false
 		})
-		// redact contract info
-		contract.Created = nil
-		genState.Contracts = append(genState.Contracts, types.Contract{
-			ContractAddress: addr.String(),
-			ContractInfo:    contract,
-			ContractState:   state,
-		})
-		return false
-	})
+	}
 
 	for _, k := range [][]byte{types.KeyLastCodeID, types.KeyLastInstanceID} {
 		genState.Sequences = append(genState.Sequences, types.Sequence{
@@ -129,5 +158,27 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 		})
 	}
 
+	keeper.IterateClassTraces(ctx, func(trace types.ClassTrace) bool {
+		genState.ClassTraces = append(genState.ClassTraces, trace)
+		return false
+	})
+	keeper.IterateTokenTraces(ctx, func(trace types.TokenTrace) bool {
+		genState.TokenTraces = append(genState.TokenTraces, trace)
+		return false
+	})
+
 	return &genState
 }
+
+// genesisContract returns the contract without state for export. The created position is redacted
+// unless the full code history is exported.
+func (k Keeper) genesisContract(ctx sdk.Context, addr sdk.AccAddress, contract types.ContractInfo) types.Contract {
+	c := types.Contract{ContractAddress: addr.String(), ContractInfo: contract}
+	if k.genesisHistoryExport {
+		c.ContractCodeHistory = k.GetContractHistory(ctx, addr)
+	} else {
+		// redact contract info
+		c.ContractInfo.Created = nil
+	}
+	return c
+}
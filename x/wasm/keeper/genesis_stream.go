@@ -0,0 +1,268 @@
+package keeper
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"cosmossdk.io/errors"
+	"github.com/cosmos/gogoproto/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// genesisStreamMagic is the header of a genesis stream file. It is followed by the records
+// which are encoded as a kind byte, the uvarint length and the protobuf encoded payload.
+var genesisStreamMagic = []byte("wasmd-genesis-stream/v1\n")
+
+// maxGenesisStreamRecordSize limits the memory that is allocated for a single record on import
+const maxGenesisStreamRecordSize = 64 << 20
+
+const (
+	// genesisStreamCode is a types.Code record
+	genesisStreamCode byte = iota + 1
+	// genesisStreamContract is a types.Contract record without the contract state
+	genesisStreamContract
+	// genesisStreamModel is a types.Model record of the contract state that belongs to
+	// the preceding contract record
+	genesisStreamModel
+)
+
+// exportGenesisStream writes all codes and contracts with their state to the file, one record
+// at a time, and returns the reference for the genesis.
+func (k Keeper) exportGenesisStream(ctx sdk.Context, file string) (stream *types.GenesisStream, err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "create stream file")
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = errors.Wrap(closeErr, "close stream file")
+		}
+	}()
+
+	w := newGenesisStreamWriter(f)
+	if err := w.writeHeader(); err != nil {
+		return nil, err
+	}
+	var codes, contracts uint64
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		var bytecode []byte
+		if bytecode, err = k.GetByteCode(ctx, codeID); err != nil {
+			return true
+		}
+		err = w.write(genesisStreamCode, &types.Code{
+			CodeID:    codeID,
+			CodeInfo:  info,
+			CodeBytes: bytecode,
+			Pinned:    k.IsPinnedCode(ctx, codeID),
+		})
+		codes++
+		return err != nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "code")
+	}
+
+	k.IterateContractInfo(ctx, func(addr sdk.AccAddress, contract types.ContractInfo) bool {
+		c := k.genesisContract(ctx, addr, contract)
+		if err = w.write(genesisStreamContract, &c); err != nil {
+			return true
+		}
+		contracts++
+		k.IterateContractState(ctx, addr, func(key, value []byte) bool {
+			err = w.write(genesisStreamModel, &types.Model{Key: key, Value: value})
+			return err != nil
+		})
+		return err != nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "contract")
+	}
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+	return &types.GenesisStream{
+		File:      filepath.Base(file),
+		SHA256:    w.hash.Sum(nil),
+		Codes:     codes,
+		Contracts: contracts,
+	}, nil
+}
+
+// importGenesisStream reads the codes and contracts from the stream file in the stream
+// directory. Each record is validated and imported before the next one is read, so that
+// memory usage does not grow with the size of the state. The counts and the checksum are
+// verified at the end, a mismatch fails the genesis.
+func (k Keeper) importGenesisStream(
+	ctx sdk.Context,
+	stream types.GenesisStream,
+	importCode func(code types.Code) error,
+	importContract func(contractAddr sdk.AccAddress, contract types.Contract) error,
+) error {
+	if k.genesisStreamDir == "" {
+		return errors.Wrap(types.ErrInvalid, "genesis stream directory not set")
+	}
+	f, err := os.Open(filepath.Join(k.genesisStreamDir, stream.File))
+	if err != nil {
+		return errors.Wrap(err, "open stream file")
+	}
+	defer f.Close()
+
+	r := newGenesisStreamReader(f)
+	if err := r.readHeader(); err != nil {
+		return err
+	}
+	var (
+		codes, contracts uint64
+		contractAddr     sdk.AccAddress
+	)
+	for {
+		kind, bz, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case genesisStreamCode:
+			var code types.Code
+			if err := k.unmarshalStreamRecord(bz, &code); err != nil {
+				return errors.Wrapf(err, "code record %d", codes)
+			}
+			if err := importCode(code); err != nil {
+				return errors.Wrapf(err, "code record %d with id: %d", codes, code.CodeID)
+			}
+			codes++
+		case genesisStreamContract:
+			var contract types.Contract
+			if err := k.unmarshalStreamRecord(bz, &contract); err != nil {
+				return errors.Wrapf(err, "contract record %d", contracts)
+			}
+			if len(contract.ContractState) != 0 {
+				return errors.Wrapf(types.ErrInvalid, "contract record %d: state must be streamed separately", contracts)
+			}
+			contractAddr = sdk.MustAccAddressFromBech32(contract.ContractAddress) // validated
+			if err := importContract(contractAddr, contract); err != nil {
+				return errors.Wrapf(err, "contract record %d", contracts)
+			}
+			contracts++
+		case genesisStreamModel:
+			if contractAddr == nil {
+				return errors.Wrap(types.ErrInvalid, "contract state record without contract")
+			}
+			var model types.Model
+			if err := k.unmarshalStreamRecord(bz, &model); err != nil {
+				return errors.Wrapf(err, "contract state of %s", contractAddr)
+			}
+			if err := k.importContractState(ctx, contractAddr, []types.Model{model}); err != nil {
+				return errors.Wrapf(err, "contract state of %s", contractAddr)
+			}
+		default:
+			return errors.Wrapf(types.ErrInvalid, "unknown record kind: %d", kind)
+		}
+	}
+	if codes != stream.Codes {
+		return errors.Wrapf(types.ErrInvalid, "codes: got %d, expected %d", codes, stream.Codes)
+	}
+	if contracts != stream.Contracts {
+		return errors.Wrapf(types.ErrInvalid, "contracts: got %d, expected %d", contracts, stream.Contracts)
+	}
+	if checksum := r.hash.Sum(nil); !bytes.Equal(checksum, stream.SHA256) {
+		return errors.Wrapf(types.ErrInvalid, "checksum: got %X, expected %X", checksum, stream.SHA256)
+	}
+	return nil
+}
+
+func (k Keeper) unmarshalStreamRecord(bz []byte, record interface {
+	proto.Message
+	ValidateBasic() error
+},
+) error {
+	if err := k.cdc.Unmarshal(bz, record); err != nil {
+		return errors.Wrap(types.ErrInvalid, err.Error())
+	}
+	return record.ValidateBasic()
+}
+
+type genesisStreamWriter struct {
+	w    *bufio.Writer
+	hash hash.Hash
+	buf  [binary.MaxVarintLen64]byte
+}
+
+func newGenesisStreamWriter(w io.Writer) *genesisStreamWriter {
+	h := sha256.New()
+	return &genesisStreamWriter{w: bufio.NewWriter(io.MultiWriter(w, h)), hash: h}
+}
+
+func (s *genesisStreamWriter) writeHeader() error {
+	_, err := s.w.Write(genesisStreamMagic)
+	return err
+}
+
+func (s *genesisStreamWriter) write(kind byte, record proto.Marshaler) error {
+	bz, err := record.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := s.w.WriteByte(kind); err != nil {
+		return err
+	}
+	n := binary.PutUvarint(s.buf[:], uint64(len(bz)))
+	if _, err := s.w.Write(s.buf[:n]); err != nil {
+		return err
+	}
+	_, err = s.w.Write(bz)
+	return err
+}
+
+func (s *genesisStreamWriter) flush() error {
+	return s.w.Flush()
+}
+
+type genesisStreamReader struct {
+	r    *bufio.Reader
+	hash hash.Hash
+}
+
+func newGenesisStreamReader(r io.Reader) *genesisStreamReader {
+	h := sha256.New()
+	return &genesisStreamReader{r: bufio.NewReader(io.TeeReader(r, h)), hash: h}
+}
+
+func (s *genesisStreamReader) readHeader() error {
+	header := make([]byte, len(genesisStreamMagic))
+	if _, err := io.ReadFull(s.r, header); err != nil || !bytes.Equal(header, genesisStreamMagic) {
+		return errors.Wrap(types.ErrInvalid, "not a genesis stream file")
+	}
+	return nil
+}
+
+// next returns the next record or io.EOF at the end of the stream
+func (s *genesisStreamReader) next() (byte, []byte, error) {
+	kind, err := s.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	size, err := binary.ReadUvarint(s.r)
+	if err != nil {
+		return 0, nil, errors.Wrap(types.ErrInvalid, "record length")
+	}
+	if size > maxGenesisStreamRecordSize {
+		return 0, nil, errors.Wrapf(types.ErrLimit, "record size: %d", size)
+	}
+	bz := make([]byte, size)
+	if _, err := io.ReadFull(s.r, bz); err != nil {
+		return 0, nil, errors.Wrap(types.ErrInvalid, "truncated record")
+	}
+	return kind, bz, nil
+}
@@ -0,0 +1,246 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGenesisStreamRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	srcCtx, srcKeepers := CreateDefaultTestInput(t)
+	srcKeeper := srcKeepers.WasmKeeper
+	contracts := make([]sdk.AccAddress, 3)
+	for i := range contracts {
+		contracts[i] = sdk.AccAddress(bytes.Repeat([]byte{byte(i + 1)}, types.ContractAddrLen))
+		info := types.ContractInfoFixture(types.OnlyGenesisFields)
+		srcKeeper.storeContractInfo(srcCtx, contracts[i], &info)
+		require.NoError(t, srcKeeper.importContractState(srcCtx, contracts[i], []types.Model{
+			{Key: []byte("a"), Value: []byte{byte(i)}},
+			{Key: []byte("b"), Value: bytes.Repeat([]byte{byte(i)}, 1024)},
+		}))
+	}
+
+	// when
+	stream, err := srcKeeper.exportGenesisStream(srcCtx, filepath.Join(dir, "wasm.stream"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "wasm.stream", stream.File)
+	assert.Equal(t, uint64(0), stream.Codes)
+	assert.Equal(t, uint64(3), stream.Contracts)
+	bz, err := os.ReadFile(filepath.Join(dir, "wasm.stream"))
+	require.NoError(t, err)
+	checksum := sha256.Sum256(bz)
+	assert.Equal(t, checksum[:], stream.SHA256)
+
+	// and when imported
+	destCtx, destKeepers := CreateDefaultTestInput(t)
+	destKeeper := destKeepers.WasmKeeper
+	destKeeper.genesisStreamDir = dir
+	destKeeper.storeCodeInfo(destCtx, 1, types.CodeInfoFixture())
+	var imported []sdk.AccAddress
+	err = destKeeper.importGenesisStream(destCtx, *stream, noCodeImport(t), func(addr sdk.AccAddress, c types.Contract) error {
+		imported = append(imported, addr)
+		return destKeeper.importContract(destCtx, addr, &c.ContractInfo, c.ContractState, c.ContractCodeHistory)
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, contracts, imported)
+	for _, addr := range contracts {
+		assert.Equal(t, srcKeeper.GetContractInfo(srcCtx, addr).Label, destKeeper.GetContractInfo(destCtx, addr).Label)
+		var srcState, destState []types.Model
+		srcKeeper.IterateContractState(srcCtx, addr, func(key, value []byte) bool {
+			srcState = append(srcState, types.Model{Key: key, Value: value})
+			return false
+		})
+		destKeeper.IterateContractState(destCtx, addr, func(key, value []byte) bool {
+			destState = append(destState, types.Model{Key: key, Value: value})
+			return false
+		})
+		assert.Equal(t, srcState, destState)
+	}
+}
+
+func TestGenesisContractCodeHistory(t *testing.T) {
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	creator := sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	created := &types.AbsoluteTxPosition{BlockHeight: 10, TxIndex: 2}
+	history := []types.ContractCodeHistoryEntry{
+		{Operation: types.ContractCodeHistoryOperationTypeInit, CodeID: 1, Updated: created, Msg: []byte(`{"init":{}}`)},
+		{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: &types.AbsoluteTxPosition{BlockHeight: 20, TxIndex: 1}, Msg: []byte(`{"migrate":{}}`)},
+	}
+	specs := map[string]struct {
+		historyExport bool
+		expCreated    *types.AbsoluteTxPosition
+		expHistory    []types.ContractCodeHistoryEntry
+	}{
+		"full history": {
+			historyExport: true,
+			expCreated:    created,
+			expHistory:    history,
+		},
+		"reset to genesis": {
+			expCreated: &types.AbsoluteTxPosition{BlockHeight: 100},
+			expHistory: []types.ContractCodeHistoryEntry{
+				{Operation: types.ContractCodeHistoryOperationTypeGenesis, CodeID: 2, Updated: &types.AbsoluteTxPosition{BlockHeight: 100}},
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			srcCtx, srcKeepers := CreateDefaultTestInput(t)
+			srcKeeper := srcKeepers.WasmKeeper
+			srcKeeper.genesisHistoryExport = spec.historyExport
+			srcKeeper.storeCodeInfo(srcCtx, 1, types.CodeInfoFixture())
+			srcKeeper.storeCodeInfo(srcCtx, 2, types.CodeInfoFixture())
+			info := types.ContractInfoFixture(func(info *types.ContractInfo) {
+				info.CodeID = 2
+				info.Creator = creator.String()
+				info.Created = created
+			})
+			require.NoError(t, srcKeeper.importContract(srcCtx, contractAddr, &info, nil, history))
+
+			// when
+			contract := srcKeeper.genesisContract(srcCtx, contractAddr, *srcKeeper.GetContractInfo(srcCtx, contractAddr))
+			require.NoError(t, contract.ValidateBasic())
+			destCtx, destKeepers := CreateDefaultTestInput(t)
+			destKeeper := destKeepers.WasmKeeper
+			destCtx = destCtx.WithBlockHeight(100)
+			destKeeper.storeCodeInfo(destCtx, 2, types.CodeInfoFixture())
+			err := destKeeper.importContract(destCtx, contractAddr, &contract.ContractInfo, contract.ContractState, contract.ContractCodeHistory)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expCreated, destKeeper.GetContractInfo(destCtx, contractAddr).Created)
+			assert.Equal(t, spec.expHistory, destKeeper.GetContractHistory(destCtx, contractAddr))
+			var byCode, byCreator []sdk.AccAddress
+			destKeeper.IterateContractsByCode(destCtx, 2, func(addr sdk.AccAddress) bool {
+				byCode = append(byCode, addr)
+				return false
+			})
+			destKeeper.IterateContractsByCreator(destCtx, creator, func(addr sdk.AccAddress) bool {
+				byCreator = append(byCreator, addr)
+				return false
+			})
+			assert.Equal(t, []sdk.AccAddress{contractAddr}, byCode)
+			assert.Equal(t, []sdk.AccAddress{contractAddr}, byCreator)
+		})
+	}
+}
+
+func TestGenesisStreamImportRejects(t *testing.T) {
+	contract := types.ContractFixture(func(c *types.Contract) { c.ContractState = nil })
+	model := types.Model{Key: []byte("a"), Value: []byte("b")}
+	type record struct {
+		kind byte
+		msg  interface{ Marshal() ([]byte, error) }
+	}
+	specs := map[string]struct {
+		header  []byte
+		records []record
+		mutate  func(*types.GenesisStream)
+		noDir   bool
+	}{
+		"checksum mismatch": {
+			records: []record{{genesisStreamContract, &contract}},
+			mutate:  func(s *types.GenesisStream) { s.SHA256 = bytes.Repeat([]byte{1}, sha256.Size) },
+		},
+		"contracts count mismatch": {
+			records: []record{{genesisStreamContract, &contract}},
+			mutate:  func(s *types.GenesisStream) { s.Contracts = 2 },
+		},
+		"codes count mismatch": {
+			records: []record{{genesisStreamContract, &contract}},
+			mutate:  func(s *types.GenesisStream) { s.Codes = 1 },
+		},
+		"state before contract": {
+			records: []record{{genesisStreamModel, &model}, {genesisStreamContract, &contract}},
+		},
+		"inline contract state": {
+			records: []record{{genesisStreamContract, &types.Contract{
+				ContractAddress: contract.ContractAddress,
+				ContractInfo:    contract.ContractInfo,
+				ContractState:   []types.Model{model},
+			}}},
+		},
+		"invalid contract": {
+			records: []record{{genesisStreamContract, &types.Contract{ContractAddress: contract.ContractAddress}}},
+		},
+		"unknown record kind": {
+			records: []record{{0xff, &contract}},
+		},
+		"invalid header": {
+			header:  []byte("invalid"),
+			records: []record{{genesisStreamContract, &contract}},
+		},
+		"stream dir not set": {
+			records: []record{{genesisStreamContract, &contract}},
+			noDir:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			var buf bytes.Buffer
+			w := newGenesisStreamWriter(&buf)
+			if spec.header != nil {
+				_, err := w.w.Write(spec.header)
+				require.NoError(t, err)
+			} else {
+				require.NoError(t, w.writeHeader())
+			}
+			for _, r := range spec.records {
+				require.NoError(t, w.write(r.kind, r.msg))
+			}
+			require.NoError(t, w.flush())
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "wasm.stream"), buf.Bytes(), 0o600))
+			checksum := sha256.Sum256(buf.Bytes())
+			stream := types.GenesisStream{File: "wasm.stream", SHA256: checksum[:], Contracts: 1}
+			if spec.mutate != nil {
+				spec.mutate(&stream)
+			}
+			ctx, keepers := CreateDefaultTestInput(t)
+			k := keepers.WasmKeeper
+			if !spec.noDir {
+				k.genesisStreamDir = dir
+			}
+			k.storeCodeInfo(ctx, 1, types.CodeInfoFixture())
+
+			// when
+			gotErr := k.importGenesisStream(ctx, stream, noCodeImport(t), func(addr sdk.AccAddress, c types.Contract) error {
+				return k.importContract(ctx, addr, &c.ContractInfo, c.ContractState, c.ContractCodeHistory)
+			})
+
+			// then
+			require.Error(t, gotErr)
+		})
+	}
+}
+
+func TestGenesisStreamReaderLimitsRecordSize(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(genesisStreamMagic)
+	buf.WriteByte(genesisStreamModel)
+	buf.Write([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}) // uvarint larger than the max record size
+	r := newGenesisStreamReader(&buf)
+	require.NoError(t, r.readHeader())
+	_, _, err := r.next()
+	require.ErrorIs(t, err, types.ErrLimit)
+}
+
+func noCodeImport(t *testing.T) func(types.Code) error {
+	return func(types.Code) error {
+		t.Fatal("no code import expected")
+		return nil
+	}
+}
@@ -7,6 +7,7 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	"cosmossdk.io/errors"
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -28,6 +29,7 @@ type MessageRouter interface {
 
 // SDKMessageHandler can handles messages that can be encoded into sdk.Message types and routed.
 type SDKMessageHandler struct {
+	cdc      codec.Codec
 	router   MessageRouter
 	encoders msgEncoder
 }
@@ -35,41 +37,44 @@ type SDKMessageHandler struct {
 func NewDefaultMessageHandler(
 	router MessageRouter,
 	channelKeeper types.ChannelKeeper,
+	ackStore AsyncAckPacketStore,
 	bankKeeper types.Burner,
-	unpacker codectypes.AnyUnpacker,
+	cdc codec.Codec,
 	portSource types.ICS20TransferPortSource,
 	customEncoders ...*MessageEncoders,
 ) Messenger {
-	encoders := DefaultEncoders(unpacker, portSource)
+	encoders := DefaultEncoders(cdc, portSource)
 	for _, e := range customEncoders {
 		encoders = encoders.Merge(e)
 	}
 	return NewMessageHandlerChain(
-		NewSDKMessageHandler(router, encoders),
-		NewIBCRawPacketHandler(channelKeeper),
+		NewSDKMessageHandler(cdc, router, encoders),
+		NewIBCRawPacketHandler(channelKeeper, ackStore),
 		NewBurnCoinMessageHandler(bankKeeper),
 	)
 }
 
-func NewSDKMessageHandler(router MessageRouter, encoders msgEncoder) SDKMessageHandler {
+func NewSDKMessageHandler(cdc codec.Codec, router MessageRouter, encoders msgEncoder) SDKMessageHandler {
 	return SDKMessageHandler{
+		cdc:      cdc,
 		router:   router,
 		encoders: encoders,
 	}
 }
 
-func (h SDKMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+func (h SDKMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 	sdkMsgs, err := h.encoders.Encode(ctx, contractAddr, contractIBCPortID, msg)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	for _, sdkMsg := range sdkMsgs {
 		res, err := h.handleSdkMessage(ctx, contractAddr, sdkMsg)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
-		// append data
+		// append data and msg responses
 		data = append(data, res.Data)
+		msgResponses = append(msgResponses, res.MsgResponses)
 		// append events
 		sdkEvents := make([]sdk.Event, len(res.Events))
 		for i := range res.Events {
@@ -81,10 +86,23 @@ func (h SDKMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddr
 }
 
 func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Address, msg sdk.Msg) (*sdk.Result, error) {
-	// Note: In SDK v0.50+, ValidateBasic and GetSigners were removed from Msg interface
-	// Message validation and authorization are now handled by the msg service router
-	// The permission check that was here has been removed as it's now done by the handler
-	
+	if m, ok := msg.(sdk.HasValidateBasic); ok {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	// make sure this account can send it. The signers are read from the proto
+	// annotations so that messages without the legacy GetSigners are covered.
+	signers, _, err := h.cdc.GetMsgV1Signers(msg)
+	if err != nil {
+		return nil, err
+	}
+	for _, acct := range signers {
+		if !contractAddr.Equals(sdk.AccAddress(acct)) {
+			return nil, errors.Wrap(sdkerrors.ErrUnauthorized, "contract doesn't have permission")
+		}
+	}
+
 	// find the handler and execute it
 	if handler := h.router.Handler(msg); handler != nil {
 		// ADR 031 request type routing
@@ -118,53 +136,64 @@ func NewMessageHandlerChain(first Messenger, others ...Messenger) *MessageHandle
 // order to find the right one to process given message. If a handler cannot
 // process given message (returns ErrUnknownMsg), its result is ignored and the
 // next handler is executed.
-func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
 	for _, h := range m.handlers {
-		events, data, err := h.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+		events, data, msgResponses, err := h.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
 		switch {
 		case err == nil:
-			return events, data, nil
+			return events, data, msgResponses, nil
 		case stderrors.Is(err, types.ErrUnknownMsg):
 			continue
 		default:
-			return events, data, err
+			return events, data, msgResponses, err
 		}
 	}
-	return nil, nil, errors.Wrap(types.ErrUnknownMsg, "no handler found")
+	return nil, nil, nil, errors.Wrap(types.ErrUnknownMsg, "no handler found")
+}
+
+// AsyncAckPacketStore persists received packets until the contract writes the acknowledgement
+type AsyncAckPacketStore interface {
+	LoadAsyncAckPacket(ctx sdk.Context, portID, channelID string, sequence uint64) (channeltypes.Packet, error)
+	DeleteAsyncAckPacket(ctx sdk.Context, portID, channelID string, sequence uint64)
 }
 
-// IBCRawPacketHandler handels IBC.SendPacket messages which are published to an IBC channel.
+// IBCRawPacketHandler handels IBC.SendPacket messages which are published to an IBC channel
+// and IBC.WriteAcknowledgement messages for packets that were received without a sync ack.
 type IBCRawPacketHandler struct {
 	channelKeeper types.ChannelKeeper
+	ackStore      AsyncAckPacketStore
 }
 
-func NewIBCRawPacketHandler(chk types.ChannelKeeper) IBCRawPacketHandler {
-	return IBCRawPacketHandler{channelKeeper: chk}
+func NewIBCRawPacketHandler(chk types.ChannelKeeper, ackStore AsyncAckPacketStore) IBCRawPacketHandler {
+	return IBCRawPacketHandler{channelKeeper: chk, ackStore: ackStore}
 }
 
-// DispatchMsg publishes a raw IBC packet onto the channel.
-func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
-	if msg.IBC == nil || msg.IBC.SendPacket == nil {
-		return nil, nil, types.ErrUnknownMsg
+// DispatchMsg publishes a raw IBC packet onto the channel or writes an async acknowledgement.
+func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+	if msg.IBC == nil || (msg.IBC.SendPacket == nil && msg.IBC.WriteAcknowledgement == nil) {
+		return nil, nil, nil, types.ErrUnknownMsg
 	}
 	if contractIBCPortID == "" {
-		return nil, nil, errors.Wrapf(types.ErrUnsupportedForContract, "ibc not supported")
+		return nil, nil, nil, errors.Wrapf(types.ErrUnsupportedForContract, "ibc not supported")
+	}
+	if msg.IBC.WriteAcknowledgement != nil {
+		return nil, nil, nil, h.writeAcknowledgement(ctx, contractIBCPortID, msg.IBC.WriteAcknowledgement)
 	}
 	contractIBCChannelID := msg.IBC.SendPacket.ChannelID
 	if contractIBCChannelID == "" {
-		return nil, nil, errors.Wrapf(types.ErrEmpty, "ibc channel")
+		return nil, nil, nil, errors.Wrapf(types.ErrEmpty, "ibc channel")
 	}
 
 	sequence, found := h.channelKeeper.GetNextSequenceSend(ctx, contractIBCPortID, contractIBCChannelID)
 	if !found {
-		return nil, nil, errors.Wrapf(channeltypes.ErrSequenceSendNotFound,
+		return nil, nil, nil, errors.Wrapf(channeltypes.ErrSequenceSendNotFound,
 			"source port: %s, source channel: %s", contractIBCPortID, contractIBCChannelID,
 		)
 	}
 
 	channelInfo, ok := h.channelKeeper.GetChannel(ctx, contractIBCPortID, contractIBCChannelID)
 	if !ok {
-		return nil, nil, errors.Wrap(channeltypes.ErrInvalidChannel, "not found")
+		return nil, nil, nil, errors.Wrap(channeltypes.ErrInvalidChannel, "not found")
 	}
 	packet := channeltypes.NewPacket(
 		msg.IBC.SendPacket.Data,
@@ -176,36 +205,55 @@ func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, cont
 		ConvertWasmIBCTimeoutHeightToCosmosHeight(msg.IBC.SendPacket.Timeout.Block),
 		msg.IBC.SendPacket.Timeout.Timestamp,
 	)
-	return nil, nil, h.channelKeeper.SendPacket(ctx, packet)
+	return nil, nil, nil, h.channelKeeper.SendPacket(ctx, packet)
+}
+
+// writeAcknowledgement writes the ack for a packet that was received by the contract on its own port
+func (h IBCRawPacketHandler) writeAcknowledgement(ctx sdk.Context, contractIBCPortID string, msg *wasmvmtypes.WriteAcknowledgementMsg) error {
+	if msg.ChannelID == "" {
+		return errors.Wrapf(types.ErrEmpty, "ibc channel")
+	}
+	if len(msg.Ack.Data) == 0 {
+		return errors.Wrapf(types.ErrEmpty, "acknowledgement")
+	}
+	packet, err := h.ackStore.LoadAsyncAckPacket(ctx, contractIBCPortID, msg.ChannelID, msg.PacketSequence)
+	if err != nil {
+		return err
+	}
+	if err := h.channelKeeper.WriteAcknowledgement(ctx, packet, types.ContractConfirmStateAck(msg.Ack.Data)); err != nil {
+		return errors.Wrap(err, "write acknowledgement")
+	}
+	h.ackStore.DeleteAsyncAckPacket(ctx, contractIBCPortID, msg.ChannelID, msg.PacketSequence)
+	return nil
 }
 
 var _ Messenger = MessageHandlerFunc(nil)
 
 // MessageHandlerFunc is a helper to construct a function based message handler.
-type MessageHandlerFunc func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error)
+type MessageHandlerFunc func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error)
 
 // DispatchMsg delegates dispatching of provided message into the MessageHandlerFunc.
-func (m MessageHandlerFunc) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+func (m MessageHandlerFunc) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 	return m(ctx, contractAddr, contractIBCPortID, msg)
 }
 
 // NewBurnCoinMessageHandler handles wasmvm.BurnMsg messages
 func NewBurnCoinMessageHandler(burner types.Burner) MessageHandlerFunc {
-	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 		if msg.Bank != nil && msg.Bank.Burn != nil {
 			coins, err := ConvertWasmCoinsToSdkCoins(msg.Bank.Burn.Amount)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			if err := burner.SendCoinsFromAccountToModule(ctx, contractAddr, types.ModuleName, coins); err != nil {
-				return nil, nil, errors.Wrap(err, "transfer to module")
+				return nil, nil, nil, errors.Wrap(err, "transfer to module")
 			}
 			if err := burner.BurnCoins(ctx, types.ModuleName, coins); err != nil {
-				return nil, nil, errors.Wrap(err, "burn coins")
+				return nil, nil, nil, errors.Wrap(err, "burn coins")
 			}
 			moduleLogger(ctx).Info("Burned", "amount", coins)
-			return nil, nil, nil
+			return nil, nil, nil, nil
 		}
-		return nil, nil, types.ErrUnknownMsg
+		return nil, nil, nil, types.ErrUnknownMsg
 	}
 }
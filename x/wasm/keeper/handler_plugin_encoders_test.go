@@ -189,6 +189,35 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"wasm instantiate2": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Instantiate2: &wasmvmtypes.Instantiate2Msg{
+						CodeID: 7,
+						Msg:    jsonMsg,
+						Funds: []wasmvmtypes.Coin{
+							wasmvmtypes.NewCoin(123, "eth"),
+						},
+						Label: "myLabel",
+						Admin: addr2.String(),
+						Salt:  []byte("mySalt"),
+					},
+				},
+			},
+			output: []sdk.Msg{
+				&types.MsgInstantiateContract2{
+					Sender: addr1.String(),
+					Admin:  addr2.String(),
+					CodeID: 7,
+					Label:  "myLabel",
+					Msg:    jsonMsg,
+					Funds:  sdk.NewCoins(sdk.NewInt64Coin("eth", 123)),
+					Salt:   []byte("mySalt"),
+					FixMsg: false,
+				},
+			},
+		},
 		"wasm migrate": {
 			sender: addr2,
 			srcMsg: wasmvmtypes.CosmosMsg{
@@ -482,6 +511,41 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"IBC transfer with memo": {
+			sender:             addr1,
+			srcContractIBCPort: "myIBCPort",
+			srcMsg: wasmvmtypes.CosmosMsg{
+				IBC: &wasmvmtypes.IBCMsg{
+					Transfer: &wasmvmtypes.TransferMsg{
+						ChannelID: "myChanID",
+						ToAddress: addr2.String(),
+						Amount: wasmvmtypes.Coin{
+							Denom:  "ALX",
+							Amount: "1",
+						},
+						Timeout: wasmvmtypes.IBCTimeout{Timestamp: 100},
+						Memo:    `{"src_callback":{"address":"` + addr1.String() + `"}}`,
+					},
+				},
+			},
+			transferPortSource: wasmtesting.MockIBCTransferKeeper{GetPortFn: func(ctx sdk.Context) string {
+				return "transfer"
+			}},
+			output: []sdk.Msg{
+				&ibctransfertypes.MsgTransfer{
+					SourcePort:    "transfer",
+					SourceChannel: "myChanID",
+					Token: sdk.Coin{
+						Denom:  "ALX",
+						Amount: sdk.NewInt(1),
+					},
+					Sender:           addr1.String(),
+					Receiver:         addr2.String(),
+					TimeoutTimestamp: 100,
+					Memo:             `{"src_callback":{"address":"` + addr1.String() + `"}}`,
+				},
+			},
+		},
 		"IBC close channel": {
 			sender:             addr1,
 			srcContractIBCPort: "myIBCPort",
@@ -2,14 +2,21 @@ package keeper
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
+	storetypes "cosmossdk.io/store/types"
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
@@ -24,12 +31,12 @@ func TestMessageHandlerChainDispatch(t *testing.T) {
 	capturingHandler, gotMsgs := wasmtesting.NewCapturingMessageHandler()
 
 	alwaysUnknownMsgHandler := &wasmtesting.MockMessageHandler{
-		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
-			return nil, nil, types.ErrUnknownMsg
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+			return nil, nil, nil, types.ErrUnknownMsg
 		}}
 
 	assertNotCalledHandler := &wasmtesting.MockMessageHandler{
-		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 			t.Fatal("not expected to be called")
 			return
 		}}
@@ -51,16 +58,16 @@ func TestMessageHandlerChainDispatch(t *testing.T) {
 		},
 		"stops iteration on handler error": {
 			handlers: []Messenger{&wasmtesting.MockMessageHandler{
-				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
-					return nil, nil, types.ErrInvalidMsg
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, nil, nil, types.ErrInvalidMsg
 				}}, assertNotCalledHandler},
 			expErr: types.ErrInvalidMsg,
 		},
 		"return events when handle": {
 			handlers: []Messenger{&wasmtesting.MockMessageHandler{
-				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
-					_, data, _ = capturingHandler.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
-					return []sdk.Event{sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"))}, data, nil
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					_, data, _, _ = capturingHandler.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+					return []sdk.Event{sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"))}, data, nil, nil
 				}},
 			},
 			expEvents: []sdk.Event{sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"))},
@@ -76,7 +83,7 @@ func TestMessageHandlerChainDispatch(t *testing.T) {
 
 			// when
 			h := MessageHandlerChain{spec.handlers}
-			gotEvents, gotData, gotErr := h.DispatchMsg(sdk.Context{}, RandomAccountAddress(t), "anyPort", myMsg)
+			gotEvents, gotData, _, gotErr := h.DispatchMsg(sdk.Context{}, RandomAccountAddress(t), "anyPort", myMsg)
 
 			// then
 			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
@@ -170,6 +177,32 @@ func TestSDKMessageHandlerDispatch(t *testing.T) {
 			},
 			expErr: sdkerrors.ErrUnauthorized,
 		},
+		"instantiate2 without salt rejected": {
+			srcRoute: capturingMessageRouter,
+			srcEncoder: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+				invalidMsg := types.MsgInstantiateContract2{
+					Sender: myContractAddr.String(),
+					CodeID: 1,
+					Label:  "label",
+					Msg:    []byte("{}"),
+				}
+				return []sdk.Msg{&invalidMsg}, nil
+			},
+			expErr: types.ErrEmpty,
+		},
+		"interchain account tx for other owner rejected": {
+			srcRoute: capturingMessageRouter,
+			srcEncoder: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+				invalidMsg := icacontrollertypes.MsgSendTx{
+					Owner:           RandomBech32AccountAddress(t),
+					ConnectionId:    "connection-0",
+					PacketData:      icatypes.InterchainAccountPacketData{Type: icatypes.EXECUTE_TX, Data: []byte("myTx")},
+					RelativeTimeout: 1,
+				}
+				return []sdk.Msg{&invalidMsg}, nil
+			},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
 		"unroutable message rejected": {
 			srcRoute: noRouteMessageRouter,
 			srcEncoder: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
@@ -197,8 +230,8 @@ func TestSDKMessageHandlerDispatch(t *testing.T) {
 
 			// when
 			ctx := sdk.Context{}
-			h := NewSDKMessageHandler(spec.srcRoute, MessageEncoders{Custom: spec.srcEncoder})
-			gotEvents, gotData, gotErr := h.DispatchMsg(ctx, myContractAddr, "myPort", myContractMessage)
+			h := NewSDKMessageHandler(moduletestutil.MakeTestEncodingConfig().Codec, spec.srcRoute, MessageEncoders{Custom: spec.srcEncoder})
+			gotEvents, gotData, _, gotErr := h.DispatchMsg(ctx, myContractAddr, "myPort", myContractMessage)
 
 			// then
 			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
@@ -215,6 +248,74 @@ func TestSDKMessageHandlerDispatch(t *testing.T) {
 	}
 }
 
+func TestSDKMessageHandlerDispatchUpdateContractLabel(t *testing.T) {
+	// WasmMsg of wasmvm 2.2.1 has no label variant, so contracts update labels with a CosmosMsg::Any
+	encodingConfig := moduletestutil.MakeTestEncodingConfig()
+	types.RegisterInterfaces(encodingConfig.InterfaceRegistry)
+	cdc := encodingConfig.Codec
+
+	myContractAddr := RandomAccountAddress(t)
+	otherAdmin := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		admin    sdk.AccAddress
+		expErr   error
+		expLabel string
+	}{
+		"contract is its own admin": {
+			admin:    myContractAddr,
+			expLabel: "new label",
+		},
+		"contract is not the admin": {
+			admin:    otherAdmin,
+			expErr:   sdkerrors.ErrUnauthorized,
+			expLabel: "old label",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateDefaultTestInput(t)
+			k := keepers.WasmKeeper
+			k.cdc = cdc
+			contractInfo := types.NewContractInfo(1, RandomAccountAddress(t), spec.admin, "old label", types.NewAbsoluteTxPosition(ctx))
+			k.storeContractInfo(ctx, myContractAddr, &contractInfo)
+			k.addToContractLabelSecondaryIndex(ctx, contractInfo.Label, myContractAddr)
+
+			router := baseapp.NewMsgServiceRouter()
+			router.SetInterfaceRegistry(encodingConfig.InterfaceRegistry)
+			types.RegisterMsgServer(router, NewMsgServerImpl(k))
+
+			msgBz, err := cdc.Marshal(&types.MsgUpdateContractLabel{
+				Sender:   myContractAddr.String(),
+				Contract: myContractAddr.String(),
+				NewLabel: "new label",
+			})
+			require.NoError(t, err)
+			myContractMessage := wasmvmtypes.CosmosMsg{
+				Any: &wasmvmtypes.AnyMsg{TypeURL: sdk.MsgTypeURL(&types.MsgUpdateContractLabel{}), Value: msgBz},
+			}
+
+			// when
+			h := NewSDKMessageHandler(cdc, router, DefaultEncoders(cdc, nil))
+			_, _, _, gotErr := h.DispatchMsg(ctx, myContractAddr, "", myContractMessage)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			assert.Equal(t, spec.expLabel, k.GetContractInfo(ctx, myContractAddr).Label)
+			var gotAddrs []sdk.AccAddress
+			k.IterateContractsByLabel(ctx, spec.expLabel, func(addr sdk.AccAddress) bool {
+				gotAddrs = append(gotAddrs, addr)
+				return false
+			})
+			assert.Equal(t, []sdk.AccAddress{myContractAddr}, gotAddrs)
+		})
+	}
+}
+
 func TestIBCRawPacketHandler(t *testing.T) {
 	ibcPort := "contractsIBCPort"
 	var ctx sdk.Context
@@ -278,8 +379,8 @@ func TestIBCRawPacketHandler(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			capturedPacket = nil
 			// when
-			h := NewIBCRawPacketHandler(spec.chanKeeper)
-			data, evts, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
+			h := NewIBCRawPacketHandler(spec.chanKeeper, nil)
+			data, evts, _, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
 			// then
 			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
 			if spec.expErr != nil {
@@ -292,6 +393,85 @@ func TestIBCRawPacketHandler(t *testing.T) {
 	}
 }
 
+func TestIBCRawPacketHandlerWriteAcknowledgement(t *testing.T) {
+	ibcPort := "contractsIBCPort"
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	parentCtx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	k := &Keeper{storeKey: storeKey, cdc: moduletestutil.MakeTestEncodingConfig().Codec}
+
+	myPacket := channeltypes.Packet{
+		Sequence:           1,
+		SourcePort:         "other-port",
+		SourceChannel:      "other-channel-1",
+		DestinationPort:    ibcPort,
+		DestinationChannel: "channel-1",
+		Data:               []byte("myData"),
+	}
+	specs := map[string]struct {
+		srcMsg       wasmvmtypes.WriteAcknowledgementMsg
+		contractPort string
+		chanErr      error
+		expErr       bool
+	}{
+		"all good": {
+			srcMsg:       wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-1", PacketSequence: 1, Ack: wasmvmtypes.IBCAcknowledgement{Data: []byte("myAck")}},
+			contractPort: ibcPort,
+		},
+		"unknown sequence": {
+			srcMsg:       wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-1", PacketSequence: 2, Ack: wasmvmtypes.IBCAcknowledgement{Data: []byte("myAck")}},
+			contractPort: ibcPort,
+			expErr:       true,
+		},
+		"packet of other contract": {
+			srcMsg:       wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-1", PacketSequence: 1, Ack: wasmvmtypes.IBCAcknowledgement{Data: []byte("myAck")}},
+			contractPort: "otherContractsIBCPort",
+			expErr:       true,
+		},
+		"empty ack": {
+			srcMsg:       wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-1", PacketSequence: 1},
+			contractPort: ibcPort,
+			expErr:       true,
+		},
+		"channel keeper fails": {
+			srcMsg:       wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-1", PacketSequence: 1, Ack: wasmvmtypes.IBCAcknowledgement{Data: []byte("myAck")}},
+			contractPort: ibcPort,
+			chanErr:      errors.New("testing"),
+			expErr:       true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			k.storeAsyncAckPacket(ctx, myPacket)
+			var capturedPacket ibcexported.PacketI
+			var capturedAck ibcexported.Acknowledgement
+			chanKeeper := &wasmtesting.MockChannelKeeper{
+				WriteAcknowledgementFn: func(ctx sdk.Context, packet ibcexported.PacketI, ack ibcexported.Acknowledgement) error {
+					capturedPacket, capturedAck = packet, ack
+					return spec.chanErr
+				},
+			}
+			// when
+			h := NewIBCRawPacketHandler(chanKeeper, k)
+			evts, data, _, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), spec.contractPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{WriteAcknowledgement: &spec.srcMsg}})
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				_, err := k.LoadAsyncAckPacket(ctx, ibcPort, "channel-1", 1)
+				require.NoError(t, err, "packet must not be deleted")
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Nil(t, data)
+			assert.Nil(t, evts)
+			assert.Equal(t, myPacket, capturedPacket)
+			assert.Equal(t, types.ContractConfirmStateAck("myAck"), capturedAck)
+			_, err := k.LoadAsyncAckPacket(ctx, ibcPort, "channel-1", 1)
+			require.Error(t, err, "packet must be deleted")
+		})
+	}
+}
+
 func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 	// testing via full keeper setup so that we are confident the
 	// module permissions are set correct and no other handler
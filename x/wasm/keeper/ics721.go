@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"cosmossdk.io/store/prefix"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetClassTrace stores the trace of an ICS-721 class received over IBC
+func (k Keeper) SetClassTrace(ctx sdk.Context, trace types.ClassTrace) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetClassTraceKey(trace.GetFullClassID()), k.cdc.MustMarshal(&trace))
+}
+
+// GetClassTrace returns the trace for the full class id on this chain
+func (k Keeper) GetClassTrace(ctx sdk.Context, fullClassID string) (types.ClassTrace, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClassTraceKey(fullClassID))
+	if bz == nil {
+		return types.ClassTrace{}, false
+	}
+	var trace types.ClassTrace
+	k.cdc.MustUnmarshal(bz, &trace)
+	return trace, true
+}
+
+// IterateClassTraces iterates over all class traces. Iteration stops when the callback returns true.
+func (k Keeper) IterateClassTraces(ctx sdk.Context, cb func(types.ClassTrace) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ClassTracePrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var trace types.ClassTrace
+		k.cdc.MustUnmarshal(iter.Value(), &trace)
+		if cb(trace) {
+			break
+		}
+	}
+}
+
+// SetTokenTrace stores that the token of the received class is held on this chain
+func (k Keeper) SetTokenTrace(ctx sdk.Context, trace types.TokenTrace) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetTokenTraceKey(trace.ClassID, trace.TokenID), k.cdc.MustMarshal(&trace))
+}
+
+// HasTokenTrace returns true when the token of the received class is held on this chain
+func (k Keeper) HasTokenTrace(ctx sdk.Context, classID, tokenID string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetTokenTraceKey(classID, tokenID))
+}
+
+// DeleteTokenTrace removes the token trace after the token was sent back
+func (k Keeper) DeleteTokenTrace(ctx sdk.Context, classID, tokenID string) {
+	ctx.KVStore(k.storeKey).Delete(types.GetTokenTraceKey(classID, tokenID))
+}
+
+// IterateTokenTraces iterates over all token traces. Iteration stops when the callback returns true.
+func (k Keeper) IterateTokenTraces(ctx sdk.Context, cb func(types.TokenTrace) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.TokenTracePrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var trace types.TokenTrace
+		k.cdc.MustUnmarshal(iter.Value(), &trace)
+		if cb(trace) {
+			break
+		}
+	}
+}
+
+// ibcPortForMsgs returns the port that the IBC packets and acknowledgements of the contract are
+// sent over. They are the only messages that use the contract port. The ics721 contract sends them
+// over the nft-transfer port. The params are only read when the response contains such messages
+// and the read is charged to the contract.
+func (k Keeper) ibcPortForMsgs(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msgs []wasmvmtypes.SubMsg) string {
+	if !hasIBCPacketMsg(msgs) {
+		return contractIBCPortID
+	}
+	if ics721Contract := k.GetParams(ctx).ICS721Contract; ics721Contract != "" && ics721Contract == contractAddr.String() {
+		return types.ICS721PortID
+	}
+	return contractIBCPortID
+}
+
+// hasIBCPacketMsg returns true when any of the messages sends an IBC packet or acknowledgement
+func hasIBCPacketMsg(msgs []wasmvmtypes.SubMsg) bool {
+	for _, m := range msgs {
+		if m.Msg.IBC != nil && (m.Msg.IBC.SendPacket != nil || m.Msg.IBC.WriteAcknowledgement != nil) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestICS721Traces(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	k := &Keeper{storeKey: storeKey, cdc: cdc}
+
+	classA := types.ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "classA"}
+	classB := types.ClassTrace{Path: "nft-transfer/channel-1/nft-transfer/channel-3", BaseClassID: "classB"}
+	k.SetClassTrace(ctx, classA)
+	k.SetClassTrace(ctx, classB)
+	k.SetTokenTrace(ctx, types.TokenTrace{ClassID: classA.GetFullClassID(), TokenID: "t1"})
+	k.SetTokenTrace(ctx, types.TokenTrace{ClassID: classA.GetFullClassID(), TokenID: "t2"})
+	k.DeleteTokenTrace(ctx, classA.GetFullClassID(), "t2")
+
+	got, ok := k.GetClassTrace(ctx, classB.GetFullClassID())
+	require.True(t, ok)
+	assert.Equal(t, classB, got)
+	_, ok = k.GetClassTrace(ctx, "classB")
+	assert.False(t, ok)
+	assert.True(t, k.HasTokenTrace(ctx, classA.GetFullClassID(), "t1"))
+	assert.False(t, k.HasTokenTrace(ctx, classA.GetFullClassID(), "t2"))
+
+	q := NewGrpcQuerier(cdc, storeKey, nil, 0)
+	specs := map[string]struct {
+		query  func() (any, error)
+		exp    any
+		expErr error
+	}{
+		"class trace": {
+			query: func() (any, error) {
+				return q.ClassTrace(ctx, &types.QueryClassTraceRequest{ClassId: classA.GetFullClassID()})
+			},
+			exp: &types.QueryClassTraceResponse{ClassTrace: classA},
+		},
+		"class trace not found": {
+			query: func() (any, error) {
+				return q.ClassTrace(ctx, &types.QueryClassTraceRequest{ClassId: "unknown"})
+			},
+			expErr: types.ErrNotFound,
+		},
+		"class traces": {
+			query: func() (any, error) {
+				return q.ClassTraces(ctx, &types.QueryClassTracesRequest{})
+			},
+			exp: &types.QueryClassTracesResponse{
+				ClassTraces: []types.ClassTrace{classA, classB},
+				Pagination:  &query.PageResponse{Total: 2},
+			},
+		},
+		"class traces with pagination": {
+			query: func() (any, error) {
+				return q.ClassTraces(ctx, &types.QueryClassTracesRequest{Pagination: &query.PageRequest{Offset: 1}})
+			},
+			exp: &types.QueryClassTracesResponse{
+				ClassTraces: []types.ClassTrace{classB},
+				Pagination:  &query.PageResponse{Total: 2},
+			},
+		},
+		"token trace": {
+			query: func() (any, error) {
+				return q.TokenTrace(ctx, &types.QueryTokenTraceRequest{ClassId: classA.GetFullClassID(), TokenId: "t1"})
+			},
+			exp: &types.QueryTokenTraceResponse{ClassTrace: classA, TokenID: "t1"},
+		},
+		"token trace deleted": {
+			query: func() (any, error) {
+				return q.TokenTrace(ctx, &types.QueryTokenTraceRequest{ClassId: classA.GetFullClassID(), TokenId: "t2"})
+			},
+			expErr: types.ErrNotFound,
+		},
+		"token trace empty class": {
+			query: func() (any, error) {
+				return q.TokenTrace(ctx, &types.QueryTokenTraceRequest{TokenId: "t1"})
+			},
+			expErr: types.ErrEmpty,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := spec.query()
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestICS721ContractPortOverride(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	k := &Keeper{storeKey: storeKey, cdc: moduletestutil.MakeTestEncodingConfig().Codec}
+	contractAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{2}, types.ContractAddrLen))
+	const contractPort = "wasm.myContract"
+
+	params := types.DefaultParams()
+	params.ICS721Contract = contractAddr.String()
+	require.NoError(t, k.SetParams(ctx, params))
+
+	sendPacket := wasmvmtypes.SubMsg{Msg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{ChannelID: "channel-0"}}}}
+	writeAck := wasmvmtypes.SubMsg{Msg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{WriteAcknowledgement: &wasmvmtypes.WriteAcknowledgementMsg{ChannelID: "channel-0"}}}}
+	bankSend := wasmvmtypes.SubMsg{Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: otherAddr.String()}}}}
+
+	specs := map[string]struct {
+		contract  sdk.AccAddress
+		msgs      []wasmvmtypes.SubMsg
+		exp       string
+		expCharge bool
+	}{
+		"ics721 contract sends packet": {
+			contract:  contractAddr,
+			msgs:      []wasmvmtypes.SubMsg{bankSend, sendPacket},
+			exp:       types.ICS721PortID,
+			expCharge: true,
+		},
+		"ics721 contract writes ack": {
+			contract:  contractAddr,
+			msgs:      []wasmvmtypes.SubMsg{writeAck},
+			exp:       types.ICS721PortID,
+			expCharge: true,
+		},
+		"ics721 contract without ibc packets": {
+			contract: contractAddr,
+			msgs:     []wasmvmtypes.SubMsg{bankSend},
+			exp:      contractPort,
+		},
+		"ics721 contract without msgs": {
+			contract: contractAddr,
+			exp:      contractPort,
+		},
+		"other contract sends packet": {
+			contract:  otherAddr,
+			msgs:      []wasmvmtypes.SubMsg{sendPacket},
+			exp:       contractPort,
+			expCharge: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := ctx.WithGasMeter(storetypes.NewGasMeter(1_000_000))
+			got := k.ibcPortForMsgs(ctx, spec.contract, contractPort, spec.msgs)
+			assert.Equal(t, spec.exp, got)
+			assert.Equal(t, spec.expCharge, ctx.GasMeter().GasConsumed() != 0)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// RegisterInvariants registers all wasm invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k *Keeper) {
+	ir.RegisterRoute(types.ModuleName, "contract-code-ids", ContractCodeIDsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "contract-history", ContractHistoryInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "contract-code-index", ContractCodeSecondaryIndexInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "contract-label-index", ContractLabelSecondaryIndexInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "pinned-codes", PinnedCodesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "sequences", SequencesInvariant(k))
+}
+
+// AllInvariants runs all invariants of the wasm module
+func AllInvariants(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		for _, inv := range []sdk.Invariant{
+			ContractCodeIDsInvariant(k),
+			ContractHistoryInvariant(k),
+			ContractCodeSecondaryIndexInvariant(k),
+			ContractLabelSecondaryIndexInvariant(k),
+			PinnedCodesInvariant(k),
+			SequencesInvariant(k),
+		} {
+			if res, stop := inv(ctx); stop {
+				return res, stop
+			}
+		}
+		return "", false
+	}
+}
+
+// ContractCodeIDsInvariant checks that the code id of every contract refers to a stored code
+func ContractCodeIDsInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+		k.IterateContractInfo(ctx, func(addr sdk.AccAddress, info types.ContractInfo) bool {
+			if !k.containsCodeInfo(ctx, info.CodeID) {
+				count++
+				msg += fmt.Sprintf("\tcontract %s refers to unknown code id %d\n", addr, info.CodeID)
+			}
+			return false
+		})
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "contract code ids",
+			fmt.Sprintf("found %d contracts with unknown code ids\n%s", count, msg)), broken
+	}
+}
+
+// ContractHistoryInvariant checks that the last code history entry of every contract matches
+// the code id of the contract
+func ContractHistoryInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+		k.IterateContractInfo(ctx, func(addr sdk.AccAddress, info types.ContractInfo) bool {
+			entry, found := k.lastContractHistoryEntry(ctx, addr)
+			switch {
+			case !found:
+				count++
+				msg += fmt.Sprintf("\tcontract %s has no code history\n", addr)
+			case entry.CodeID != info.CodeID:
+				count++
+				msg += fmt.Sprintf("\tcontract %s with code id %d has last history code id %d\n", addr, info.CodeID, entry.CodeID)
+			}
+			return false
+		})
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "contract history",
+			fmt.Sprintf("found %d contracts with an inconsistent code history\n%s", count, msg)), broken
+	}
+}
+
+// ContractCodeSecondaryIndexInvariant checks that the contracts-by-code index has exactly one
+// entry per contract that matches the contract's last code history entry
+func ContractCodeSecondaryIndexInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+		store := ctx.KVStore(k.storeKey)
+		expected := make(map[string]struct{})
+		k.IterateContractInfo(ctx, func(addr sdk.AccAddress, _ types.ContractInfo) bool {
+			entry, found := k.lastContractHistoryEntry(ctx, addr)
+			if !found {
+				return false // reported by the contract history invariant
+			}
+			key := types.GetContractByCreatedSecondaryIndexKey(addr, entry)
+			expected[string(key)] = struct{}{}
+			if !store.Has(key) {
+				count++
+				msg += fmt.Sprintf("\tmissing code index entry for contract %s\n", addr)
+			}
+			return false
+		})
+
+		iter := prefix.NewStore(store, types.ContractByCodeIDAndCreatedSecondaryIndexPrefix).Iterator(nil, nil)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			key := append(bytes.Clone(types.ContractByCodeIDAndCreatedSecondaryIndexPrefix), iter.Key()...)
+			if _, ok := expected[string(key)]; !ok {
+				count++
+				msg += fmt.Sprintf("\tdangling code index entry %X\n", key)
+			}
+		}
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "contract code index",
+			fmt.Sprintf("found %d inconsistent code index entries\n%s", count, msg)), broken
+	}
+}
+
+// ContractLabelSecondaryIndexInvariant checks that the contracts-by-label index has exactly one
+// entry per contract that matches the contract's current label
+func ContractLabelSecondaryIndexInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+		store := ctx.KVStore(k.storeKey)
+		expected := make(map[string]struct{})
+		k.IterateContractInfo(ctx, func(addr sdk.AccAddress, info types.ContractInfo) bool {
+			key := types.GetContractByLabelSecondaryIndexKey(info.Label, addr)
+			expected[string(key)] = struct{}{}
+			if !store.Has(key) {
+				count++
+				msg += fmt.Sprintf("\tmissing label index entry for contract %s\n", addr)
+			}
+			return false
+		})
+
+		iter := prefix.NewStore(store, types.ContractsByLabelPrefix).Iterator(nil, nil)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			key := append(bytes.Clone(types.ContractsByLabelPrefix), iter.Key()...)
+			if _, ok := expected[string(key)]; !ok {
+				count++
+				msg += fmt.Sprintf("\tdangling label index entry %X\n", key)
+			}
+		}
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "contract label index",
+			fmt.Sprintf("found %d inconsistent label index entries\n%s", count, msg)), broken
+	}
+}
+
+// PinnedCodesInvariant checks that all pinned code ids refer to stored codes
+func PinnedCodesInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+		iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.PinnedCodeIndexPrefix).Iterator(nil, nil)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			codeID := types.ParsePinnedCodeIndex(iter.Key())
+			if !k.containsCodeInfo(ctx, codeID) {
+				count++
+				msg += fmt.Sprintf("\tpinned code id %d does not exist\n", codeID)
+			}
+		}
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "pinned codes",
+			fmt.Sprintf("found %d pinned unknown codes\n%s", count, msg)), broken
+	}
+}
+
+// SequencesInvariant checks that the code id sequence is greater than all stored code ids and
+// that the instance id sequence is not used by any contract yet. Contracts with predictable
+// addresses do not consume an instance id, so the next classic address of every code must be free.
+func SequencesInvariant(k *Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var broken bool
+		codeSeq := k.PeekAutoIncrementID(ctx, types.KeyLastCodeID)
+		instanceSeq := k.PeekAutoIncrementID(ctx, types.KeyLastInstanceID)
+		k.IterateCodeInfos(ctx, func(codeID uint64, _ types.CodeInfo) bool {
+			if codeID >= codeSeq {
+				broken = true
+				msg += fmt.Sprintf("\tseq %s with value %d must be greater than code id %d\n", string(types.KeyLastCodeID), codeSeq, codeID)
+			}
+			if addr := BuildContractAddress(codeID, instanceSeq); k.HasContractInfo(ctx, addr) {
+				broken = true
+				msg += fmt.Sprintf("\tseq %s with value %d is used by contract %s\n", string(types.KeyLastInstanceID), instanceSeq, addr)
+			}
+			return false
+		})
+		return sdk.FormatInvariant(types.ModuleName, "sequences", msg), broken
+	}
+}
+
+// lastContractHistoryEntry returns the last code history entry of the contract and false when
+// the contract has no history
+func (k Keeper) lastContractHistoryEntry(ctx sdk.Context, contractAddr sdk.AccAddress) (types.ContractCodeHistoryEntry, bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractCodeHistoryElementPrefix(contractAddr)).ReverseIterator(nil, nil)
+	defer iter.Close()
+
+	var r types.ContractCodeHistoryEntry
+	if !iter.Valid() {
+		return r, false
+	}
+	k.cdc.MustUnmarshal(iter.Value(), &r)
+	return r, true
+}
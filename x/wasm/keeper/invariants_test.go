@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestInvariants(t *testing.T) {
+	contractAddr := BuildContractAddress(1, 1)
+	specs := map[string]struct {
+		setup     func(t *testing.T, ctx sdk.Context, k *Keeper)
+		expBroken bool
+	}{
+		"consistent state": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {},
+		},
+		"unknown code id": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				info := k.GetContractInfo(ctx, contractAddr)
+				info.CodeID = 99
+				k.storeContractInfo(ctx, contractAddr, info)
+			},
+			expBroken: true,
+		},
+		"history does not match code id": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				k.storeCodeInfo(ctx, 2, types.CodeInfoFixture())
+				info := k.GetContractInfo(ctx, contractAddr)
+				info.CodeID = 2
+				k.storeContractInfo(ctx, contractAddr, info)
+			},
+			expBroken: true,
+		},
+		"missing code index entry": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				entry, _ := k.lastContractHistoryEntry(ctx, contractAddr)
+				k.removeFromContractCodeSecondaryIndex(ctx, contractAddr, entry)
+			},
+			expBroken: true,
+		},
+		"dangling code index entry": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				otherAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+				k.addToContractCodeSecondaryIndex(ctx, otherAddr, types.ContractCodeHistoryEntry{CodeID: 1, Updated: &types.AbsoluteTxPosition{}})
+			},
+			expBroken: true,
+		},
+		"missing label index entry": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				info := k.GetContractInfo(ctx, contractAddr)
+				k.removeFromContractLabelSecondaryIndex(ctx, info.Label, contractAddr)
+			},
+			expBroken: true,
+		},
+		"stale label index entry": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				info := k.GetContractInfo(ctx, contractAddr)
+				info.Label = "other"
+				k.storeContractInfo(ctx, contractAddr, info)
+			},
+			expBroken: true,
+		},
+		"pinned unknown code": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				ctx.KVStore(k.storeKey).Set(types.GetPinnedCodeIndexPrefix(99), []byte{1})
+			},
+			expBroken: true,
+		},
+		"code sequence not greater than code id": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				ctx.KVStore(k.storeKey).Set(types.KeyLastCodeID, sdk.Uint64ToBigEndian(1))
+			},
+			expBroken: true,
+		},
+		"instance sequence used by contract": {
+			setup: func(t *testing.T, ctx sdk.Context, k *Keeper) {
+				ctx.KVStore(k.storeKey).Set(types.KeyLastInstanceID, sdk.Uint64ToBigEndian(1))
+			},
+			expBroken: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateDefaultTestInput(t)
+			k := keepers.WasmKeeper
+			k.storeCodeInfo(ctx, 1, types.CodeInfoFixture())
+			require.NoError(t, k.importAutoIncrementID(ctx, types.KeyLastCodeID, 2))
+			require.NoError(t, k.importAutoIncrementID(ctx, types.KeyLastInstanceID, 2))
+			info := types.ContractInfoFixture(types.OnlyGenesisFields)
+			require.NoError(t, k.importContract(ctx, contractAddr, &info, nil, nil))
+			ctx.KVStore(k.storeKey).Set(types.GetPinnedCodeIndexPrefix(1), []byte{1})
+
+			spec.setup(t, ctx, k)
+
+			// when
+			msg, broken := AllInvariants(k)(ctx)
+
+			// then
+			assert.Equal(t, spec.expBroken, broken, msg)
+		})
+	}
+}
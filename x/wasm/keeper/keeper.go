@@ -3,15 +3,15 @@ package keeper
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/types/address"
-
 	"cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	"cosmossdk.io/store/prefix"
@@ -22,7 +22,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -66,14 +66,33 @@ type Keeper struct {
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
 	portKeeper            types.PortKeeper
-	wasmVM                *wasmvm.VM // Use concrete type instead of interface for wasmvm v2 compatibility
+	wasmVM                types.WasmerEngine
 	wasmVMQueryHandler    WasmVMQueryHandler
 	wasmVMResponseHandler WasmVMResponseHandler
 	messenger             Messenger
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
 	queryGasLimit uint64
-	paramSpace    paramtypes.Subspace
-	gasRegister   GasRegister
+	// gasRegister is a custom gas register that replaces the gas schedule from the params
+	gasRegister GasRegister
+	// apiCosts are custom api costs that replace the costs of the gas schedule from the params
+	apiCosts *apiCosts
+	// authority is the address capable of executing governance operations like a MsgUpdateParams.
+	// Typically, this should be the x/gov module account.
+	authority string
+	// genesisStreamDir is the directory of the genesis stream files referenced on import
+	genesisStreamDir string
+	// genesisStreamExport is the file that the codes and contracts are streamed to on export.
+	// They are exported inline in the genesis when empty.
+	genesisStreamExport string
+	// genesisHistoryExport exports the contract code history and created positions
+	genesisHistoryExport bool
+	// uniqueContractLabels rejects labels that are already used by another contract
+	uniqueContractLabels bool
+	// tracer records the contract calls of the executed txs, nil when disabled
+	tracer *contractTracer
+	// acceptedAccountTypes are the unused account types that can exist at a contract address
+	// before the instantiation. They are replaced by a new account.
+	acceptedAccountTypes map[reflect.Type]struct{}
 }
 
 // NewKeeper creates a new contract Keeper instance
@@ -81,7 +100,6 @@ type Keeper struct {
 func NewKeeper(
 	cdc codec.Codec,
 	storeKey storetypes.StoreKey,
-	paramSpace paramtypes.Subspace,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
 	stakingKeeper types.StakingKeeper,
@@ -94,19 +112,55 @@ func NewKeeper(
 	homeDir string,
 	wasmConfig types.WasmConfig,
 	supportedFeatures string,
+	authority string,
 	opts ...Option,
 ) Keeper {
-	// Convert supportedFeatures string to []string for wasmvm v2
+	keeper := &Keeper{
+		storeKey:      storeKey,
+		cdc:           cdc,
+		accountKeeper: accountKeeper,
+		bank:          NewBankCoinTransferrer(bankKeeper),
+		portKeeper:    portKeeper,
+		queryGasLimit: wasmConfig.SmartQueryGasLimit,
+		authority:     authority,
+		tracer:        newContractTracer(wasmConfig.ContractTraceCacheSize),
+		acceptedAccountTypes: map[reflect.Type]struct{}{
+			reflect.TypeOf(&authtypes.BaseAccount{}): {},
+		},
+	}
+	keeper.messenger = NewDefaultMessageHandler(router, channelKeeper, keeper, bankKeeper, cdc, portSource)
+	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, queryRouter, keeper)
+	var postOpts []Option
+	for _, o := range opts {
+		if _, ok := o.(postOptsFn); ok {
+			postOpts = append(postOpts, o)
+			continue
+		}
+		o.apply(keeper)
+	}
+	// only set the default wasmvm if no engine was injected via options
+	if keeper.wasmVM == nil {
+		keeper.wasmVM = newDefaultWasmVM(homeDir, wasmConfig, supportedFeatures)
+	}
+	// options that depend on the final engine, e.g. metrics
+	for _, o := range postOpts {
+		o.apply(keeper)
+	}
+	// not updateable, yet
+	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
+	return *keeper
+}
+
+// newDefaultWasmVM creates the wasmvm engine that is used when none was set via the WithWasmEngine option.
+// The comma separated list of supportedFeatures is passed as capabilities to the VM.
+func newDefaultWasmVM(homeDir string, wasmConfig types.WasmConfig, supportedFeatures string) *wasmvm.VM {
 	var capabilities []string
 	if supportedFeatures != "" {
 		capabilities = strings.Split(supportedFeatures, ",")
-		// Trim spaces from each capability
 		for i := range capabilities {
 			capabilities[i] = strings.TrimSpace(capabilities[i])
 		}
 	}
-	
-	// Initialize VM with wasmvm v2 config
 	wasmer, err := wasmvm.NewVM(
 		filepath.Join(homeDir, "wasm"),
 		capabilities,
@@ -117,59 +171,48 @@ func NewKeeper(
 	if err != nil {
 		panic(err)
 	}
-	// set KeyTable if it has not already been set
-	if !paramSpace.HasKeyTable() {
-		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
-	}
-
-	keeper := &Keeper{
-		storeKey:      storeKey,
-		cdc:           cdc,
-		wasmVM:        wasmer,
-		accountKeeper: accountKeeper,
-		bank:          NewBankCoinTransferrer(bankKeeper),
-		portKeeper:    portKeeper,
-		messenger:     NewDefaultMessageHandler(router, channelKeeper, bankKeeper, cdc, portSource),
-		queryGasLimit: wasmConfig.SmartQueryGasLimit,
-		paramSpace:    paramSpace,
-		gasRegister:   NewDefaultWasmGasRegister(),
-	}
-	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, queryRouter, keeper)
-	for _, o := range opts {
-		o.apply(keeper)
-	}
-	// not updateable, yet
-	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
-	return *keeper
+	return wasmer
 }
 
 func (k Keeper) getUploadAccessConfig(ctx sdk.Context) types.AccessConfig {
-	var a types.AccessConfig
-	k.paramSpace.Get(ctx, types.ParamStoreKeyUploadAccess, &a)
-	return a
+	return k.GetParams(ctx).CodeUploadAccess
 }
 
 func (k Keeper) getInstantiateAccessConfig(ctx sdk.Context) types.AccessType {
-	var a types.AccessType
-	k.paramSpace.Get(ctx, types.ParamStoreKeyInstantiateAccess, &a)
-	return a
+	return k.GetParams(ctx).InstantiateDefaultPermission
 }
 
 func (k Keeper) GetMaxWasmCodeSize(ctx sdk.Context) uint64 {
-	var a uint64
-	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxWasmCodeSize, &a)
-	return a
+	return k.GetParams(ctx).MaxWasmCodeSize
 }
 
 // GetParams returns the total set of wasm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	var params types.Params
-	k.paramSpace.GetParamSet(ctx, &params)
+	bz := ctx.KVStore(k.storeKey).Get(types.ParamsKey)
+	if bz == nil {
+		return params
+	}
+	k.cdc.MustUnmarshal(bz, &params)
 	return params
 }
 
-func (k Keeper) SetParams(ctx sdk.Context, ps types.Params) {
-	k.paramSpace.SetParamSet(ctx, &ps)
+// SetParams sets all wasm parameters.
+func (k Keeper) SetParams(ctx sdk.Context, ps types.Params) error {
+	if err := ps.ValidateBasic(); err != nil {
+		return err
+	}
+	bz, err := k.cdc.Marshal(&ps)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set(types.ParamsKey, bz)
+	return nil
+}
+
+// GetAuthority returns the x/wasm module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
 }
 
 func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, err error) {
@@ -184,17 +227,18 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	if err != nil {
 		return 0, errors.Wrap(types.ErrCreateFailed, err.Error())
 	}
-	ctx.GasMeter().ConsumeGas(k.gasRegister.CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
+	costs := k.runtimeCosts(ctx)
+	ctx.GasMeter().ConsumeGas(costs.gasRegister.CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
 
 	// In wasmvm v2, StoreCode requires a gas limit parameter
 	// We use the maximum gas available for this operation
-	gasLimit := k.runtimeGasForContract(ctx)
+	gasLimit := k.runtimeGasForContract(ctx, costs.gasRegister)
 	checksum, gasUsed, err := k.wasmVM.StoreCode(wasmCode, gasLimit)
 	if err != nil {
 		return 0, errors.Wrap(types.ErrCreateFailed, err.Error())
 	}
 	// Consume the gas used by the VM
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	
 	report, err := k.wasmVM.AnalyzeCode(checksum)
 	if err != nil {
@@ -208,6 +252,7 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	}
 	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
 	k.storeCodeInfo(ctx, codeID, codeInfo)
+	k.addToCodeChecksumSecondaryIndex(ctx, checksum, codeID)
 
 	evt := sdk.NewEvent(
 		types.EventTypeStoreCode,
@@ -234,13 +279,14 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	}
 	
 	// In wasmvm v2, StoreCode requires a gas limit parameter
-	gasLimit := k.runtimeGasForContract(ctx)
+	costs := k.runtimeCosts(ctx)
+	gasLimit := k.runtimeGasForContract(ctx, costs.gasRegister)
 	newCodeHash, gasUsed, err := k.wasmVM.StoreCode(wasmCode, gasLimit)
 	if err != nil {
 		return errors.Wrap(types.ErrCreateFailed, err.Error())
 	}
 	// Consume the gas used by the VM
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	
 	if !bytes.Equal(codeInfo.CodeHash, newCodeHash) {
 		return errors.Wrap(types.ErrInvalid, "code hashes not same")
@@ -253,35 +299,29 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	}
 	// 0x01 | codeID (uint64) -> ContractInfo
 	store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	k.addToCodeChecksumSecondaryIndex(ctx, codeInfo.CodeHash, codeID)
 	return nil
 }
 
-func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
+func (k Keeper) instantiate(
+	ctx sdk.Context,
+	codeID uint64,
+	creator, admin sdk.AccAddress,
+	initMsg []byte,
+	label string,
+	deposit sdk.Coins,
+	addressGenerator AddressGenerator,
+	authZ AuthorizationPolicy,
+) (_ sdk.AccAddress, _ []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "instantiate")
+	span := k.tracer.begin(ctx, types.ContractCallTypeInstantiate, nil)
+	defer func() { span.end(err) }()
+	span.setCodeID(codeID)
 
-	instanceCosts := k.gasRegister.NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
+	costs := k.runtimeCosts(ctx)
+	instanceCosts := costs.gasRegister.NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
 	ctx.GasMeter().ConsumeGas(instanceCosts, "Loading CosmWasm module: instantiate")
 
-	// create contract address
-	contractAddress := k.generateContractAddress(ctx, codeID)
-	existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress)
-	if existingAcct != nil {
-		return nil, nil, errors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
-	}
-
-	// deposit initial contract funds
-	if !deposit.IsZero() {
-		if err := k.bank.TransferCoins(ctx, creator, contractAddress, deposit); err != nil {
-			return nil, nil, err
-		}
-
-	} else {
-		// create an empty account (so we don't have issues later)
-		// TODO: can we remove this?
-		contractAccount := k.accountKeeper.NewAccountWithAddress(ctx, contractAddress)
-		k.accountKeeper.SetAccount(ctx, contractAccount)
-	}
-
 	// get contact info
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get(types.GetCodeKey(codeID))
@@ -294,6 +334,27 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	if !authZ.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, errors.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
 	}
+	if err := k.ensureLabelAvailable(ctx, label); err != nil {
+		return nil, nil, err
+	}
+
+	// create contract address
+	contractAddress := addressGenerator(ctx, codeID, codeInfo.CodeHash)
+	span.setContract(contractAddress)
+	if k.HasContractInfo(ctx, contractAddress) {
+		return nil, nil, errors.Wrap(types.ErrDuplicate, "instance with this code id, sender, salt and msg exists")
+	}
+
+	if err := k.createContractAccount(ctx, contractAddress); err != nil {
+		return nil, nil, err
+	}
+
+	// deposit initial contract funds
+	if !deposit.IsZero() {
+		if err := k.bank.TransferCoins(ctx, creator, contractAddress, deposit); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// prepare params for contract instantiate call
 	env := types.NewEnv(ctx, contractAddress)
@@ -305,12 +366,12 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	querier := k.newQueryHandler(ctx, contractAddress, costs.gasRegister)
 
 	// instantiate wasm contract
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, types.NewStoreAdapter(span.store(prefixStore)), costs.api, querier, k.gasMeter(ctx, costs.gasRegister), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if err != nil {
 		return nil, nil, errors.Wrap(types.ErrInstantiateFailed, err.Error())
 	}
@@ -336,6 +397,8 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	// store contract before dispatch so that contract could be called back
 	historyEntry := contractInfo.InitialHistory(initMsg)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddress, historyEntry)
+	k.addToContractCreatorSecondaryIndex(ctx, creator, historyEntry.Updated, contractAddress)
+	k.addToContractLabelSecondaryIndex(ctx, label, contractAddress)
 	k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	k.storeContractInfo(ctx, contractAddress, &contractInfo)
 
@@ -349,7 +412,7 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	if res.Err != "" {
 		return nil, nil, errors.Wrap(types.ErrInstantiateFailed, res.Err)
 	}
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, costs.gasRegister, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "dispatch")
 	}
@@ -358,14 +421,18 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 }
 
 // Execute executes the contract instance
-func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (_ []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
+	span := k.tracer.begin(ctx, types.ContractCallTypeExecute, contractAddress)
+	defer func() { span.end(err) }()
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	span.setCodeID(contractInfo.CodeID)
 
-	executeCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	costs := k.runtimeCosts(ctx)
+	executeCosts := costs.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(executeCosts, "Loading CosmWasm module: execute")
 
 	// add more funds
@@ -379,10 +446,10 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	info := types.NewInfo(caller, coins)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	querier := k.newQueryHandler(ctx, contractAddress, costs.gasRegister)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, types.NewStoreAdapter(span.store(prefixStore)), costs.api, querier, k.gasMeter(ctx, costs.gasRegister), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return nil, errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -396,7 +463,7 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if res.Err != "" {
 		return nil, errors.Wrap(types.ErrExecuteFailed, res.Err)
 	}
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, costs.gasRegister, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errors.Wrap(err, "dispatch")
 	}
@@ -404,9 +471,13 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	return data, nil
 }
 
-func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
+func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) (_ []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
-	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
+	span := k.tracer.begin(ctx, types.ContractCallTypeMigrate, contractAddress)
+	defer func() { span.end(err) }()
+	span.setCodeID(newCodeID)
+	costs := k.runtimeCosts(ctx)
+	migrateSetupCosts := costs.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(migrateSetupCosts, "Loading CosmWasm module: migrate")
 
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
@@ -441,13 +512,13 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	querier := k.newQueryHandler(ctx, contractAddress, costs.gasRegister)
 
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, types.NewStoreAdapter(span.store(prefixStore)), costs.api, &querier, k.gasMeter(ctx, costs.gasRegister), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if err != nil {
 		return nil, errors.Wrap(types.ErrMigrationFailed, err.Error())
 	}
@@ -470,7 +541,7 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if res.Err != "" {
 		return nil, errors.Wrap(types.ErrMigrationFailed, res.Err)
 	}
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, costs.gasRegister, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errors.Wrap(err, "dispatch")
 	}
@@ -481,23 +552,27 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 // Sudo allows priviledged access to a contract. This can never be called by an external tx, but only by
 // another native Go module directly, or on-chain governance (if sudo proposals are enabled). Thus, the keeper doesn't
 // place any access controls on it, that is the responsibility or the app developer (who passes the wasm.Keeper in app.go)
-func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
+func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) (_ []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "sudo")
+	span := k.tracer.begin(ctx, types.ContractCallTypeSudo, contractAddress)
+	defer func() { span.end(err) }()
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	span.setCodeID(contractInfo.CodeID)
 
-	sudoSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	costs := k.runtimeCosts(ctx)
+	sudoSetupCosts := costs.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(sudoSetupCosts, "Loading CosmWasm module: sudo")
 
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	querier := k.newQueryHandler(ctx, contractAddress, costs.gasRegister)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(span.store(prefixStore)), costs.api, querier, k.gasMeter(ctx, costs.gasRegister), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return nil, errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -511,7 +586,7 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 	if res.Err != "" {
 		return nil, errors.Wrap(types.ErrExecuteFailed, res.Err)
 	}
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, costs.gasRegister, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errors.Wrap(err, "dispatch")
 	}
@@ -520,23 +595,27 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 }
 
 // reply is only called from keeper internal functions (dispatchSubmessages) after processing the submessage
-func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) (_ []byte, err error) {
+	span := k.tracer.begin(ctx, types.ContractCallTypeReply, contractAddress)
+	defer func() { span.end(err) }()
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	span.setCodeID(contractInfo.CodeID)
 
 	// always consider this pinned
-	replyCosts := k.gasRegister.ReplyCosts(true, reply)
+	costs := k.runtimeCosts(ctx)
+	replyCosts := costs.gasRegister.ReplyCosts(true, reply)
 	ctx.GasMeter().ConsumeGas(replyCosts, "Loading CosmWasm module: reply")
 
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	querier := k.newQueryHandler(ctx, contractAddress, costs.gasRegister)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, types.NewStoreAdapter(span.store(prefixStore)), costs.api, querier, k.gasMeter(ctx, costs.gasRegister), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return nil, errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -550,7 +629,7 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 	if res.Err != "" {
 		return nil, errors.Wrap(types.ErrExecuteFailed, res.Err)
 	}
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err := k.handleContractResponse(ctx, costs.gasRegister, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errors.Wrap(err, "dispatch")
 	}
@@ -569,6 +648,102 @@ func (k Keeper) removeFromContractCodeSecondaryIndex(ctx sdk.Context, contractAd
 	ctx.KVStore(k.storeKey).Delete(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry))
 }
 
+// addToContractCreatorSecondaryIndex adds element to the index for contracts-by-creator queries
+func (k Keeper) addToContractCreatorSecondaryIndex(ctx sdk.Context, creatorAddress sdk.AccAddress, position *types.AbsoluteTxPosition, contractAddress sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetContractByCreatorSecondaryIndexKey(creatorAddress, position, contractAddress), []byte{})
+}
+
+// IterateContractsByCreator iterates over all contracts created by the given address ASC on creation time.
+func (k Keeper) IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractsByCreatorPrefix(creator))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(key[types.AbsoluteTxPositionLen:]) {
+			return
+		}
+	}
+}
+
+// addToCodeChecksumSecondaryIndex adds element to the index for code-by-checksum queries
+func (k Keeper) addToCodeChecksumSecondaryIndex(ctx sdk.Context, checksum []byte, codeID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetCodeByChecksumSecondaryIndexKey(checksum, codeID), []byte{})
+}
+
+// IterateCodesByChecksum iterates over all code ids stored with the given checksum ASC on code id.
+func (k Keeper) IterateCodesByChecksum(ctx sdk.Context, checksum []byte, cb func(codeID uint64) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetCodeByChecksumSecondaryIndexPrefix(checksum))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(sdk.BigEndianToUint64(iter.Key())) {
+			return
+		}
+	}
+}
+
+// setAccessConfig updates the instantiate permission of an existing code
+func (k Keeper) setAccessConfig(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, newConfig types.AccessConfig, authZ AuthorizationPolicy) error {
+	info := k.GetCodeInfo(ctx, codeID)
+	if info == nil {
+		return errors.Wrapf(types.ErrNotFound, "code id: %d", codeID)
+	}
+	creator, err := sdk.AccAddressFromBech32(info.Creator)
+	if err != nil {
+		return errors.Wrap(err, "creator")
+	}
+	isSubset := newConfig.IsSubset(k.getInstantiateAccessConfig(ctx).With(creator))
+	if !authZ.CanModifyCodeAccessConfig(creator, caller, isSubset) {
+		return errors.Wrap(sdkerrors.ErrUnauthorized, "can not modify code access config")
+	}
+
+	info.InstantiateConfig = newConfig
+	k.storeCodeInfo(ctx, codeID, *info)
+
+	evt := sdk.NewEvent(
+		types.EventTypeUpdateCodeAccessConfig,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyCodePermission, newConfig.Permission.String()),
+	)
+	if addrs := newConfig.AllowedClients(); len(addrs) != 0 {
+		bech32Addrs := make([]string, len(addrs))
+		for i, v := range addrs {
+			bech32Addrs[i] = v.String()
+		}
+		evt.AppendAttributes(sdk.NewAttribute(types.AttributeKeyAuthorizedAddresses, strings.Join(bech32Addrs, ",")))
+	}
+	ctx.EventManager().EmitEvent(evt)
+	return nil
+}
+
+// addToContractLabelSecondaryIndex adds element to the index for contracts-by-label queries
+func (k Keeper) addToContractLabelSecondaryIndex(ctx sdk.Context, label string, contractAddress sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetContractByLabelSecondaryIndexKey(label, contractAddress), []byte{})
+}
+
+// removeFromContractLabelSecondaryIndex removes element from the index for contracts-by-label queries
+func (k Keeper) removeFromContractLabelSecondaryIndex(ctx sdk.Context, label string, contractAddress sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Delete(types.GetContractByLabelSecondaryIndexKey(label, contractAddress))
+}
+
+// IterateContractsByLabel iterates over all contracts with the given label ASC on the contract address.
+func (k Keeper) IterateContractsByLabel(ctx sdk.Context, label string, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractsByLabelPrefix(label))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
+
 // IterateContractsByCode iterates over all contracts with given codeID ASC on code update time.
 func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool) {
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(codeID))
@@ -583,6 +758,22 @@ func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(a
 	}
 }
 
+// createContractAccount creates a new account for the contract address. An existing account is only
+// replaced when it is of an accepted type and was never used, so that the address can be pre-funded.
+// The balance is kept.
+func (k Keeper) createContractAccount(ctx sdk.Context, contractAddress sdk.AccAddress) error {
+	if existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress); existingAcct != nil {
+		if existingAcct.GetSequence() != 0 || existingAcct.GetPubKey() != nil {
+			return errors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
+		}
+		if _, ok := k.acceptedAccountTypes[reflect.TypeOf(existingAcct)]; !ok {
+			return errors.Wrapf(types.ErrAccountExists, "%s of type %T", existingAcct.GetAddress(), existingAcct)
+		}
+	}
+	k.accountKeeper.SetAccount(ctx, k.accountKeeper.NewAccountWithAddress(ctx, contractAddress))
+	return nil
+}
+
 func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error {
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
@@ -596,6 +787,50 @@ func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAd
 	return nil
 }
 
+func (k Keeper) setContractLabel(ctx sdk.Context, contractAddress, caller sdk.AccAddress, newLabel string, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return errors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if contractInfo.Label == newLabel {
+		return nil
+	}
+	if err := k.ensureLabelAvailable(ctx, newLabel); err != nil {
+		return err
+	}
+	k.removeFromContractLabelSecondaryIndex(ctx, contractInfo.Label, contractAddress)
+	k.addToContractLabelSecondaryIndex(ctx, newLabel, contractAddress)
+	contractInfo.Label = newLabel
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateContractLabel,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyNewLabel, newLabel),
+	))
+	return nil
+}
+
+// ensureLabelAvailable returns an error when unique contract labels are enabled and the label
+// is already used by another contract
+func (k Keeper) ensureLabelAvailable(ctx sdk.Context, label string) error {
+	if !k.uniqueContractLabels {
+		return nil
+	}
+	var exists bool
+	k.IterateContractsByLabel(ctx, label, func(sdk.AccAddress) bool {
+		exists = true
+		return true
+	})
+	if exists {
+		return errors.Wrapf(types.ErrDuplicate, "contract label: %s", label)
+	}
+	return nil
+}
+
 func (k Keeper) appendToContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress, newEntries ...types.ContractCodeHistoryEntry) {
 	store := ctx.KVStore(k.storeKey)
 	// find last element position
@@ -631,36 +866,35 @@ func (k Keeper) GetContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress)
 
 // getLastContractHistoryEntry returns the last element from history. To be used internally only as it panics when none exists
 func (k Keeper) getLastContractHistoryEntry(ctx sdk.Context, contractAddr sdk.AccAddress) types.ContractCodeHistoryEntry {
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractCodeHistoryElementPrefix(contractAddr))
-	iter := prefixStore.ReverseIterator(nil, nil)
-	defer iter.Close()
-
-	var r types.ContractCodeHistoryEntry
-	if !iter.Valid() {
+	r, found := k.lastContractHistoryEntry(ctx, contractAddr)
+	if !found {
 		// all contracts have a history
 		panic(fmt.Sprintf("no history for %s", contractAddr.String()))
 	}
-	k.cdc.MustUnmarshal(iter.Value(), &r)
 	return r
 }
 
 // QuerySmart queries the smart contract itself.
-func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
+func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) (_ []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-smart")
+	span := k.tracer.begin(ctx, types.ContractCallTypeQuery, contractAddr)
+	defer func() { span.end(err) }()
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
+	span.setCodeID(contractInfo.CodeID)
 
-	smartQuerySetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(req))
+	costs := k.runtimeCosts(ctx)
+	smartQuerySetupCosts := costs.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(req))
 	ctx.GasMeter().ConsumeGas(smartQuerySetupCosts, "Loading CosmWasm module: query")
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddr)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
 	env := types.NewEnv(ctx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, types.NewStoreAdapter(span.store(prefixStore)), costs.api, querier, k.gasMeter(ctx, costs.gasRegister), k.runtimeGasForContract(ctx, costs.gasRegister), costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if qErr != nil {
 		return nil, errors.Wrap(types.ErrQueryFailed, qErr.Error())
 	}
@@ -853,6 +1087,71 @@ func (k Keeper) unpinCode(ctx sdk.Context, codeID uint64) error {
 	return nil
 }
 
+// removeCode deletes a code without contract instances from the store. The wasm code is removed
+// from the wasmvm cache at the end of the block that is types.CodeRemovalDelay blocks later, as the cache is
+// not reverted with the state and is still read by snapshots and queries of earlier heights.
+func (k Keeper) removeCode(ctx sdk.Context, codeID uint64) error {
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return errors.Wrap(types.ErrNotFound, "code info")
+	}
+	var inUse bool
+	k.IterateContractsByCode(ctx, codeID, func(sdk.AccAddress) bool {
+		inUse = true
+		return true
+	})
+	if inUse {
+		return errors.Wrap(types.ErrCodeInUse, "contract instances exist")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCodeKey(codeID))
+	store.Delete(types.GetPinnedCodeIndexPrefix(codeID))
+	store.Delete(types.GetCodeByChecksumSecondaryIndexKey(codeInfo.CodeHash, codeID))
+	store.Set(types.GetCodeRemovalQueueKey(codeInfo.CodeHash), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight()+types.CodeRemovalDelay)))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveCode,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(codeInfo.CodeHash)),
+	))
+	return nil
+}
+
+// ProcessCodeRemovals unpins and removes the wasm codes of the removed code ids from the wasmvm cache
+// once their removal height is reached. Codes with a checksum that is still used by another code id are kept.
+func (k Keeper) ProcessCodeRemovals(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iter := prefix.NewStore(store, types.CodeRemovalQueuePrefix).Iterator(nil, nil)
+	var checksums [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if int64(sdk.BigEndianToUint64(iter.Value())) > ctx.BlockHeight() {
+			continue
+		}
+		checksums = append(checksums, bytes.Clone(iter.Key()))
+	}
+	iter.Close()
+
+	for _, checksum := range checksums {
+		store.Delete(types.GetCodeRemovalQueueKey(checksum))
+		var inUse bool
+		k.IterateCodesByChecksum(ctx, checksum, func(uint64) bool {
+			inUse = true
+			return true
+		})
+		if inUse {
+			continue
+		}
+		if err := k.wasmVM.Unpin(checksum); err != nil {
+			k.Logger(ctx).Error("failed to unpin removed code", "checksum", hex.EncodeToString(checksum), "error", err)
+		}
+		// the wasm code may not exist in the cache of a node that was restored from a snapshot
+		if err := k.wasmVM.RemoveCode(checksum); err != nil {
+			k.Logger(ctx).Error("failed to remove code from wasmvm cache", "checksum", hex.EncodeToString(checksum), "error", err)
+		}
+	}
+}
+
 // IsPinnedCode returns true when codeID is pinned in wasmvm cache
 func (k Keeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
 	store := ctx.KVStore(k.storeKey)
@@ -893,6 +1192,7 @@ func (k Keeper) setContractInfoExtension(ctx sdk.Context, contractAddr sdk.AccAd
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
+	gasRegister GasRegister,
 	contractAddr sdk.AccAddress,
 	ibcPort string,
 	msgs []wasmvmtypes.SubMsg,
@@ -900,7 +1200,7 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Array[wasmvmtypes.Event],
 ) ([]byte, error) {
-	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
+	attributeGasCost := gasRegister.EventCosts(attrs, evts)
 	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
 	// emit all events from this contract itself
 	if len(attrs) != 0 {
@@ -909,6 +1209,7 @@ func (k *Keeper) handleContractResponse(
 			return nil, err
 		}
 		ctx.EventManager().EmitEvents(wasmEvents)
+		k.tracer.recordEvents(ctx, wasmEvents)
 	}
 	if len(evts) > 0 {
 		customEvents, err := newCustomEvents(evts, contractAddr)
@@ -916,11 +1217,13 @@ func (k *Keeper) handleContractResponse(
 			return nil, err
 		}
 		ctx.EventManager().EmitEvents(customEvents)
+		k.tracer.recordEvents(ctx, customEvents)
 	}
+	ibcPort = k.ibcPortForMsgs(ctx, contractAddr, ibcPort, msgs)
 	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
 }
 
-func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
+func (k Keeper) runtimeGasForContract(ctx sdk.Context, gasRegister GasRegister) uint64 {
 	meter := ctx.GasMeter()
 	if meter.IsOutOfGas() {
 		return 0
@@ -928,11 +1231,11 @@ func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
 	if meter.Limit() == 0 { // infinite gas meter with limit=0 and not out of gas
 		return math.MaxUint64
 	}
-	return k.gasRegister.ToWasmVMGas(meter.Limit() - meter.GasConsumedToLimit())
+	return gasRegister.ToWasmVMGas(meter.Limit() - meter.GasConsumedToLimit())
 }
 
-func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
-	consumed := k.gasRegister.FromWasmVMGas(gas)
+func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gasRegister GasRegister, gas uint64) {
+	consumed := gasRegister.FromWasmVMGas(gas)
 	ctx.GasMeter().ConsumeGas(consumed, "wasm contract")
 	// throw OutOfGas error if we ran out (got exactly to zero due to better limit enforcing)
 	if ctx.GasMeter().IsOutOfGas() {
@@ -940,20 +1243,6 @@ func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
 	}
 }
 
-// generates a contract address from codeID + instanceID
-func (k Keeper) generateContractAddress(ctx sdk.Context, codeID uint64) sdk.AccAddress {
-	instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
-	return BuildContractAddress(codeID, instanceID)
-}
-
-// BuildContractAddress builds an sdk account address for a contract.
-func BuildContractAddress(codeID, instanceID uint64) sdk.AccAddress {
-	contractID := make([]byte, 16)
-	binary.BigEndian.PutUint64(contractID[:8], codeID)
-	binary.BigEndian.PutUint64(contractID[8:], instanceID)
-	return address.Module(types.ModuleName, contractID)[:types.ContractAddrLen]
-}
-
 func (k Keeper) autoIncrementID(ctx sdk.Context, lastIDKey []byte) uint64 {
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get(lastIDKey)
@@ -987,7 +1276,9 @@ func (k Keeper) importAutoIncrementID(ctx sdk.Context, lastIDKey []byte, val uin
 	return nil
 }
 
-func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *types.ContractInfo, state []types.Model) error {
+// importContract stores the contract from genesis. Without a code history the contract is reset
+// to the genesis position, otherwise the history and created position are restored as exported.
+func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *types.ContractInfo, state []types.Model, history []types.ContractCodeHistoryEntry) error {
 	if !k.containsCodeInfo(ctx, c.CodeID) {
 		return errors.Wrapf(types.ErrNotFound, "code id: %d", c.CodeID)
 	}
@@ -995,15 +1286,25 @@ func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *
 		return errors.Wrapf(types.ErrDuplicate, "contract: %s", contractAddr)
 	}
 
-	historyEntry := c.ResetFromGenesis(ctx)
-	k.appendToContractHistory(ctx, contractAddr, historyEntry)
+	if len(history) == 0 {
+		history = []types.ContractCodeHistoryEntry{c.ResetFromGenesis(ctx)}
+	}
+	k.appendToContractHistory(ctx, contractAddr, history...)
 	k.storeContractInfo(ctx, contractAddr, c)
-	k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntry)
+	k.addToContractCodeSecondaryIndex(ctx, contractAddr, history[len(history)-1])
+	creatorAddr, err := sdk.AccAddressFromBech32(c.Creator)
+	if err != nil {
+		return errors.Wrap(err, "creator")
+	}
+	k.addToContractCreatorSecondaryIndex(ctx, creatorAddr, c.Created, contractAddr)
+	k.addToContractLabelSecondaryIndex(ctx, c.Label, contractAddr)
 	return k.importContractState(ctx, contractAddr, state)
 }
 
-func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
-	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress, gasRegister GasRegister) QueryHandler {
+	h := NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, gasRegister)
+	h.errorDetails = k
+	return h
 }
 
 // MultipliedGasMeter wraps the GasMeter from context and multiplies all reads by out defined multiplier
@@ -1022,8 +1323,8 @@ func (m MultipliedGasMeter) GasConsumed() storetypes.Gas {
 	return m.GasRegister.ToWasmVMGas(m.originalMeter.GasConsumed())
 }
 
-func (k Keeper) gasMeter(ctx sdk.Context) MultipliedGasMeter {
-	return NewMultipliedGasMeter(ctx.GasMeter(), k.gasRegister)
+func (k Keeper) gasMeter(ctx sdk.Context, gasRegister GasRegister) MultipliedGasMeter {
+	return NewMultipliedGasMeter(ctx.GasMeter(), gasRegister)
 }
 
 // Logger returns a module-specific logger.
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -15,9 +16,18 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
 	stypes "cosmossdk.io/store/types"
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
 	"github.com/stretchr/testify/assert"
@@ -114,9 +124,9 @@ func TestCreateStoresInstantiatePermission(t *testing.T) {
 			srcPermission: types.AccessTypeNobody,
 			expInstConf:   types.AllowNobody,
 		},
-		"onlyAddress with matching address": {
-			srcPermission: types.AccessTypeOnlyAddress,
-			expInstConf:   types.AccessConfig{Permission: types.AccessTypeOnlyAddress, Address: myAddr.String()},
+		"anyOfAddresses with matching address": {
+			srcPermission: types.AccessTypeAnyOfAddresses,
+			expInstConf:   types.AccessTypeAnyOfAddresses.With(myAddr),
 		},
 	}
 	for msg, spec := range specs {
@@ -162,11 +172,11 @@ func TestCreateWithParamPermissions(t *testing.T) {
 			srcPermission: types.AllowNobody,
 			expError:      sdkerrors.ErrUnauthorized,
 		},
-		"onlyAddress with matching address": {
-			srcPermission: types.AccessTypeOnlyAddress.With(creator),
+		"anyOfAddresses with matching address": {
+			srcPermission: types.AccessTypeAnyOfAddresses.With(creator),
 		},
-		"onlyAddress with non matching address": {
-			srcPermission: types.AccessTypeOnlyAddress.With(otherAddr),
+		"anyOfAddresses with non matching address": {
+			srcPermission: types.AccessTypeAnyOfAddresses.With(otherAddr),
 			expError:      sdkerrors.ErrUnauthorized,
 		},
 	}
@@ -431,12 +441,12 @@ func TestInstantiateWithPermissions(t *testing.T) {
 			srcActor:      myAddr,
 			expError:      sdkerrors.ErrUnauthorized,
 		},
-		"onlyAddress with matching address": {
-			srcPermission: types.AccessTypeOnlyAddress.With(myAddr),
+		"anyOfAddresses with matching address": {
+			srcPermission: types.AccessTypeAnyOfAddresses.With(myAddr),
 			srcActor:      myAddr,
 		},
-		"onlyAddress with non matching address": {
-			srcPermission: types.AccessTypeOnlyAddress.With(otherAddr),
+		"anyOfAddresses with non matching address": {
+			srcPermission: types.AccessTypeAnyOfAddresses.With(otherAddr),
 			expError:      sdkerrors.ErrUnauthorized,
 		},
 	}
@@ -479,7 +489,7 @@ func TestInstantiateWithContractDataResponse(t *testing.T) {
 			return &wasmvmtypes.Response{Data: []byte("my-response-data")}, 0, nil
 		},
 		AnalyzeCodeFn: wasmtesting.WithoutIBCAnalyzeFn,
-		CreateFn:      wasmtesting.NoOpCreateFn,
+		StoreCodeFn:   wasmtesting.NoOpStoreCodeFn,
 	}
 
 	example := StoreRandomContract(t, ctx, keepers, wasmerMock)
@@ -488,6 +498,116 @@ func TestInstantiateWithContractDataResponse(t *testing.T) {
 	assert.Equal(t, []byte("my-response-data"), data)
 }
 
+func TestCreateContractAccount(t *testing.T) {
+	contractAddr := BuildContractAddress(1, 1)
+	specs := map[string]struct {
+		existing func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI
+		opts     []Option
+		expErr   error
+	}{
+		"no existing account": {
+			existing: func(*testing.T, authkeeper.AccountKeeper, sdk.Context) sdk.AccountI { return nil },
+		},
+		"unused base account": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				return ak.NewAccountWithAddress(ctx, contractAddr)
+			},
+		},
+		"base account with sequence": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				acc := ak.NewAccountWithAddress(ctx, contractAddr)
+				require.NoError(t, acc.SetSequence(1))
+				return acc
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"base account with pubkey": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				acc := ak.NewAccountWithAddress(ctx, contractAddr)
+				require.NoError(t, acc.SetPubKey(secp256k1.GenPrivKey().PubKey()))
+				return acc
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"unused vesting account": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				return unusedVestingAccount(t, ak, ctx, contractAddr)
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"unused vesting account when accepted": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				return unusedVestingAccount(t, ak, ctx, contractAddr)
+			},
+			opts: []Option{WithAcceptedAccountTypesOnContractInstantiation(&authtypes.BaseAccount{}, &vestingtypes.ContinuousVestingAccount{})},
+		},
+		"unused base account when not accepted": {
+			existing: func(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context) sdk.AccountI {
+				return ak.NewAccountWithAddress(ctx, contractAddr)
+			},
+			opts:   []Option{WithAcceptedAccountTypesOnContractInstantiation(&vestingtypes.ContinuousVestingAccount{})},
+			expErr: types.ErrAccountExists,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, ak := setupAccountKeeper(t)
+			k := &Keeper{accountKeeper: accountKeeperAdapter{ak}, acceptedAccountTypes: map[reflect.Type]struct{}{reflect.TypeOf(&authtypes.BaseAccount{}): {}}}
+			for _, o := range spec.opts {
+				o.apply(k)
+			}
+			existing := spec.existing(t, ak, ctx)
+			if existing != nil {
+				ak.SetAccount(ctx, existing)
+			}
+
+			// when
+			gotErr := k.createContractAccount(ctx, contractAddr)
+
+			// then
+			got := ak.GetAccount(ctx, contractAddr)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.IsType(t, existing, got)
+				assert.Equal(t, existing.GetAccountNumber(), got.GetAccountNumber())
+				return
+			}
+			require.NoError(t, gotErr)
+			require.IsType(t, &authtypes.BaseAccount{}, got)
+			assert.Equal(t, uint64(0), got.GetSequence())
+			assert.Nil(t, got.GetPubKey())
+			if existing != nil {
+				assert.NotEqual(t, existing.GetAccountNumber(), got.GetAccountNumber())
+			}
+		})
+	}
+}
+
+func unusedVestingAccount(t *testing.T, ak authkeeper.AccountKeeper, ctx sdk.Context, addr sdk.AccAddress) sdk.AccountI {
+	t.Helper()
+	base := ak.NewAccountWithAddress(ctx, addr).(*authtypes.BaseAccount)
+	bva, err := vestingtypes.NewBaseVestingAccount(base, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), 2)
+	require.NoError(t, err)
+	return vestingtypes.NewContinuousVestingAccountRaw(bva, 1)
+}
+
+func setupAccountKeeper(t *testing.T) (sdk.Context, authkeeper.AccountKeeper) {
+	t.Helper()
+	storeKey := stypes.NewKVStoreKey(authtypes.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, stypes.NewTransientStoreKey("transient_test"))
+	encCfg := moduletestutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, vesting.AppModuleBasic{})
+	ak := authkeeper.NewAccountKeeper(
+		encCfg.Codec,
+		runtime.NewKVStoreService(storeKey),
+		authtypes.ProtoBaseAccount,
+		nil,
+		addresscodec.NewBech32Codec(sdk.GetConfig().GetBech32AccountAddrPrefix()),
+		sdk.GetConfig().GetBech32AccountAddrPrefix(),
+		authtypes.NewModuleAddress("gov").String(),
+	)
+	return ctx, ak
+}
+
 func TestExecute(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -1451,6 +1571,204 @@ func TestUnpinCode(t *testing.T) {
 	assert.Equal(t, exp, em.Events())
 }
 
+func TestRemoveCode(t *testing.T) {
+	ctx, keepers := CreateDefaultTestInput(t)
+	k := keepers.WasmKeeper
+	checksum := bytes.Repeat([]byte{1}, types.ChecksumLen)
+	codeInfo := types.CodeInfoFixture(func(info *types.CodeInfo) { info.CodeHash = checksum })
+	k.storeCodeInfo(ctx, 1, codeInfo)
+	k.addToCodeChecksumSecondaryIndex(ctx, checksum, 1)
+	ctx.KVStore(k.storeKey).Set(types.GetPinnedCodeIndexPrefix(1), []byte{1})
+	k.storeCodeInfo(ctx, 2, codeInfo)
+	contractAddr := BuildContractAddress(2, 1)
+	info := types.ContractInfoFixture(func(info *types.ContractInfo) { info.CodeID = 2 })
+	require.NoError(t, k.importContract(ctx, contractAddr, &info, nil, nil))
+
+	specs := map[string]struct {
+		codeID uint64
+		expErr error
+	}{
+		"unreferenced code": {
+			codeID: 1,
+		},
+		"code with contract instances": {
+			codeID: 2,
+			expErr: types.ErrCodeInUse,
+		},
+		"unknown code": {
+			codeID: 99,
+			expErr: types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := k.removeCode(ctx.WithEventManager(em), spec.codeID)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Nil(t, k.GetCodeInfo(ctx, spec.codeID))
+			assert.False(t, k.IsPinnedCode(ctx, spec.codeID))
+			k.IterateCodesByChecksum(ctx, checksum, func(uint64) bool {
+				t.Fatal("unexpected checksum index entry")
+				return true
+			})
+			expHeight := sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight() + types.CodeRemovalDelay))
+			assert.Equal(t, expHeight, ctx.KVStore(k.storeKey).Get(types.GetCodeRemovalQueueKey(checksum)))
+
+			// and events
+			exp := sdk.Events{sdk.NewEvent("remove_code",
+				sdk.NewAttribute("code_id", "1"),
+				sdk.NewAttribute("checksum", "0101010101010101010101010101010101010101010101010101010101010101"),
+			)}
+			assert.Equal(t, exp, em.Events())
+		})
+	}
+}
+
+func TestProcessCodeRemovals(t *testing.T) {
+	checksumA := bytes.Repeat([]byte{1}, types.ChecksumLen)
+	checksumB := bytes.Repeat([]byte{2}, types.ChecksumLen)
+	checksumC := bytes.Repeat([]byte{3}, types.ChecksumLen)
+	ctx, keepers := CreateDefaultTestInput(t)
+	k := keepers.WasmKeeper
+	ctx = ctx.WithBlockHeight(100)
+	var unpinned, removed []wasmvm.Checksum
+	k.wasmVM = &wasmtesting.MockWasmer{
+		UnpinFn: func(checksum wasmvm.Checksum) error {
+			unpinned = append(unpinned, checksum)
+			return nil
+		},
+		RemoveCodeFn: func(checksum wasmvm.Checksum) error {
+			removed = append(removed, checksum)
+			return errors.New("not in cache")
+		},
+	}
+	// checksum B is still used by another code
+	k.addToCodeChecksumSecondaryIndex(ctx, checksumB, 2)
+	ctx.KVStore(k.storeKey).Set(types.GetCodeRemovalQueueKey(checksumA), sdk.Uint64ToBigEndian(100))
+	ctx.KVStore(k.storeKey).Set(types.GetCodeRemovalQueueKey(checksumB), sdk.Uint64ToBigEndian(99))
+	// checksum C is not due yet
+	ctx.KVStore(k.storeKey).Set(types.GetCodeRemovalQueueKey(checksumC), sdk.Uint64ToBigEndian(101))
+
+	// when
+	k.ProcessCodeRemovals(ctx)
+
+	// then
+	assert.Equal(t, []wasmvm.Checksum{checksumA}, unpinned)
+	assert.Equal(t, []wasmvm.Checksum{checksumA}, removed)
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetCodeRemovalQueueKey(checksumA)))
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetCodeRemovalQueueKey(checksumB)))
+	assert.True(t, ctx.KVStore(k.storeKey).Has(types.GetCodeRemovalQueueKey(checksumC)))
+}
+
+func TestSetContractLabel(t *testing.T) {
+	admin := sdk.AccAddress(bytes.Repeat([]byte{1}, types.SDKAddrLen))
+	other := sdk.AccAddress(bytes.Repeat([]byte{2}, types.SDKAddrLen))
+	contractAddr := BuildContractAddress(1, 1)
+
+	specs := map[string]struct {
+		unique   bool
+		caller   sdk.AccAddress
+		contract sdk.AccAddress
+		newLabel string
+		expErr   error
+		expEvent bool
+	}{
+		"admin updates label": {
+			caller:   admin,
+			contract: contractAddr,
+			newLabel: "new",
+			expEvent: true,
+		},
+		"same label is a no-op": {
+			caller:   admin,
+			contract: contractAddr,
+			newLabel: "first",
+		},
+		"duplicate label allowed by default": {
+			caller:   admin,
+			contract: contractAddr,
+			newLabel: "second",
+			expEvent: true,
+		},
+		"duplicate label rejected when unique": {
+			unique:   true,
+			caller:   admin,
+			contract: contractAddr,
+			newLabel: "second",
+			expErr:   types.ErrDuplicate,
+		},
+		"unique label accepted when unique": {
+			unique:   true,
+			caller:   admin,
+			contract: contractAddr,
+			newLabel: "new",
+			expEvent: true,
+		},
+		"non admin": {
+			caller:   other,
+			contract: contractAddr,
+			newLabel: "new",
+			expErr:   sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			caller:   admin,
+			contract: BuildContractAddress(1, 99),
+			newLabel: "new",
+			expErr:   sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateDefaultTestInput(t)
+			k := keepers.WasmKeeper
+			k.uniqueContractLabels = spec.unique
+			k.storeCodeInfo(ctx, 1, types.CodeInfoFixture())
+			for i, label := range []string{"first", "second"} {
+				info := types.ContractInfoFixture(func(info *types.ContractInfo) {
+					info.Admin = admin.String()
+					info.Label = label
+				})
+				require.NoError(t, k.importContract(ctx, BuildContractAddress(1, uint64(i+1)), &info, nil, nil))
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := k.setContractLabel(ctx.WithEventManager(em), spec.contract, spec.caller, spec.newLabel, DefaultAuthorizationPolicy{})
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Equal(t, "first", k.GetContractInfo(ctx, contractAddr).Label)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.newLabel, k.GetContractInfo(ctx, contractAddr).Label)
+			var got []sdk.AccAddress
+			k.IterateContractsByLabel(ctx, spec.newLabel, func(addr sdk.AccAddress) bool {
+				got = append(got, addr)
+				return false
+			})
+			assert.Contains(t, got, contractAddr)
+			if spec.newLabel != "first" {
+				k.IterateContractsByLabel(ctx, "first", func(sdk.AccAddress) bool {
+					t.Fatal("unexpected label index entry")
+					return false
+				})
+			}
+			assert.Equal(t, spec.expEvent, len(em.Events()) != 0)
+		})
+	}
+}
+
 func TestInitializePinnedCodes(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	k := keepers.WasmKeeper
@@ -7,6 +7,7 @@ import (
 	storetypes "cosmossdk.io/store/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
@@ -16,7 +17,7 @@ import (
 // Messenger is an extension point for custom wasmd message handling
 type Messenger interface {
 	// DispatchMsg encodes the wasmVM message and dispatches it.
-	DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error)
+	DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error)
 }
 
 // replyer is a subset of keeper that can handle replies to submessages
@@ -38,7 +39,7 @@ func NewMessageDispatcher(messenger Messenger, keeper replyer) *MessageDispatche
 // DispatchMessages sends all messages.
 func (d MessageDispatcher) DispatchMessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.CosmosMsg) error {
 	for _, msg := range msgs {
-		events, _, err := d.messenger.DispatchMsg(ctx, contractAddr, ibcPort, msg)
+		events, _, _, err := d.messenger.DispatchMsg(ctx, contractAddr, ibcPort, msg)
 		if err != nil {
 			return err
 		}
@@ -49,7 +50,7 @@ func (d MessageDispatcher) DispatchMessages(ctx sdk.Context, contractAddr sdk.Ac
 }
 
 // dispatchMsgWithGasLimit sends a message with gas limit applied
-func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msg wasmvmtypes.CosmosMsg, gasLimit uint64) (events []sdk.Event, data [][]byte, err error) {
+func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msg wasmvmtypes.CosmosMsg, gasLimit uint64) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 	limitedMeter := storetypes.NewGasMeter(gasLimit)
 	subCtx := ctx.WithGasMeter(limitedMeter)
 
@@ -66,13 +67,13 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 			err = errors.Wrap(sdkerrors.ErrOutOfGas, "SubMsg hit gas limit")
 		}
 	}()
-	events, data, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg)
+	events, data, msgResponses, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg)
 
 	// make sure we charge the parent what was spent
 	spent := subCtx.GasMeter().GasConsumed()
 	ctx.GasMeter().ConsumeGas(spent, "From limited Sub-Message")
 
-	return events, data, err
+	return events, data, msgResponses, err
 }
 
 // DispatchSubmessages builds a sandbox to execute these messages and returns the execution result to the contract
@@ -97,11 +98,14 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		var err error
 		var events []sdk.Event
 		var data [][]byte
+		var msgResponses [][]*codectypes.Any
+		gasBefore := ctx.GasMeter().GasConsumed()
 		if limitGas {
-			events, data, err = d.dispatchMsgWithGasLimit(subCtx, contractAddr, ibcPort, msg.Msg, *msg.GasLimit)
+			events, data, msgResponses, err = d.dispatchMsgWithGasLimit(subCtx, contractAddr, ibcPort, msg.Msg, *msg.GasLimit)
 		} else {
-			events, data, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg.Msg)
+			events, data, msgResponses, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg.Msg)
 		}
+		gasUsed := ctx.GasMeter().GasConsumed() - gasBefore
 
 		// if it succeeds, commit state changes from submessage, and pass on events to Event Manager
 		var filteredEvents []sdk.Event
@@ -122,30 +126,37 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		// otherwise, we create a SubMsgResult and pass it into the calling contract
 		var result wasmvmtypes.SubMsgResult
 		if err == nil {
-			// just take the first one for now if there are multiple sub-sdk messages
-			// and safely return nothing if no data
+			// the deprecated data field keeps the first one for backwards compatibility,
+			// all responses are returned in msg_responses. Safely return nothing if no data
 			var responseData []byte
 			if len(data) > 0 {
 				responseData = data[0]
 			}
 			result = wasmvmtypes.SubMsgResult{
 				Ok: &wasmvmtypes.SubMsgResponse{
-					Events: sdkEventsToWasmVMEvents(filteredEvents),
-					Data:   responseData,
+					Events:       sdkEventsToWasmVMEvents(filteredEvents),
+					Data:         responseData,
+					MsgResponses: sdkMsgResponsesToWasmVMMsgResponses(msgResponses),
 				},
 			}
 		} else {
 			// Issue #759 - we don't return error string for worries of non-determinism
-			moduleLogger(ctx).Info("Redacting submessage error", "cause", err)
+			logRedactedError(ctx, "Redacting submessage error", err)
+			var errorDetailsVersion uint32
+			if s, ok := d.keeper.(errorDetailsSource); ok {
+				errorDetailsVersion = s.errorDetailsVersion(ctx)
+			}
 			result = wasmvmtypes.SubMsgResult{
-				Err: redactError(err).Error(),
+				Err: redactError(err, errorDetailsVersion).Error(),
 			}
 		}
 
 		// now handle the reply, we use the parent context, and abort on error
 		reply := wasmvmtypes.Reply{
-			ID:     msg.ID,
-			Result: result,
+			ID:      msg.ID,
+			Result:  result,
+			Payload: msg.Payload,
+			GasUsed: gasUsed,
 		}
 
 		// we can ignore any result returned as there is nothing to do with the data
@@ -161,20 +172,20 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 	return rsp, nil
 }
 
-// Issue #759 - we don't return error string for worries of non-determinism
-func redactError(err error) error {
+// Issue #759 - we don't return error string for worries of non-determinism.
+// Well known errors get a stable message from the error details registry of the given version.
+func redactError(err error, errorDetailsVersion uint32) error {
 	// Do not redact system errors
 	// SystemErrors must be created in x/wasm and we can ensure determinism
 	if wasmvmtypes.ToSystemError(err) != nil {
 		return err
 	}
 
-	// FIXME: do we want to hardcode some constant string mappings here as well?
-	// Or better document them? (SDK error string may change on a patch release to fix wording)
-	// sdk/11 is out of gas
-	// sdk/5 is insufficient funds (on bank send)
 	// (we can theoretically redact less in the future, but this is a first step to safety)
 	codespace, code, _ := errors.ABCIInfo(err, false)
+	if details, ok := types.ErrorDetails(errorDetailsVersion, codespace, code); ok {
+		return fmt.Errorf("codespace: %s, code: %d, details: %s", codespace, code, details)
+	}
 	return fmt.Errorf("codespace: %s, code: %d", codespace, code)
 }
 
@@ -200,6 +211,24 @@ func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
 	return res
 }
 
+// sdkMsgResponsesToWasmVMMsgResponses flattens the responses of all sdk messages that a
+// wasmvm message was encoded into
+func sdkMsgResponsesToWasmVMMsgResponses(msgResponses [][]*codectypes.Any) []wasmvmtypes.MsgResponse {
+	var res []wasmvmtypes.MsgResponse
+	for _, rsps := range msgResponses {
+		for _, rsp := range rsps {
+			if rsp == nil {
+				continue
+			}
+			res = append(res, wasmvmtypes.MsgResponse{
+				TypeURL: rsp.TypeUrl,
+				Value:   rsp.Value,
+			})
+		}
+	}
+	return res
+}
+
 func sdkAttributesToWasmVMAttributes(attrs []abci.EventAttribute) []wasmvmtypes.EventAttribute {
 	res := make([]wasmvmtypes.EventAttribute, len(attrs))
 	for i, attr := range attrs {
@@ -5,6 +5,7 @@ import (
 
 	"cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -12,13 +13,30 @@ import (
 var _ types.MsgServer = msgServer{}
 
 type msgServer struct {
-	keeper types.ContractOpsKeeper
+	keeper *Keeper
 }
 
-func NewMsgServerImpl(k types.ContractOpsKeeper) types.MsgServer {
+func NewMsgServerImpl(k *Keeper) types.MsgServer {
 	return &msgServer{keeper: k}
 }
 
+// contractKeeper returns the contract operations guarded by the authorization policy for the given actor.
+// The gov authorization policy applies when the actor is the module authority, the default policy otherwise.
+func (m msgServer) contractKeeper(actor string) types.ContractOpsKeeper {
+	if actor == m.keeper.GetAuthority() {
+		return NewGovPermissionKeeper(m.keeper)
+	}
+	return NewDefaultPermissionKeeper(m.keeper)
+}
+
+// ensureAuthority returns an error when the given address is not the module authority
+func (m msgServer) ensureAuthority(authority string) error {
+	if expected := m.keeper.GetAuthority(); expected != authority {
+		return errors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", expected, authority)
+	}
+	return nil
+}
+
 func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*types.MsgStoreCodeResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
@@ -32,7 +50,7 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	codeID, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
+	codeID, err := m.contractKeeper(msg.Sender).Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +80,7 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	contractAddr, data, err := m.keeper.Instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
+	contractAddr, data, err := m.contractKeeper(msg.Sender).Instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +91,37 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 	}, nil
 }
 
+func (m msgServer) InstantiateContract2(goCtx context.Context, msg *types.MsgInstantiateContract2) (*types.MsgInstantiateContract2Response, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errors.Wrap(err, "sender")
+	}
+	var adminAddr sdk.AccAddress
+	if msg.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return nil, errors.Wrap(err, "admin")
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	contractAddr, data, err := m.contractKeeper(msg.Sender).Instantiate2(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds, msg.Salt, msg.FixMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgInstantiateContract2Response{
+		Address: contractAddr.String(),
+		Data:    data,
+	}, nil
+}
+
 func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteContract) (*types.MsgExecuteContractResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
@@ -90,7 +139,7 @@ func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteC
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	data, err := m.keeper.Execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
+	data, err := m.contractKeeper(msg.Sender).Execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +166,7 @@ func (m msgServer) MigrateContract(goCtx context.Context, msg *types.MsgMigrateC
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	data, err := m.keeper.Migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg)
+	data, err := m.contractKeeper(msg.Sender).Migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +197,7 @@ func (m msgServer) UpdateAdmin(goCtx context.Context, msg *types.MsgUpdateAdmin)
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	if err := m.keeper.UpdateContractAdmin(ctx, contractAddr, senderAddr, newAdminAddr); err != nil {
+	if err := m.contractKeeper(msg.Sender).UpdateContractAdmin(ctx, contractAddr, senderAddr, newAdminAddr); err != nil {
 		return nil, err
 	}
 
@@ -172,9 +221,232 @@ func (m msgServer) ClearAdmin(goCtx context.Context, msg *types.MsgClearAdmin) (
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	if err := m.keeper.ClearContractAdmin(ctx, contractAddr, senderAddr); err != nil {
+	if err := m.contractKeeper(msg.Sender).ClearContractAdmin(ctx, contractAddr, senderAddr); err != nil {
 		return nil, err
 	}
 
 	return &types.MsgClearAdminResponse{}, nil
 }
+
+func (m msgServer) UpdateContractLabel(goCtx context.Context, msg *types.MsgUpdateContractLabel) (*types.MsgUpdateContractLabelResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.contractKeeper(msg.Sender).UpdateContractLabel(ctx, contractAddr, senderAddr, msg.NewLabel); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateContractLabelResponse{}, nil
+}
+
+func (m msgServer) UpdateInstantiateConfig(goCtx context.Context, msg *types.MsgUpdateInstantiateConfig) (*types.MsgUpdateInstantiateConfigResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errors.Wrap(err, "sender")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.contractKeeper(msg.Sender).SetAccessConfig(ctx, msg.CodeID, senderAddr, *msg.NewInstantiatePermission); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateInstantiateConfigResponse{}, nil
+}
+
+func (m msgServer) UpdateParams(goCtx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := m.keeper.SetParams(ctx, req.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+func (m msgServer) SudoContract(goCtx context.Context, req *types.MsgSudoContract) (*types.MsgSudoContractResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errors.Wrap(err, "contract")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	data, err := m.contractKeeper(req.Authority).Sudo(ctx, contractAddr, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSudoContractResponse{Data: data}, nil
+}
+
+func (m msgServer) PinCodes(goCtx context.Context, req *types.MsgPinCodes) (*types.MsgPinCodesResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractKeeper := m.contractKeeper(req.Authority)
+	for _, codeID := range req.CodeIDs {
+		if err := contractKeeper.PinCode(ctx, codeID); err != nil {
+			return nil, errors.Wrapf(err, "code id: %d", codeID)
+		}
+	}
+
+	return &types.MsgPinCodesResponse{}, nil
+}
+
+func (m msgServer) UnpinCodes(goCtx context.Context, req *types.MsgUnpinCodes) (*types.MsgUnpinCodesResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractKeeper := m.contractKeeper(req.Authority)
+	for _, codeID := range req.CodeIDs {
+		if err := contractKeeper.UnpinCode(ctx, codeID); err != nil {
+			return nil, errors.Wrapf(err, "code id: %d", codeID)
+		}
+	}
+
+	return &types.MsgUnpinCodesResponse{}, nil
+}
+
+func (m msgServer) RemoveCodes(goCtx context.Context, req *types.MsgRemoveCodes) (*types.MsgRemoveCodesResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractKeeper := m.contractKeeper(req.Authority)
+	for _, codeID := range req.CodeIDs {
+		if err := contractKeeper.RemoveCode(ctx, codeID); err != nil {
+			return nil, errors.Wrapf(err, "code id: %d", codeID)
+		}
+	}
+
+	return &types.MsgRemoveCodesResponse{}, nil
+}
+
+func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types.MsgStoreAndInstantiateContract) (*types.MsgStoreAndInstantiateContractResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errors.Wrap(err, "authority")
+	}
+	var adminAddr sdk.AccAddress
+	if req.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(req.Admin); err != nil {
+			return nil, errors.Wrap(err, "admin")
+		}
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractKeeper := m.contractKeeper(req.Authority)
+	codeID, err := contractKeeper.Create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission)
+	if err != nil {
+		return nil, err
+	}
+	if !req.UnpinCode {
+		if err := contractKeeper.PinCode(ctx, codeID); err != nil {
+			return nil, err
+		}
+	}
+
+	contractAddr, data, err := contractKeeper.Instantiate(ctx, codeID, authorityAddr, adminAddr, req.Msg, req.Label, req.Funds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgStoreAndInstantiateContractResponse{
+		Address: contractAddr.String(),
+		Data:    data,
+	}, nil
+}
+
+func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.MsgStoreAndMigrateContract) (*types.MsgStoreAndMigrateContractResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureAuthority(req.Authority); err != nil {
+		return nil, err
+	}
+
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errors.Wrap(err, "authority")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errors.Wrap(err, "contract")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractKeeper := m.contractKeeper(req.Authority)
+	codeID, err := contractKeeper.Create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := contractKeeper.Migrate(ctx, contractAddr, authorityAddr, codeID, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgStoreAndMigrateContractResponse{
+		CodeID:   codeID,
+		Checksum: m.keeper.GetCodeInfo(ctx, codeID).CodeHash,
+		Data:     data,
+	}, nil
+}
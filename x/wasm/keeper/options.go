@@ -2,10 +2,13 @@ package keeper
 
 import (
 	"fmt"
+	"reflect"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
-	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 type optsFn func(*Keeper)
@@ -14,9 +17,17 @@ func (f optsFn) apply(keeper *Keeper) {
 	f(keeper)
 }
 
+// postOptsFn options are applied after the default wasm engine was set up in the keeper
+type postOptsFn func(*Keeper)
+
+func (f postOptsFn) apply(keeper *Keeper) {
+	f(keeper)
+}
+
 // WithWasmEngine is an optional constructor parameter to replace the default wasmVM engine with the
-// given one.
-func WithWasmEngine(x *wasmvm.VM) Option {
+// given one. Any implementation of types.WasmerEngine, like a mock or a decorator of the *wasmvm.VM,
+// can be used.
+func WithWasmEngine(x types.WasmerEngine) Option {
 	return optsFn(func(k *Keeper) {
 		k.wasmVM = x
 	})
@@ -94,13 +105,15 @@ func WithCoinTransferrer(x CoinTransferrer) Option {
 	})
 }
 
+// WithVMCacheMetrics registers the wasm engine cache metrics with the given prometheus registerer.
 func WithVMCacheMetrics(r prometheus.Registerer) Option {
-	return optsFn(func(k *Keeper) {
+	return postOptsFn(func(k *Keeper) {
 		NewWasmVMMetricsCollector(k.wasmVM).Register(r)
 	})
 }
 
-// WithGasRegister set a new gas register to implement custom gas costs.
+// WithGasRegister set a new gas register to implement custom gas costs. It replaces the
+// gas schedule from the params.
 // When the "gas multiplier" for wasmvm gas conversion is modified inside the new register,
 // make sure to also use `WithApiCosts` option for non default values
 func WithGasRegister(x GasRegister) Option {
@@ -109,10 +122,57 @@ func WithGasRegister(x GasRegister) Option {
 	})
 }
 
-// WithAPICosts sets custom api costs. Amounts are in cosmwasm gas Not SDK gas.
+// WithAPICosts sets custom api costs that replace the costs of the gas schedule from the params.
+// Amounts are in cosmwasm gas Not SDK gas.
 func WithAPICosts(human, canonical uint64) Option {
-	return optsFn(func(_ *Keeper) {
-		costHumanize = human
-		costCanonical = canonical
+	return optsFn(func(k *Keeper) {
+		k.apiCosts = &apiCosts{humanize: human, canonicalize: canonical}
+	})
+}
+
+// WithGenesisStreamDir sets the directory of the genesis stream files that are referenced
+// by the wasm genesis on import.
+func WithGenesisStreamDir(dir string) Option {
+	return optsFn(func(k *Keeper) {
+		k.genesisStreamDir = dir
+	})
+}
+
+// WithGenesisStreamExport streams the codes and contracts to the given file on export instead
+// of embedding them in the genesis. The genesis references the file by its base name.
+func WithGenesisStreamExport(file string) Option {
+	return optsFn(func(k *Keeper) {
+		k.genesisStreamExport = file
+	})
+}
+
+// WithUniqueContractLabels rejects contract labels on instantiation and label updates that are
+// already used by another contract, so that labels resolve to a single contract. Contracts that
+// share a label before it is enabled keep their labels.
+func WithUniqueContractLabels() Option {
+	return optsFn(func(k *Keeper) {
+		k.uniqueContractLabels = true
+	})
+}
+
+// WithGenesisHistoryExport exports the full contract code history and the created positions
+// so that they are restored on import instead of being reset to the genesis.
+func WithGenesisHistoryExport() Option {
+	return optsFn(func(k *Keeper) {
+		k.genesisHistoryExport = true
+	})
+}
+
+// WithAcceptedAccountTypesOnContractInstantiation sets the account types that can exist unused at
+// a contract address before the instantiation. They are replaced by a new contract account, all
+// other account types fail the instantiation with ErrAccountExists. The default is the
+// `*authtypes.BaseAccount` that is created when funds are sent to the address.
+func WithAcceptedAccountTypesOnContractInstantiation(accts ...authtypes.AccountI) Option {
+	m := make(map[reflect.Type]struct{}, len(accts))
+	for _, a := range accts {
+		m[reflect.TypeOf(a)] = struct{}{}
+	}
+	return optsFn(func(k *Keeper) {
+		k.acceptedAccountTypes = m
 	})
 }
@@ -70,9 +70,25 @@ func TestConstructorOptions(t *testing.T) {
 		"api costs": {
 			srcOpt: WithAPICosts(1, 2),
 			verify: func(t *testing.T, k Keeper) {
-				t.Cleanup(setApiDefaults)
-				assert.Equal(t, uint64(1), costHumanize)
-				assert.Equal(t, uint64(2), costCanonical)
+				assert.Equal(t, &apiCosts{humanize: 1, canonicalize: 2}, k.apiCosts)
+			},
+		},
+		"genesis stream dir": {
+			srcOpt: WithGenesisStreamDir("myDir"),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, "myDir", k.genesisStreamDir)
+			},
+		},
+		"genesis stream export": {
+			srcOpt: WithGenesisStreamExport("myFile"),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, "myFile", k.genesisStreamExport)
+			},
+		},
+		"genesis history export": {
+			srcOpt: WithGenesisHistoryExport(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.True(t, k.genesisHistoryExport)
 			},
 		},
 	}
@@ -84,8 +100,3 @@ func TestConstructorOptions(t *testing.T) {
 	}
 
 }
-
-func setApiDefaults() {
-	costHumanize = DefaultGasCostHumanAddress * DefaultGasMultiplier
-	costCanonical = DefaultGasCostCanonicalAddress * DefaultGasMultiplier
-}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"context"
 	"encoding/binary"
+	"encoding/hex"
 	"runtime/debug"
 
 	"cosmossdk.io/errors"
@@ -314,3 +315,228 @@ func (q grpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 	}, nil
 
 }
+
+// UnreferencedCodes lists the stored codes without contract instances
+func (q grpcQuerier) UnreferencedCodes(c context.Context, req *types.QueryUnreferencedCodesRequest) (*types.QueryUnreferencedCodesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.UnreferencedCodeResponse, 0)
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.CodeKeyPrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		codeID := binary.BigEndian.Uint64(key)
+		var referenced bool
+		q.keeper.IterateContractsByCode(ctx, codeID, func(sdk.AccAddress) bool {
+			referenced = true
+			return true
+		})
+		if referenced {
+			return false, nil
+		}
+		if accumulate {
+			var c types.CodeInfo
+			if err := q.cdc.Unmarshal(value, &c); err != nil {
+				return false, err
+			}
+			code, err := q.keeper.GetByteCode(ctx, codeID)
+			if err != nil {
+				return false, errors.Wrapf(err, "loading wasm code: %d", codeID)
+			}
+			r = append(r, types.UnreferencedCodeResponse{
+				CodeInfoResponse: &types.CodeInfoResponse{
+					CodeID:   codeID,
+					Creator:  c.Creator,
+					DataHash: c.CodeHash,
+				},
+				CodeSize: uint64(len(code)),
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryUnreferencedCodesResponse{Codes: r, Pagination: pageRes}, nil
+}
+
+// CodeInfoByChecksum gets the metadata for all wasm codes stored with the given checksum
+func (q grpcQuerier) CodeInfoByChecksum(c context.Context, req *types.QueryCodeInfoByChecksumRequest) (*types.QueryCodeInfoByChecksumResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	checksum, err := hex.DecodeString(req.Checksum)
+	if err != nil {
+		return nil, errors.Wrap(types.ErrInvalid, "checksum: not hex encoded")
+	}
+	if len(checksum) != types.ChecksumLen {
+		return nil, errors.Wrapf(types.ErrInvalid, "checksum: expected %d bytes", types.ChecksumLen)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.CodeInfoResponse, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetCodeByChecksumSecondaryIndexPrefix(checksum))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			codeID := sdk.BigEndianToUint64(key)
+			info := q.keeper.GetCodeInfo(ctx, codeID)
+			if info == nil {
+				return false, errors.Wrapf(types.ErrNotFound, "code id: %d", codeID)
+			}
+			r = append(r, types.CodeInfoResponse{
+				CodeID:   codeID,
+				Creator:  info.Creator,
+				DataHash: info.CodeHash,
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeInfoByChecksumResponse{
+		CodeInfos:  r,
+		Pagination: pageRes,
+	}, nil
+}
+
+// ContractsByCreator lists all smart contracts instantiated by an address
+func (q grpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContractsByCreatorRequest) (*types.QueryContractsByCreatorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	creatorAddr, err := sdk.AccAddressFromBech32(req.CreatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]string, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractsByCreatorPrefix(creatorAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+			r = append(r, contractAddr.String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByCreatorResponse{
+		ContractAddresses: r,
+		Pagination:        pageRes,
+	}, nil
+}
+
+// ContractByLabel lists the smart contracts with the given label
+func (q grpcQuerier) ContractByLabel(c context.Context, req *types.QueryContractByLabelRequest) (*types.QueryContractByLabelResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Label == "" {
+		return nil, errors.Wrap(types.ErrEmpty, "label")
+	}
+	if len(req.Label) > types.MaxLabelSize {
+		return nil, errors.Wrap(types.ErrLimit, "label")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]string, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractsByLabelPrefix(req.Label))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			r = append(r, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractByLabelResponse{
+		ContractAddresses: r,
+		Pagination:        pageRes,
+	}, nil
+}
+
+func (q grpcQuerier) ClassTrace(c context.Context, req *types.QueryClassTraceRequest) (*types.QueryClassTraceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.ClassId == "" {
+		return nil, errors.Wrap(types.ErrEmpty, "class id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	bz := ctx.KVStore(q.storeKey).Get(types.GetClassTraceKey(req.ClassId))
+	if bz == nil {
+		return nil, types.ErrNotFound
+	}
+	var trace types.ClassTrace
+	if err := q.cdc.Unmarshal(bz, &trace); err != nil {
+		return nil, err
+	}
+	return &types.QueryClassTraceResponse{ClassTrace: trace}, nil
+}
+
+func (q grpcQuerier) ClassTraces(c context.Context, req *types.QueryClassTracesRequest) (*types.QueryClassTracesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.ClassTrace, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.ClassTracePrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(_ []byte, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var trace types.ClassTrace
+			if err := q.cdc.Unmarshal(value, &trace); err != nil {
+				return false, err
+			}
+			r = append(r, trace)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryClassTracesResponse{
+		ClassTraces: r,
+		Pagination:  pageRes,
+	}, nil
+}
+
+func (q grpcQuerier) TokenTrace(c context.Context, req *types.QueryTokenTraceRequest) (*types.QueryTokenTraceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.ClassId == "" {
+		return nil, errors.Wrap(types.ErrEmpty, "class id")
+	}
+	if req.TokenId == "" {
+		return nil, errors.Wrap(types.ErrEmpty, "token id")
+	}
+	if err := types.ValidateClassIDLength(req.ClassId); err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	store := ctx.KVStore(q.storeKey)
+	if !store.Has(types.GetTokenTraceKey(req.ClassId, req.TokenId)) {
+		return nil, types.ErrNotFound
+	}
+	bz := store.Get(types.GetClassTraceKey(req.ClassId))
+	if bz == nil {
+		return nil, errors.Wrap(types.ErrNotFound, "class trace")
+	}
+	var trace types.ClassTrace
+	if err := q.cdc.Unmarshal(bz, &trace); err != nil {
+		return nil, err
+	}
+	return &types.QueryTokenTraceResponse{ClassTrace: trace, TokenID: req.TokenId}, nil
+}
+
+func (q grpcQuerier) GasSchedule(c context.Context, req *types.QueryGasScheduleRequest) (*types.QueryGasScheduleResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	return &types.QueryGasScheduleResponse{GasSchedule: q.keeper.GetGasSchedule(sdk.UnwrapSDKContext(c))}, nil
+}
@@ -27,6 +27,8 @@ type QueryHandler struct {
 	Plugins     WasmVMQueryHandler
 	Caller      sdk.AccAddress
 	gasRegister GasRegister
+	// errorDetails is optional and selects the messages of well known errors
+	errorDetails errorDetailsSource
 }
 
 func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister GasRegister) QueryHandler {
@@ -70,7 +72,12 @@ func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) (
 	}
 
 	// Issue #759 - we don't return error string for worries of non-determinism
-	return nil, redactError(err)
+	logRedactedError(q.Ctx, "Redacting query error", err)
+	var errorDetailsVersion uint32
+	if q.errorDetails != nil {
+		errorDetailsVersion = q.errorDetails.errorDetailsVersion(q.Ctx)
+	}
+	return nil, redactError(err, errorDetailsVersion)
 }
 
 func (q QueryHandler) GasConsumed() uint64 {
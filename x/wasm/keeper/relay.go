@@ -7,11 +7,19 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ibccallbackstypes "github.com/cosmos/ibc-go/modules/apps/callbacks/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-var _ types.IBCContractKeeper = (*Keeper)(nil)
+var (
+	_ types.IBCContractKeeper          = (*Keeper)(nil)
+	_ ibccallbackstypes.ContractKeeper = (*Keeper)(nil)
+)
 
 // OnOpenChannel calls the contract to participate in the IBC channel handshake step.
 // In the IBC protocol this is either the `Channel Open Init` event on the initiating chain or
@@ -31,11 +39,12 @@ func (k Keeper) OnOpenChannel(
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -64,16 +73,17 @@ func (k Keeper) OnConnectChannel(
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
 }
 
 // OnCloseChannel calls the contract to let it know the IBC channel is closed.
@@ -95,16 +105,17 @@ func (k Keeper) OnCloseChannel(
 	}
 
 	params := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
 }
 
 // OnRecvPacket calls the contract to process the incoming IBC packet. The contract fully owns the data processing and
@@ -112,32 +123,56 @@ func (k Keeper) OnCloseChannel(
 // of IBC. Although it is recommended to use the standard acknowledgement envelope defined in
 // https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#acknowledgement-envelope
 //
+// The contract state is only committed for success acks. When the contract returns an error, it is converted into
+// an error ack and all state changes of the contract are reverted.
+// When the contract does not return an ack, the packet is stored and a nil ack returned. The contract has to write
+// the acknowledgement later via `IBCMsg::WriteAcknowledgement`.
+//
 // For more information see: https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#packet-flow--handling
 func (k Keeper) OnRecvPacket(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
 	msg wasmvmtypes.IBCPacketReceiveMsg,
-) ([]byte, error) {
+) (ibcexported.Acknowledgement, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-recv-packet")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	// state and events are only committed on success
+	cacheCtx, commit := ctx.CacheContext()
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(cacheCtx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	env := types.NewEnv(cacheCtx, contractAddr)
+	costs := k.runtimeCosts(cacheCtx)
+	querier := k.newQueryHandler(cacheCtx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(cacheCtx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, cacheCtx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(cacheCtx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return nil, errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
-	if res.Err != "" { // handle error case as before https://github.com/CosmWasm/wasmvm/commit/c300106fe5c9426a495f8e10821e00a9330c56c6
-		return nil, errors.Wrap(types.ErrExecuteFailed, res.Err)
+	if res.Err != "" {
+		// the contract rejected the packet: return an error ack with the contract error message
+		// and drop all state changes
+		return channeltypes.Acknowledgement{
+			Response: &channeltypes.Acknowledgement_Error{Error: res.Err},
+		}, nil
 	}
 	// note submessage reply results can overwrite the `Acknowledgement` data
-	return k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
+	data, err := k.handleContractResponse(cacheCtx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
+	if err != nil {
+		// submessage errors result in an error ack by the caller, state is not committed
+		return nil, err
+	}
+	commit()
+	if data == nil {
+		// async ack: the contract writes the acknowledgement later
+		packet := newChannelPacket(msg.Packet)
+		k.storeAsyncAckPacket(ctx, packet)
+		return nil, nil
+	}
+	return types.ContractConfirmStateAck(data), nil
 }
 
 // OnAckPacket calls the contract to handle the "acknowledgement" data which can contain success or failure of a packet
@@ -159,15 +194,16 @@ func (k Keeper) OnAckPacket(
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
 }
 
 // OnTimeoutPacket calls the contract to let it know the packet was never received on the destination chain within
@@ -186,23 +222,237 @@ func (k Keeper) OnTimeoutPacket(
 	}
 
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
+
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
+	if execErr != nil {
+		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
+	}
+
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
+}
+
+// IBCSourceCallback calls the contract to let it know the packet it sent via another IBC app, like ICS-20,
+// was acknowledged or timed out. The sender contract must have requested the callback in the packet data.
+func (k Keeper) IBCSourceCallback(
+	ctx sdk.Context,
+	contractAddr sdk.AccAddress,
+	msg wasmvmtypes.IBCSourceCallbackMsg,
+) error {
+	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-source-callback")
+
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	if err != nil {
+		return err
+	}
+
+	env := types.NewEnv(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
+
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCSourceCallback(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
+	if execErr != nil {
+		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
+	}
+
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
+}
+
+// IBCDestinationCallback calls the contract to let it know a packet addressed to it via another IBC app, like ICS-20,
+// was received and acknowledged successfully.
+func (k Keeper) IBCDestinationCallback(
+	ctx sdk.Context,
+	contractAddr sdk.AccAddress,
+	msg wasmvmtypes.IBCDestinationCallbackMsg,
+) error {
+	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-destination-callback")
+
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	if err != nil {
+		return err
+	}
+
+	env := types.NewEnv(ctx, contractAddr)
+	costs := k.runtimeCosts(ctx)
+	querier := k.newQueryHandler(ctx, contractAddr, costs.gasRegister)
 
-	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), cosmwasmAPI, querier, ctx.GasMeter(), gas, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	gas := k.runtimeGasForContract(ctx, costs.gasRegister)
+	res, gasUsed, execErr := k.wasmVM.IBCDestinationCallback(codeInfo.CodeHash, env, msg, types.NewStoreAdapter(prefixStore), costs.api, querier, ctx.GasMeter(), gas, costs.deserialization)
+	k.consumeRuntimeGas(ctx, costs.gasRegister, gasUsed)
 	if execErr != nil {
 		return errors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, costs.gasRegister, contractAddr, contractInfo.IBCPortID, res)
+}
+
+// IBCSendPacketCallback implements the ContractKeeper interface of the ibc callbacks middleware.
+// Contracts can request source callbacks only for the packets that they send.
+func (k Keeper) IBCSendPacketCallback(
+	cachedCtx sdk.Context,
+	sourcePort string,
+	sourceChannel string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	packetData []byte,
+	contractAddress,
+	packetSenderAddress string,
+) error {
+	_, err := validateCallbackSender(contractAddress, packetSenderAddress)
+	return err
+}
+
+// IBCOnAcknowledgementPacketCallback implements the ContractKeeper interface of the ibc callbacks middleware.
+// The sender contract is notified about the acknowledgement via the source callback entry point.
+func (k Keeper) IBCOnAcknowledgementPacketCallback(
+	cachedCtx sdk.Context,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+	relayer sdk.AccAddress,
+	contractAddress,
+	packetSenderAddress string,
+) error {
+	contractAddr, err := validateCallbackSender(contractAddress, packetSenderAddress)
+	if err != nil {
+		return err
+	}
+	msg := wasmvmtypes.IBCSourceCallbackMsg{
+		Acknowledgement: &wasmvmtypes.IBCAckCallbackMsg{
+			Acknowledgement: wasmvmtypes.IBCAcknowledgement{Data: acknowledgement},
+			OriginalPacket:  newIBCPacket(packet),
+			Relayer:         relayer.String(),
+		},
+	}
+	return k.IBCSourceCallback(cachedCtx, contractAddr, msg)
+}
+
+// IBCOnTimeoutPacketCallback implements the ContractKeeper interface of the ibc callbacks middleware.
+// The sender contract is notified about the timeout via the source callback entry point.
+func (k Keeper) IBCOnTimeoutPacketCallback(
+	cachedCtx sdk.Context,
+	packet channeltypes.Packet,
+	relayer sdk.AccAddress,
+	contractAddress,
+	packetSenderAddress string,
+) error {
+	contractAddr, err := validateCallbackSender(contractAddress, packetSenderAddress)
+	if err != nil {
+		return err
+	}
+	msg := wasmvmtypes.IBCSourceCallbackMsg{
+		Timeout: &wasmvmtypes.IBCTimeoutCallbackMsg{
+			Packet:  newIBCPacket(packet),
+			Relayer: relayer.String(),
+		},
+	}
+	return k.IBCSourceCallback(cachedCtx, contractAddr, msg)
+}
+
+// IBCReceivePacketCallback implements the ContractKeeper interface of the ibc callbacks middleware.
+// The receiver contract is notified via the destination callback entry point for successful acks only,
+// as the state changes of failed packets are reverted.
+func (k Keeper) IBCReceivePacketCallback(
+	cachedCtx sdk.Context,
+	packet ibcexported.PacketI,
+	ack ibcexported.Acknowledgement,
+	contractAddress string,
+) error {
+	if !ack.Success() {
+		return nil
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(contractAddress)
+	if err != nil {
+		return errors.Wrap(err, "contract address")
+	}
+	msg := wasmvmtypes.IBCDestinationCallbackMsg{
+		Ack:    wasmvmtypes.IBCAcknowledgement{Data: ack.Acknowledgement()},
+		Packet: newIBCPacket(packet),
+	}
+	return k.IBCDestinationCallback(cachedCtx, contractAddr, msg)
+}
+
+// validateCallbackSender returns the contract address when the contract is the packet sender
+func validateCallbackSender(contractAddress, packetSenderAddress string) (sdk.AccAddress, error) {
+	contractAddr, err := sdk.AccAddressFromBech32(contractAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "contract address")
+	}
+	if contractAddress != packetSenderAddress {
+		return nil, errors.Wrapf(sdkerrors.ErrUnauthorized, "contract %s is not the packet sender %s", contractAddress, packetSenderAddress)
+	}
+	return contractAddr, nil
 }
 
-func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, addr sdk.AccAddress, id string, res *wasmvmtypes.IBCBasicResult) error {
+func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, gasRegister GasRegister, addr sdk.AccAddress, id string, res *wasmvmtypes.IBCBasicResult) error {
 	// Handle contract result from wasmvm v2
 	if res.Err != "" {
 		return errors.Wrap(types.ErrExecuteFailed, res.Err)
 	}
-	_, err := k.handleContractResponse(ctx, addr, id, res.Ok.Messages, res.Ok.Attributes, nil, res.Ok.Events)
+	_, err := k.handleContractResponse(ctx, gasRegister, addr, id, res.Ok.Messages, res.Ok.Attributes, nil, res.Ok.Events)
 	return err
 }
+
+// storeAsyncAckPacket persists a received packet until the contract writes the acknowledgement
+func (k Keeper) storeAsyncAckPacket(ctx sdk.Context, packet channeltypes.Packet) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetAsyncAckPacketKey(packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+	store.Set(key, k.cdc.MustMarshal(&packet))
+}
+
+// LoadAsyncAckPacket returns the received packet that waits for an async acknowledgement by the contract
+// bound to the given port.
+func (k Keeper) LoadAsyncAckPacket(ctx sdk.Context, portID, channelID string, sequence uint64) (channeltypes.Packet, error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAsyncAckPacketKey(portID, channelID, sequence))
+	if bz == nil {
+		return channeltypes.Packet{}, errors.Wrapf(types.ErrNotFound, "async ack packet: port %s, channel %s, sequence %d", portID, channelID, sequence)
+	}
+	var packet channeltypes.Packet
+	if err := k.cdc.Unmarshal(bz, &packet); err != nil {
+		return channeltypes.Packet{}, err
+	}
+	return packet, nil
+}
+
+// DeleteAsyncAckPacket removes the packet after the acknowledgement was written.
+func (k Keeper) DeleteAsyncAckPacket(ctx sdk.Context, portID, channelID string, sequence uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetAsyncAckPacketKey(portID, channelID, sequence))
+}
+
+func newChannelPacket(packet wasmvmtypes.IBCPacket) channeltypes.Packet {
+	return channeltypes.NewPacket(
+		packet.Data,
+		packet.Sequence,
+		packet.Src.PortID,
+		packet.Src.ChannelID,
+		packet.Dest.PortID,
+		packet.Dest.ChannelID,
+		ConvertWasmIBCTimeoutHeightToCosmosHeight(packet.Timeout.Block),
+		packet.Timeout.Timestamp,
+	)
+}
+
+func newIBCPacket(packet ibcexported.PacketI) wasmvmtypes.IBCPacket {
+	timeout := wasmvmtypes.IBCTimeout{
+		Timestamp: packet.GetTimeoutTimestamp(),
+	}
+	if timeoutHeight := packet.GetTimeoutHeight(); !timeoutHeight.IsZero() {
+		timeout.Block = &wasmvmtypes.IBCTimeoutBlock{
+			Height:   timeoutHeight.GetRevisionHeight(),
+			Revision: timeoutHeight.GetRevisionNumber(),
+		}
+	}
+	return wasmvmtypes.IBCPacket{
+		Data:     packet.GetData(),
+		Src:      wasmvmtypes.IBCEndpoint{ChannelID: packet.GetSourceChannel(), PortID: packet.GetSourcePort()},
+		Dest:     wasmvmtypes.IBCEndpoint{ChannelID: packet.GetDestChannel(), PortID: packet.GetDestPort()},
+		Sequence: packet.GetSequence(),
+		Timeout:  timeout,
+	}
+}
@@ -9,6 +9,8 @@ import (
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -323,11 +325,13 @@ func TestOnRecvPacket(t *testing.T) {
 	specs := map[string]struct {
 		contractAddr       sdk.AccAddress
 		contractResp       *wasmvmtypes.IBCReceiveResponse
+		contractRespErr    string
 		contractErr        error
 		overwriteMessenger *wasmtesting.MockMessageHandler
 		mockReplyFn        func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error)
 		expContractGas     sdk.Gas
-		expAck             []byte
+		expAck             ibcexported.Acknowledgement
+		expAsyncAck        bool
 		expErr             bool
 		expEventTypes      []string
 	}{
@@ -337,12 +341,21 @@ func TestOnRecvPacket(t *testing.T) {
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 			},
-			expAck: []byte("myAck"),
+			expAck: types.ContractConfirmStateAck("myAck"),
 		},
-		"can return empty ack": {
+		"async ack when no ack returned": {
 			contractAddr:   example.Contract,
 			expContractGas: myContractGas,
 			contractResp:   &wasmvmtypes.IBCReceiveResponse{},
+			expAsyncAck:    true,
+		},
+		"contract error returned as error ack, state reverted": {
+			contractAddr:    example.Contract,
+			expContractGas:  myContractGas,
+			contractRespErr: "my error",
+			expAck: channeltypes.Acknowledgement{
+				Response: &channeltypes.Acknowledgement_Error{Error: "my error"},
+			},
 		},
 		"consume gas on error, ignore events + messages": {
 			contractAddr:   example.Contract,
@@ -362,7 +375,7 @@ func TestOnRecvPacket(t *testing.T) {
 				Acknowledgement: []byte("myAck"),
 				Messages:        []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}}}, {ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Custom: json.RawMessage(`{"foo":"bar"}`)}}},
 			},
-			expAck: []byte("myAck"),
+			expAck: types.ContractConfirmStateAck("myAck"),
 		},
 		"emit contract attributes on success": {
 			contractAddr:   example.Contract,
@@ -372,7 +385,7 @@ func TestOnRecvPacket(t *testing.T) {
 				Attributes:      []wasmvmtypes.EventAttribute{{Key: "Foo", Value: "Bar"}},
 			},
 			expEventTypes: []string{types.WasmModuleEventType},
-			expAck:        []byte("myAck"),
+			expAck:        types.ContractConfirmStateAck("myAck"),
 		},
 		"emit contract events on success": {
 			contractAddr:   example.Contract,
@@ -389,9 +402,9 @@ func TestOnRecvPacket(t *testing.T) {
 				}},
 			},
 			expEventTypes: []string{types.WasmModuleEventType, "wasm-custom"},
-			expAck:        []byte("myAck"),
+			expAck:        types.ContractConfirmStateAck("myAck"),
 		},
-		"messenger errors returned, events stored": {
+		"messenger errors returned, events and state reverted": {
 			contractAddr:   example.Contract,
 			expContractGas: myContractGas + 10,
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
@@ -401,7 +414,6 @@ func TestOnRecvPacket(t *testing.T) {
 			},
 			overwriteMessenger: wasmtesting.NewErroringMessageHandler(),
 			expErr:             true,
-			expEventTypes:      []string{types.WasmModuleEventType},
 		},
 		"submessage reply can overwrite ack data": {
 			contractAddr:   example.Contract,
@@ -413,7 +425,7 @@ func TestOnRecvPacket(t *testing.T) {
 			mockReplyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
 				return &wasmvmtypes.Response{Data: []byte("myBetterAck")}, 0, nil
 			},
-			expAck:        []byte("myBetterAck"),
+			expAck:        types.ContractConfirmStateAck("myBetterAck"),
 			expEventTypes: []string{types.EventTypeReply},
 		},
 		"unknown contract address": {
@@ -423,10 +435,18 @@ func TestOnRecvPacket(t *testing.T) {
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			myPacket := wasmvmtypes.IBCPacket{Data: []byte("my data")}
+			myPacket := wasmvmtypes.IBCPacket{
+				Data:     []byte("my data"),
+				Dest:     wasmvmtypes.IBCEndpoint{PortID: "my-port", ChannelID: "channel-1"},
+				Sequence: 1,
+			}
 
 			m.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketReceiveMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCReceiveResult, uint64, error) {
 				assert.Equal(t, myPacket, msg.Packet)
+				store.Set([]byte("foo"), []byte("bar"))
+				if spec.contractRespErr != "" {
+					return &wasmvmtypes.IBCReceiveResult{Err: spec.contractRespErr}, myContractGas * DefaultGasMultiplier, spec.contractErr
+				}
 				return &wasmvmtypes.IBCReceiveResult{Ok: spec.contractResp}, myContractGas * DefaultGasMultiplier, spec.contractErr
 			}
 			if spec.mockReplyFn != nil {
@@ -455,10 +475,20 @@ func TestOnRecvPacket(t *testing.T) {
 				require.Error(t, err)
 				assert.Empty(t, capturedMsgs) // no messages captured on error
 				assert.Equal(t, spec.expEventTypes, stripTypes(ctx.EventManager().Events()))
+				assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, spec.contractAddr, []byte("foo")))
 				return
 			}
 			require.NoError(t, err)
 			require.Equal(t, spec.expAck, gotAck)
+			_, loadErr := keepers.WasmKeeper.LoadAsyncAckPacket(ctx, "my-port", "channel-1", 1)
+			assert.Equal(t, spec.expAsyncAck, loadErr == nil)
+			if gotAck != nil && !gotAck.Success() {
+				// state and events reverted
+				assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, spec.contractAddr, []byte("foo")))
+				assert.Empty(t, ctx.EventManager().Events())
+				return
+			}
+			assert.Equal(t, []byte("bar"), keepers.WasmKeeper.QueryRaw(ctx, spec.contractAddr, []byte("foo")))
 
 			// verify gas consumed
 			const storageCosts = sdk.Gas(2903)
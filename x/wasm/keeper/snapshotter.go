@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+
+	"cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	snapshot "cosmossdk.io/store/snapshots/types"
+	storetypes "cosmossdk.io/store/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ snapshot.ExtensionSnapshotter = &WasmSnapshotter{}
+
+// SnapshotFormat format 1 is just gzipped wasm byte code for each item payload. No protobuf envelope, no metadata.
+const SnapshotFormat = 1
+
+// WasmSnapshotter adds the wasm byte code, that is stored outside of the IAVL tree,
+// to state sync snapshots.
+type WasmSnapshotter struct {
+	wasm *Keeper
+	cms  storetypes.MultiStore
+}
+
+// NewWasmSnapshotter constructor
+func NewWasmSnapshotter(cms storetypes.MultiStore, wasm *Keeper) *WasmSnapshotter {
+	return &WasmSnapshotter{
+		wasm: wasm,
+		cms:  cms,
+	}
+}
+
+// SnapshotName implements ExtensionSnapshotter
+func (ws *WasmSnapshotter) SnapshotName() string {
+	return types.ModuleName
+}
+
+// SnapshotFormat implements ExtensionSnapshotter
+func (ws *WasmSnapshotter) SnapshotFormat() uint32 {
+	return SnapshotFormat
+}
+
+// SupportedFormats implements ExtensionSnapshotter
+func (ws *WasmSnapshotter) SupportedFormats() []uint32 {
+	// If we support older formats, add them here and handle them in Restore
+	return []uint32{SnapshotFormat}
+}
+
+// SnapshotExtension writes the gzipped byte code of every stored code into the snapshot.
+// Codes with the same checksum are written only once.
+func (ws *WasmSnapshotter) SnapshotExtension(height uint64, payloadWriter snapshot.ExtensionPayloadWriter) error {
+	cacheMS, err := ws.cms.CacheMultiStoreWithVersion(int64(height))
+	if err != nil {
+		return err
+	}
+
+	ctx := sdk.NewContext(cacheMS, tmproto.Header{}, false, log.NewNopLogger())
+	seenBefore := make(map[string]bool)
+	var rerr error
+
+	ws.wasm.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+		hexHash := hex.EncodeToString(info.CodeHash)
+		// if many codeIDs share the same checksum, only store the code once
+		if seenBefore[hexHash] {
+			return false
+		}
+		seenBefore[hexHash] = true
+
+		bytecode, err := ws.wasm.GetByteCode(ctx, id)
+		if err != nil {
+			rerr = errors.Wrapf(err, "code id %d", id)
+			return true
+		}
+
+		compressedWasm, err := compress(bytecode)
+		if err != nil {
+			rerr = err
+			return true
+		}
+
+		if err = payloadWriter(compressedWasm); err != nil {
+			rerr = err
+			return true
+		}
+		return false
+	})
+
+	return rerr
+}
+
+// RestoreExtension stores the wasm byte code from the snapshot payloads in the wasm engine
+// and pins the codes that are marked as pinned in the restored state.
+func (ws *WasmSnapshotter) RestoreExtension(height uint64, format uint32, payloadReader snapshot.ExtensionPayloadReader) error {
+	if format == SnapshotFormat {
+		return ws.processAllItems(height, payloadReader, restoreV1, finalizeV1)
+	}
+	return snapshot.ErrUnknownFormat
+}
+
+func restoreV1(ctx sdk.Context, k *Keeper, compressedCode []byte) error {
+	if !bytes.HasPrefix(compressedCode, gzipIdent) {
+		return errors.Wrap(types.ErrInvalid, "not a gzip")
+	}
+	wasmCode, err := uncompress(compressedCode, k.GetMaxWasmCodeSize(ctx))
+	if err != nil {
+		return errors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+
+	// the code was validated when it was uploaded originally. Static checks may have become
+	// stricter since then, so they must not be applied again when restoring the state.
+	if _, err = k.wasmVM.StoreCodeUnchecked(wasmCode); err != nil {
+		return errors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	return nil
+}
+
+func finalizeV1(ctx sdk.Context, k *Keeper) error {
+	return k.InitializePinnedCodes(ctx)
+}
+
+func (ws *WasmSnapshotter) processAllItems(
+	height uint64,
+	payloadReader snapshot.ExtensionPayloadReader,
+	cb func(sdk.Context, *Keeper, []byte) error,
+	finalize func(sdk.Context, *Keeper) error,
+) error {
+	ctx := sdk.NewContext(ws.cms, tmproto.Header{Height: int64(height)}, false, log.NewNopLogger())
+	for {
+		payload, err := payloadReader()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if err := cb(ctx, ws.wasm, payload); err != nil {
+			return errors.Wrap(err, "processing snapshot item")
+		}
+	}
+
+	return finalize(ctx, ws.wasm)
+}
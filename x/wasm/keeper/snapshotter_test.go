@@ -0,0 +1,159 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSnapshotter(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	cms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	codes := map[string][]byte{}
+	srcEngine := &wasmtesting.MockWasmer{GetCodeFn: func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+		return codes[string(checksum)], nil
+	}}
+	k := &Keeper{storeKey: storeKey, cdc: moduletestutil.MakeTestEncodingConfig().Codec, wasmVM: srcEngine}
+
+	// given three codes where two share the same byte code and one is pinned
+	ctx := sdk.NewContext(cms, tmproto.Header{}, false, log.NewNopLogger())
+	require.NoError(t, k.SetParams(ctx, types.DefaultParams()))
+	creator := sdk.AccAddress(bytes.Repeat([]byte{0x1}, types.SDKAddrLen))
+	for i, code := range [][]byte{[]byte("code1"), []byte("code2"), []byte("code1")} {
+		checksum := sha256.Sum256(code)
+		codes[string(checksum[:])] = code
+		k.storeCodeInfo(ctx, uint64(i+1), types.NewCodeInfo(checksum[:], creator, types.AllowEverybody))
+	}
+	ctx.KVStore(storeKey).Set(types.GetPinnedCodeIndexPrefix(2), []byte{1})
+	cid := cms.Commit()
+
+	// when snapshot taken
+	s := NewWasmSnapshotter(cms, k)
+	var payloads [][]byte
+	err := s.SnapshotExtension(uint64(cid.Version), func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// then byte code is stored once per checksum
+	require.Len(t, payloads, 2)
+
+	// and when restored
+	var stored [][]byte
+	var pinned []wasmvm.Checksum
+	k.wasmVM = &wasmtesting.MockWasmer{
+		StoreCodeUncheckedFn: func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+			stored = append(stored, code)
+			checksum := sha256.Sum256(code)
+			return checksum[:], nil
+		},
+		PinFn: func(checksum wasmvm.Checksum) error {
+			pinned = append(pinned, checksum)
+			return nil
+		},
+	}
+	pos := 0
+	err = s.RestoreExtension(uint64(cid.Version), SnapshotFormat, func() ([]byte, error) {
+		if pos == len(payloads) {
+			return nil, io.EOF
+		}
+		pos++
+		return payloads[pos-1], nil
+	})
+	require.NoError(t, err)
+
+	// then all byte code is passed to the engine and the pinned code re-pinned
+	sort.Slice(stored, func(i, j int) bool { return bytes.Compare(stored[i], stored[j]) < 0 })
+	assert.Equal(t, [][]byte{[]byte("code1"), []byte("code2")}, stored)
+	code2Checksum := sha256.Sum256([]byte("code2"))
+	assert.Equal(t, []wasmvm.Checksum{code2Checksum[:]}, pinned)
+}
+
+func TestSnapshotterWithRemovedCode(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	cms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	code := []byte("code1")
+	checksum := sha256.Sum256(code)
+	codes := map[string][]byte{string(checksum[:]): code}
+	k := &Keeper{storeKey: storeKey, cdc: moduletestutil.MakeTestEncodingConfig().Codec, wasmVM: &wasmtesting.MockWasmer{
+		GetCodeFn: func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+			code, ok := codes[string(checksum)]
+			if !ok {
+				return nil, errors.New("not found")
+			}
+			return code, nil
+		},
+		UnpinFn: func(wasmvm.Checksum) error { return nil },
+		RemoveCodeFn: func(checksum wasmvm.Checksum) error {
+			delete(codes, string(checksum))
+			return nil
+		},
+	}}
+
+	// given a code that is removed in the block after the snapshot height
+	ctx := sdk.NewContext(cms, tmproto.Header{Height: 1}, false, log.NewNopLogger())
+	creator := sdk.AccAddress(bytes.Repeat([]byte{0x1}, types.SDKAddrLen))
+	k.storeCodeInfo(ctx, 1, types.NewCodeInfo(checksum[:], creator, types.AllowEverybody))
+	snapshotHeight := cms.Commit().Version
+
+	ctx = sdk.NewContext(cms, tmproto.Header{Height: 2}, false, log.NewNopLogger())
+	require.NoError(t, k.removeCode(ctx, 1))
+	k.ProcessCodeRemovals(ctx)
+	cms.Commit()
+
+	// when snapshot of the earlier height taken
+	s := NewWasmSnapshotter(cms, k)
+	var payloads [][]byte
+	err := s.SnapshotExtension(uint64(snapshotHeight), func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	})
+
+	// then the byte code is still included
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+
+	// and when the removal delay has passed
+	ctx = sdk.NewContext(cms, tmproto.Header{Height: 2 + types.CodeRemovalDelay - 1}, false, log.NewNopLogger())
+	k.ProcessCodeRemovals(ctx)
+	assert.Contains(t, codes, string(checksum[:]))
+	ctx = ctx.WithBlockHeight(2 + types.CodeRemovalDelay)
+	k.ProcessCodeRemovals(ctx)
+
+	// then the byte code is removed
+	assert.NotContains(t, codes, string(checksum[:]))
+	assert.False(t, ctx.KVStore(storeKey).Has(types.GetCodeRemovalQueueKey(checksum[:])))
+}
+
+func TestSnapshotterRestoreUnknownFormat(t *testing.T) {
+	s := NewWasmSnapshotter(nil, nil)
+	err := s.RestoreExtension(1, SnapshotFormat+1, func() ([]byte, error) {
+		return nil, io.EOF
+	})
+	require.Error(t, err)
+}
@@ -11,9 +11,16 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	storetypes "cosmossdk.io/store/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 )
 
 // test handing of submessages, very closely related to the reflect_test
@@ -256,7 +263,7 @@ func TestDispatchSubMsgErrorHandling(t *testing.T) {
 			msg:         invalidBankSend,
 			subMsgError: true,
 			// uses less gas than the send tokens (cost of bank transfer)
-			resultAssertions: []assertion{assertGasUsed(76000, 79000), assertErrorString("codespace: sdk, code: 5")},
+			resultAssertions: []assertion{assertGasUsed(76000, 79000), assertErrorString("codespace: sdk, code: 5, details: insufficient funds")},
 		},
 		"out of gas panic with no gas limit": {
 			submsgID:        7,
@@ -277,7 +284,7 @@ func TestDispatchSubMsgErrorHandling(t *testing.T) {
 			subMsgError: true,
 			gasLimit:    &subGasLimit,
 			// uses same gas as call without limit (note we do not charge the 40k on reply)
-			resultAssertions: []assertion{assertGasUsed(77800, 77900), assertErrorString("codespace: sdk, code: 5")},
+			resultAssertions: []assertion{assertGasUsed(77800, 77900), assertErrorString("codespace: sdk, code: 5, details: insufficient funds")},
 		},
 		"out of gas caught with gas limit": {
 			submsgID:    17,
@@ -285,7 +292,7 @@ func TestDispatchSubMsgErrorHandling(t *testing.T) {
 			subMsgError: true,
 			gasLimit:    &subGasLimit,
 			// uses all the subGasLimit, plus the 52k or so for the main contract
-			resultAssertions: []assertion{assertGasUsed(subGasLimit+73000, subGasLimit+74000), assertErrorString("codespace: sdk, code: 11")},
+			resultAssertions: []assertion{assertGasUsed(subGasLimit+73000, subGasLimit+74000), assertErrorString("codespace: sdk, code: 11, details: out of gas")},
 		},
 		"instantiate contract gets address in data and events": {
 			submsgID:         21,
@@ -374,7 +381,7 @@ func TestDispatchSubMsgEncodeToNoSdkMsg(t *testing.T) {
 		Bank: nilEncoder,
 	}
 
-	ctx, keepers := CreateTestInput(t, false, ReflectFeatures, WithMessageHandler(NewSDKMessageHandler(nil, customEncoders)))
+	ctx, keepers := CreateTestInput(t, false, ReflectFeatures, WithMessageHandler(NewSDKMessageHandler(MakeEncodingConfig(t).Marshaler, nil, customEncoders)))
 	keeper := keepers.WasmKeeper
 
 	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
@@ -569,3 +576,152 @@ func TestDispatchSubMsgConditionalReplyOn(t *testing.T) {
 		})
 	}
 }
+
+func TestDispatchSubMsgMsgResponses(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	newExecMsg := func() sdk.Msg {
+		return &types.MsgExecuteContract{Sender: myContractAddr.String(), Contract: RandomBech32AccountAddress(t), Msg: []byte("{}")}
+	}
+	var dispatched int
+	router := wasmtesting.MessageRouterFunc(func(msg sdk.Msg) baseapp.MsgServiceHandler {
+		return func(ctx sdk.Context, req sdk.Msg) (*sdk.Result, error) {
+			dispatched++
+			ctx.GasMeter().ConsumeGas(1000, "testing")
+			return sdk.WrapServiceResult(ctx, &types.MsgExecuteContractResponse{Data: []byte(strconv.Itoa(dispatched))}, nil)
+		}
+	})
+	specs := map[string]struct {
+		sdkMsgs         []sdk.Msg
+		expData         []byte
+		expMsgResponses []wasmvmtypes.MsgResponse
+	}{
+		"single message": {
+			sdkMsgs: []sdk.Msg{newExecMsg()},
+			expData: mustProtoMarshal(t, &types.MsgExecuteContractResponse{Data: []byte("1")}),
+			expMsgResponses: []wasmvmtypes.MsgResponse{
+				{TypeURL: "/cosmwasm.wasm.v1.MsgExecuteContractResponse", Value: mustProtoMarshal(t, &types.MsgExecuteContractResponse{Data: []byte("1")})},
+			},
+		},
+		"multiple messages": {
+			sdkMsgs: []sdk.Msg{newExecMsg(), newExecMsg()},
+			expData: mustProtoMarshal(t, &types.MsgExecuteContractResponse{Data: []byte("1")}),
+			expMsgResponses: []wasmvmtypes.MsgResponse{
+				{TypeURL: "/cosmwasm.wasm.v1.MsgExecuteContractResponse", Value: mustProtoMarshal(t, &types.MsgExecuteContractResponse{Data: []byte("1")})},
+				{TypeURL: "/cosmwasm.wasm.v1.MsgExecuteContractResponse", Value: mustProtoMarshal(t, &types.MsgExecuteContractResponse{Data: []byte("2")})},
+			},
+		},
+		"no messages": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			dispatched = 0
+			encoder := MessageEncoders{Custom: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+				return spec.sdkMsgs, nil
+			}}
+			var gotReplies []wasmvmtypes.Reply
+			replyer := &mockReplyer{replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+				gotReplies = append(gotReplies, reply)
+				return nil, nil
+			}}
+			ctx := submsgTestContext()
+			d := NewMessageDispatcher(NewSDKMessageHandler(moduletestutil.MakeTestEncodingConfig().Codec, router, encoder), replyer)
+			msgs := []wasmvmtypes.SubMsg{{ID: 1, Msg: wasmvmtypes.CosmosMsg{Custom: []byte("{}")}, ReplyOn: wasmvmtypes.ReplySuccess, Payload: []byte("myPayload")}}
+
+			// when
+			_, gotErr := d.DispatchSubmessages(ctx, myContractAddr, "", msgs)
+
+			// then
+			require.NoError(t, gotErr)
+			require.Len(t, gotReplies, 1)
+			assert.Equal(t, []byte("myPayload"), gotReplies[0].Payload)
+			assert.Equal(t, uint64(len(spec.sdkMsgs)*1000), gotReplies[0].GasUsed)
+			require.NotNil(t, gotReplies[0].Result.Ok)
+			assert.Equal(t, spec.expData, gotReplies[0].Result.Ok.Data)
+			assert.Equal(t, spec.expMsgResponses, []wasmvmtypes.MsgResponse(gotReplies[0].Result.Ok.MsgResponses))
+		})
+	}
+}
+
+func TestDispatchSubMsgNestedReplies(t *testing.T) {
+	outerContract, innerContract := RandomAccountAddress(t), RandomAccountAddress(t)
+	type gotReply struct {
+		contract sdk.AccAddress
+		reply    wasmvmtypes.Reply
+	}
+	var gotReplies []gotReply
+	replyer := &mockReplyer{replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+		gotReplies = append(gotReplies, gotReply{contract: contractAddress, reply: reply})
+		if reply.Result.Err != "" {
+			return nil, nil
+		}
+		return append([]byte("reply:"), reply.Payload...), nil
+	}}
+	innerResponse := &codectypes.Any{TypeUrl: "/my.InnerResponse", Value: []byte("inner")}
+	var d *MessageDispatcher
+	messenger := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+			ctx.GasMeter().ConsumeGas(100, "testing")
+			if contractAddr.Equals(innerContract) {
+				if string(msg.Custom) == `"fail"` {
+					return nil, nil, nil, types.ErrInvalid
+				}
+				return nil, [][]byte{[]byte("innerData")}, [][]*codectypes.Any{{innerResponse}}, nil
+			}
+			// the outer contract message executes the inner contract which dispatches submessages
+			innerMsgs := []wasmvmtypes.SubMsg{
+				{ID: 2, Msg: wasmvmtypes.CosmosMsg{Custom: []byte(`"ok"`)}, ReplyOn: wasmvmtypes.ReplyAlways, Payload: []byte("inner1")},
+				{ID: 3, Msg: wasmvmtypes.CosmosMsg{Custom: []byte(`"fail"`)}, ReplyOn: wasmvmtypes.ReplyError, Payload: []byte("inner2")},
+			}
+			rsp, err := d.DispatchSubmessages(ctx, innerContract, "", innerMsgs)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			outerResponse := &codectypes.Any{TypeUrl: "/my.OuterResponse", Value: rsp}
+			return nil, [][]byte{rsp}, [][]*codectypes.Any{{outerResponse}}, nil
+		},
+	}
+	d = NewMessageDispatcher(messenger, replyer)
+	ctx := submsgTestContext()
+	msgs := []wasmvmtypes.SubMsg{{ID: 1, Msg: wasmvmtypes.CosmosMsg{Custom: []byte(`"ok"`)}, ReplyOn: wasmvmtypes.ReplySuccess, Payload: []byte("outer")}}
+
+	// when
+	gotData, gotErr := d.DispatchSubmessages(ctx, outerContract, "", msgs)
+
+	// then
+	require.NoError(t, gotErr)
+	assert.Equal(t, []byte("reply:outer"), gotData)
+	require.Len(t, gotReplies, 3)
+
+	assert.Equal(t, innerContract, gotReplies[0].contract)
+	assert.Equal(t, uint64(2), gotReplies[0].reply.ID)
+	assert.Equal(t, []byte("inner1"), gotReplies[0].reply.Payload)
+	assert.Equal(t, uint64(100), gotReplies[0].reply.GasUsed)
+	assert.Equal(t, []byte("innerData"), gotReplies[0].reply.Result.Ok.Data)
+	assert.Equal(t, []wasmvmtypes.MsgResponse{{TypeURL: "/my.InnerResponse", Value: []byte("inner")}}, []wasmvmtypes.MsgResponse(gotReplies[0].reply.Result.Ok.MsgResponses))
+
+	assert.Equal(t, innerContract, gotReplies[1].contract)
+	assert.Equal(t, uint64(3), gotReplies[1].reply.ID)
+	assert.Equal(t, []byte("inner2"), gotReplies[1].reply.Payload)
+	assert.NotEmpty(t, gotReplies[1].reply.Result.Err)
+
+	assert.Equal(t, outerContract, gotReplies[2].contract)
+	assert.Equal(t, uint64(1), gotReplies[2].reply.ID)
+	assert.Equal(t, []byte("outer"), gotReplies[2].reply.Payload)
+	// the outer message includes the gas of the nested messages
+	assert.Equal(t, uint64(300), gotReplies[2].reply.GasUsed)
+	assert.Equal(t, []byte("reply:inner1"), gotReplies[2].reply.Result.Ok.Data)
+	assert.Equal(t, []wasmvmtypes.MsgResponse{{TypeURL: "/my.OuterResponse", Value: []byte("reply:inner1")}}, []wasmvmtypes.MsgResponse(gotReplies[2].reply.Result.Ok.MsgResponses))
+}
+
+func submsgTestContext() sdk.Context {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	return ctx.WithGasMeter(storetypes.NewGasMeter(1_000_000))
+}
+
+func mustProtoMarshal(t *testing.T, msg interface{ Marshal() ([]byte, error) }) []byte {
+	t.Helper()
+	bz, err := msg.Marshal()
+	require.NoError(t, err)
+	return bz
+}
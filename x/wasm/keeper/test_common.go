@@ -4,25 +4,31 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
+	"cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
 	"cosmossdk.io/store"
+	"cosmossdk.io/store/metrics"
+	storetypes "cosmossdk.io/store/types"
 	"cosmossdk.io/x/evidence"
 	evidencetypes "cosmossdk.io/x/evidence/types"
 	"cosmossdk.io/x/feegrant"
 	"cosmossdk.io/x/upgrade"
 	upgradekeeper "cosmossdk.io/x/upgrade/keeper"
 	upgradetypes "cosmossdk.io/x/upgrade/types"
-	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/crypto"
 	"github.com/cometbft/cometbft/crypto/ed25519"
-	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/libs/rand"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	"github.com/cosmos/cosmos-sdk/runtime"
 	"github.com/cosmos/cosmos-sdk/std"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -42,6 +48,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -53,10 +61,18 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/ibc-go/modules/capability"
+	capabilitykeeper "github.com/cosmos/ibc-go/modules/capability/keeper"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
 	"github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	ibc "github.com/cosmos/ibc-go/v8/modules/core"
-	ibchost "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channelkeeper "github.com/cosmos/ibc-go/v8/modules/core/04-channel/keeper"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	portkeeper "github.com/cosmos/ibc-go/v8/modules/core/05-port/keeper"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 	ibckeeper "github.com/cosmos/ibc-go/v8/modules/core/keeper"
 	"github.com/stretchr/testify/require"
 
@@ -66,13 +82,10 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-// Router is a compatibility type for the deprecated baseapp.Router
-// This is a minimal interface to maintain backward compatibility in tests
-type Router struct{}
-
 var ModuleBasics = module.NewBasicManager(
 	auth.AppModuleBasic{},
 	bank.AppModuleBasic{},
+	capability.AppModuleBasic{},
 	staking.AppModuleBasic{},
 	mint.AppModuleBasic{},
 	distribution.AppModuleBasic{},
@@ -113,6 +126,7 @@ var TestingStakeParams = stakingtypes.Params{
 	MaxEntries:        10,
 	HistoricalEntries: 10,
 	BondDenom:         "stake",
+	MinCommissionRate: sdkmath.LegacyZeroDec(),
 }
 
 type TestFaucet struct {
@@ -152,7 +166,7 @@ func (f *TestFaucet) Fund(parentCtx sdk.Context, receiver sdk.AccAddress, amount
 	ctx := parentCtx.WithEventManager(sdk.NewEventManager()) // discard all faucet related events
 	err := f.bankKeeper.SendCoins(ctx, f.sender, receiver, amounts)
 	require.NoError(f.t, err)
-	f.balance = f.balance.Sub(amounts)
+	f.balance = f.balance.Sub(amounts...)
 }
 
 func (f *TestFaucet) NewFundedAccount(ctx sdk.Context, amounts ...sdk.Coin) sdk.AccAddress {
@@ -163,14 +177,13 @@ func (f *TestFaucet) NewFundedAccount(ctx sdk.Context, amounts ...sdk.Coin) sdk.
 
 type TestKeepers struct {
 	AccountKeeper  authkeeper.AccountKeeper
-	StakingKeeper  stakingkeeper.Keeper
+	StakingKeeper  *stakingkeeper.Keeper
 	DistKeeper     distributionkeeper.Keeper
 	BankKeeper     bankkeeper.Keeper
-	GovKeeper      govkeeper.Keeper
+	GovKeeper      *govkeeper.Keeper
 	ContractKeeper types.ContractOpsKeeper
 	WasmKeeper     *Keeper
 	IBCKeeper      *ibckeeper.Keeper
-	Router         *Router
 	EncodingConfig wasmappparams.EncodingConfig
 	Faucet         *TestFaucet
 }
@@ -197,26 +210,26 @@ func createTestInput(
 ) (sdk.Context, TestKeepers) {
 	tempDir := t.TempDir()
 
-	keys := sdk.NewKVStoreKeys(
+	keys := storetypes.NewKVStoreKeys(
 		authtypes.StoreKey, banktypes.StoreKey, stakingtypes.StoreKey,
 		minttypes.StoreKey, distributiontypes.StoreKey, slashingtypes.StoreKey,
-		govtypes.StoreKey, paramstypes.StoreKey, ibchost.StoreKey, upgradetypes.StoreKey,
+		govtypes.StoreKey, paramstypes.StoreKey, ibcexported.StoreKey, upgradetypes.StoreKey,
 		evidencetypes.StoreKey, ibctransfertypes.StoreKey,
-		feegrant.StoreKey, authzkeeper.StoreKey,
+		capabilitytypes.StoreKey, feegrant.StoreKey, authzkeeper.StoreKey,
 		types.StoreKey,
 	)
-	ms := store.NewCommitMultiStore(db)
+	ms := store.NewCommitMultiStore(db, log.NewNopLogger(), metrics.NewNoOpMetrics())
 	for _, v := range keys {
-		ms.MountStoreWithDB(v, sdk.StoreTypeIAVL, db)
+		ms.MountStoreWithDB(v, storetypes.StoreTypeIAVL, db)
 	}
-	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
 	for _, v := range tkeys {
-		ms.MountStoreWithDB(v, sdk.StoreTypeTransient, db)
+		ms.MountStoreWithDB(v, storetypes.StoreTypeTransient, db)
 	}
 
-	memKeys := sdk.NewMemoryStoreKeys()
+	memKeys := storetypes.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
 	for _, v := range memKeys {
-		ms.MountStoreWithDB(v, sdk.StoreTypeMemory, db)
+		ms.MountStoreWithDB(v, storetypes.StoreTypeMemory, db)
 	}
 
 	require.NoError(t, ms.LoadLatestVersion())
@@ -229,6 +242,7 @@ func createTestInput(
 
 	encodingConfig := MakeEncodingConfig(t)
 	appCodec, legacyAmino := encodingConfig.Marshaler, encodingConfig.Amino
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
 
 	paramsKeeper := paramskeeper.NewKeeper(
 		appCodec,
@@ -244,7 +258,7 @@ func createTestInput(
 		slashingtypes.ModuleName,
 		crisistypes.ModuleName,
 		ibctransfertypes.ModuleName,
-		ibchost.ModuleName,
+		ibcexported.ModuleName,
 		govtypes.ModuleName,
 		types.ModuleName} {
 		paramsKeeper.Subspace(m)
@@ -264,12 +278,15 @@ func createTestInput(
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
 		types.ModuleName:               {authtypes.Burner},
 	}
+	bech32Prefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
 	accountKeeper := authkeeper.NewAccountKeeper(
 		appCodec,
-		keys[authtypes.StoreKey], // target store
-		subspace(authtypes.ModuleName),
-		authtypes.ProtoBaseAccount, // prototype
+		runtime.NewKVStoreService(keys[authtypes.StoreKey]), // target store
+		authtypes.ProtoBaseAccount,                          // prototype
 		maccPerms,
+		addresscodec.NewBech32Codec(bech32Prefix),
+		bech32Prefix,
+		authority,
 	)
 	blockedAddrs := make(map[string]bool)
 	for acc := range maccPerms {
@@ -278,70 +295,76 @@ func createTestInput(
 
 	bankKeeper := bankkeeper.NewBaseKeeper(
 		appCodec,
-		keys[banktypes.StoreKey],
+		runtime.NewKVStoreService(keys[banktypes.StoreKey]),
 		accountKeeper,
-		subspace(banktypes.ModuleName),
 		blockedAddrs,
+		authority,
+		log.NewNopLogger(),
 	)
-	bankKeeper.SetParams(ctx, banktypes.DefaultParams())
+	require.NoError(t, bankKeeper.SetParams(ctx, banktypes.DefaultParams()))
 
 	stakingKeeper := stakingkeeper.NewKeeper(
 		appCodec,
-		keys[stakingtypes.StoreKey],
+		runtime.NewKVStoreService(keys[stakingtypes.StoreKey]),
 		accountKeeper,
 		bankKeeper,
-		subspace(stakingtypes.ModuleName),
+		authority,
+		addresscodec.NewBech32Codec(sdk.GetConfig().GetBech32ValidatorAddrPrefix()),
+		addresscodec.NewBech32Codec(sdk.GetConfig().GetBech32ConsensusAddrPrefix()),
 	)
-	stakingKeeper.SetParams(ctx, TestingStakeParams)
+	require.NoError(t, stakingKeeper.SetParams(ctx, TestingStakeParams))
 
 	distKeeper := distributionkeeper.NewKeeper(
 		appCodec,
-		keys[distributiontypes.StoreKey],
-		subspace(distributiontypes.ModuleName),
+		runtime.NewKVStoreService(keys[distributiontypes.StoreKey]),
 		accountKeeper,
 		bankKeeper,
 		stakingKeeper,
 		authtypes.FeeCollectorName,
-		nil,
+		authority,
 	)
-	distKeeper.SetParams(ctx, distributiontypes.DefaultParams())
+	require.NoError(t, distKeeper.Params.Set(ctx, distributiontypes.DefaultParams()))
 	stakingKeeper.SetHooks(distKeeper.Hooks())
 
 	// set genesis items required for distribution
-	distKeeper.SetFeePool(ctx, distributiontypes.InitialFeePool())
+	require.NoError(t, distKeeper.FeePool.Set(ctx, distributiontypes.InitialFeePool()))
 
 	upgradeKeeper := upgradekeeper.NewKeeper(
 		map[int64]bool{},
-		keys[upgradetypes.StoreKey],
+		runtime.NewKVStoreService(keys[upgradetypes.StoreKey]),
 		appCodec,
 		tempDir,
 		nil,
+		authority,
 	)
 
-	faucet := NewTestFaucet(t, ctx, bankKeeper, minttypes.ModuleName, sdk.NewCoin("stake", sdk.NewInt(100_000_000_000)))
+	faucet := NewTestFaucet(t, ctx, bankKeeper, minttypes.ModuleName, sdk.NewCoin("stake", sdkmath.NewInt(100_000_000_000)))
 
 	// set some funds ot pay out validatores, based on code from:
 	// https://github.com/cosmos/cosmos-sdk/blob/fea231556aee4d549d7551a6190389c4328194eb/x/distribution/keeper/keeper_test.go#L50-L57
 	distrAcc := distKeeper.GetDistributionAccount(ctx)
-	faucet.Fund(ctx, distrAcc.GetAddress(), sdk.NewCoin("stake", sdk.NewInt(2000000)))
+	faucet.Fund(ctx, distrAcc.GetAddress(), sdk.NewCoin("stake", sdkmath.NewInt(2000000)))
 	accountKeeper.SetModuleAccount(ctx, distrAcc)
 
+	capabilityKeeper := capabilitykeeper.NewKeeper(
+		appCodec,
+		keys[capabilitytypes.StoreKey],
+		memKeys[capabilitytypes.MemStoreKey],
+	)
+	scopedIBCKeeper := capabilityKeeper.ScopeToModule(ibcexported.ModuleName)
+	scopedWasmKeeper := capabilityKeeper.ScopeToModule(types.ModuleName)
+	capabilityKeeper.Seal()
+
 	ibcKeeper := ibckeeper.NewKeeper(
 		appCodec,
-		keys[ibchost.StoreKey],
-		subspace(ibchost.ModuleName),
+		keys[ibcexported.StoreKey],
+		subspace(ibcexported.ModuleName),
 		stakingKeeper,
 		upgradeKeeper,
+		scopedIBCKeeper,
+		authority,
 	)
 
-	router := baseapp.NewRouter()
-	bh := bank.NewHandler(bankKeeper)
-	router.AddRoute(sdk.NewRoute(banktypes.RouterKey, bh))
-	sh := staking.NewHandler(stakingKeeper)
-	router.AddRoute(sdk.NewRoute(stakingtypes.RouterKey, sh))
-	dh := distribution.NewHandler(distKeeper)
-	router.AddRoute(sdk.NewRoute(distributiontypes.RouterKey, dh))
-
 	querier := baseapp.NewGRPCQueryRouter()
 	querier.SetInterfaceRegistry(encodingConfig.InterfaceRegistry)
 	msgRouter := baseapp.NewMsgServiceRouter()
@@ -353,55 +376,53 @@ func createTestInput(
 	keeper := NewKeeper(
 		appCodec,
 		keys[types.StoreKey],
-		subspace(types.ModuleName),
-		accountKeeper,
-		bankKeeper,
-		stakingKeeper,
-		distKeeper,
-		ibcKeeper.ChannelKeeper,
-		&ibcKeeper.PortKeeper,
+		accountKeeperAdapter{accountKeeper},
+		bankKeeperAdapter{bankKeeper},
+		stakingKeeperAdapter{stakingKeeper},
+		distributionkeeper.NewQuerier(distKeeper),
+		channelKeeperAdapter{&ibcKeeper.ChannelKeeper, scopedWasmKeeper},
+		portKeeperAdapter{ibcKeeper.PortKeeper, scopedWasmKeeper},
 		wasmtesting.MockIBCTransferKeeper{},
 		msgRouter,
 		querier,
 		tempDir,
 		wasmConfig,
 		supportedFeatures,
+		authority,
 		opts...,
 	)
-	keeper.SetParams(ctx, types.DefaultParams())
-	// add wasm handler so we can loop-back (contracts calling contracts)
+	require.NoError(t, keeper.SetParams(ctx, types.DefaultParams()))
 	contractKeeper := NewDefaultPermissionKeeper(&keeper)
-	router.AddRoute(sdk.NewRoute(types.RouterKey, TestHandler(contractKeeper)))
 
 	am := module.NewManager( // minimal module set that we use for message/ query tests
-		bank.NewAppModule(appCodec, bankKeeper, accountKeeper),
-		staking.NewAppModule(appCodec, stakingKeeper, accountKeeper, bankKeeper),
-		distribution.NewAppModule(appCodec, distKeeper, accountKeeper, bankKeeper, stakingKeeper),
+		bank.NewAppModule(appCodec, bankKeeper, accountKeeper, subspace(banktypes.ModuleName)),
+		staking.NewAppModule(appCodec, stakingKeeper, accountKeeper, bankKeeper, subspace(stakingtypes.ModuleName)),
+		distribution.NewAppModule(appCodec, distKeeper, accountKeeper, bankKeeper, stakingKeeper, subspace(distributiontypes.ModuleName)),
 	)
-	am.RegisterServices(module.NewConfigurator(appCodec, msgRouter, querier))
-	types.RegisterMsgServer(msgRouter, NewMsgServerImpl(NewDefaultPermissionKeeper(keeper)))
-	types.RegisterQueryServer(querier, NewGrpcQuerier(appCodec, keys[types.ModuleName], keeper, keeper.queryGasLimit))
+	require.NoError(t, am.RegisterServices(module.NewConfigurator(appCodec, msgRouter, querier)))
+	types.RegisterMsgServer(msgRouter, NewMsgServerImpl(&keeper))
+	types.RegisterQueryServer(querier, NewGrpcQuerier(appCodec, keys[types.StoreKey], keeper, keeper.queryGasLimit))
 
-	govRouter := govtypes.NewRouter().
-		AddRoute(govtypes.RouterKey, govtypes.ProposalHandler).
+	govRouter := govv1beta1.NewRouter().
+		AddRoute(govtypes.RouterKey, govv1beta1.ProposalHandler).
 		AddRoute(paramproposal.RouterKey, params.NewParamChangeProposalHandler(paramsKeeper)).
-		AddRoute(distributiontypes.RouterKey, distribution.NewCommunityPoolSpendProposalHandler(distKeeper)).
 		AddRoute(types.RouterKey, NewWasmProposalHandler(&keeper, types.EnableAllProposals))
 
 	govKeeper := govkeeper.NewKeeper(
 		appCodec,
-		keys[govtypes.StoreKey],
-		subspace(govtypes.ModuleName).WithKeyTable(govtypes.ParamKeyTable()),
+		runtime.NewKVStoreService(keys[govtypes.StoreKey]),
 		accountKeeper,
 		bankKeeper,
 		stakingKeeper,
-		govRouter,
+		distKeeper,
+		msgRouter,
+		govtypes.DefaultConfig(),
+		authority,
 	)
+	govKeeper.SetLegacyRouter(govRouter)
 
-	govKeeper.SetProposalID(ctx, govtypes.DefaultStartingProposalID)
-	govKeeper.SetDepositParams(ctx, govtypes.DefaultDepositParams())
-	govKeeper.SetVotingParams(ctx, govtypes.DefaultVotingParams())
-	govKeeper.SetTallyParams(ctx, govtypes.DefaultTallyParams())
+	require.NoError(t, govKeeper.ProposalID.Set(ctx, govv1.DefaultStartingProposalID))
+	require.NoError(t, govKeeper.Params.Set(ctx, govv1.DefaultParams()))
 
 	keepers := TestKeepers{
 		AccountKeeper:  accountKeeper,
@@ -412,13 +433,158 @@ func createTestInput(
 		BankKeeper:     bankKeeper,
 		GovKeeper:      govKeeper,
 		IBCKeeper:      ibcKeeper,
-		Router:         router,
 		EncodingConfig: encodingConfig,
 		Faucet:         faucet,
 	}
 	return ctx, keepers
 }
 
+// accountKeeperAdapter adapts the sdk account keeper to the sdk.Context based types.AccountKeeper
+type accountKeeperAdapter struct {
+	authkeeper.AccountKeeper
+}
+
+func (a accountKeeperAdapter) GetAccount(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI {
+	return a.AccountKeeper.GetAccount(ctx, addr)
+}
+
+func (a accountKeeperAdapter) NewAccountWithAddress(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI {
+	return a.AccountKeeper.NewAccountWithAddress(ctx, addr)
+}
+
+func (a accountKeeperAdapter) SetAccount(ctx sdk.Context, acc authtypes.AccountI) {
+	a.AccountKeeper.SetAccount(ctx, acc)
+}
+
+// bankKeeperAdapter adapts the sdk bank keeper to the sdk.Context based types.BankKeeper
+type bankKeeperAdapter struct {
+	bankkeeper.Keeper
+}
+
+func (b bankKeeperAdapter) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return b.Keeper.GetAllBalances(ctx, addr)
+}
+
+func (b bankKeeperAdapter) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return b.Keeper.GetBalance(ctx, addr, denom)
+}
+
+func (b bankKeeperAdapter) BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error {
+	return b.Keeper.BurnCoins(ctx, moduleName, amt)
+}
+
+func (b bankKeeperAdapter) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	return b.Keeper.SendCoinsFromAccountToModule(ctx, senderAddr, recipientModule, amt)
+}
+
+func (b bankKeeperAdapter) IsSendEnabledCoins(ctx sdk.Context, coins ...sdk.Coin) error {
+	return b.Keeper.IsSendEnabledCoins(ctx, coins...)
+}
+
+func (b bankKeeperAdapter) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	return b.Keeper.SendCoins(ctx, fromAddr, toAddr, amt)
+}
+
+// stakingKeeperAdapter adapts the sdk staking keeper to the types.StakingKeeper that reports not found entries with a bool
+type stakingKeeperAdapter struct {
+	*stakingkeeper.Keeper
+}
+
+func (s stakingKeeperAdapter) BondDenom(ctx sdk.Context) string {
+	denom, err := s.Keeper.BondDenom(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return denom
+}
+
+func (s stakingKeeperAdapter) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (stakingtypes.Validator, bool) {
+	validator, err := s.Keeper.GetValidator(ctx, addr)
+	return validator, err == nil
+}
+
+func (s stakingKeeperAdapter) GetBondedValidatorsByPower(ctx sdk.Context) []stakingtypes.Validator {
+	validators, err := s.Keeper.GetBondedValidatorsByPower(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return validators
+}
+
+func (s stakingKeeperAdapter) GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress) []stakingtypes.Delegation {
+	delegations, err := s.Keeper.GetAllDelegatorDelegations(ctx, delegator)
+	if err != nil {
+		panic(err)
+	}
+	return delegations
+}
+
+func (s stakingKeeperAdapter) GetDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (stakingtypes.Delegation, bool) {
+	delegation, err := s.Keeper.GetDelegation(ctx, delAddr, valAddr)
+	return delegation, err == nil
+}
+
+func (s stakingKeeperAdapter) HasReceivingRedelegation(ctx sdk.Context, delAddr sdk.AccAddress, valDstAddr sdk.ValAddress) bool {
+	has, err := s.Keeper.HasReceivingRedelegation(ctx, delAddr, valDstAddr)
+	if err != nil {
+		panic(err)
+	}
+	return has
+}
+
+// channelKeeperAdapter adapts the ibc channel keeper to the types.ChannelKeeper by looking up the
+// channel capabilities in the wasm scoped keeper
+type channelKeeperAdapter struct {
+	*channelkeeper.Keeper
+	scopedKeeper capabilitykeeper.ScopedKeeper
+}
+
+func (c channelKeeperAdapter) ChanCloseInit(ctx sdk.Context, portID, channelID string) error {
+	chanCap, err := c.channelCapability(ctx, portID, channelID)
+	if err != nil {
+		return err
+	}
+	return c.Keeper.ChanCloseInit(ctx, portID, channelID, chanCap)
+}
+
+func (c channelKeeperAdapter) SendPacket(ctx sdk.Context, packet ibcexported.PacketI) error {
+	chanCap, err := c.channelCapability(ctx, packet.GetSourcePort(), packet.GetSourceChannel())
+	if err != nil {
+		return err
+	}
+	_, err = c.Keeper.SendPacket(ctx, chanCap, packet.GetSourcePort(), packet.GetSourceChannel(),
+		packet.GetTimeoutHeight().(clienttypes.Height), packet.GetTimeoutTimestamp(), packet.GetData())
+	return err
+}
+
+func (c channelKeeperAdapter) WriteAcknowledgement(ctx sdk.Context, packet ibcexported.PacketI, acknowledgement ibcexported.Acknowledgement) error {
+	chanCap, err := c.channelCapability(ctx, packet.GetDestPort(), packet.GetDestChannel())
+	if err != nil {
+		return err
+	}
+	return c.Keeper.WriteAcknowledgement(ctx, chanCap, packet, acknowledgement)
+}
+
+func (c channelKeeperAdapter) channelCapability(ctx sdk.Context, portID, channelID string) (*capabilitytypes.Capability, error) {
+	chanCap, ok := c.scopedKeeper.GetCapability(ctx, host.ChannelCapabilityPath(portID, channelID))
+	if !ok {
+		return nil, errors.Wrapf(channeltypes.ErrChannelCapabilityNotFound, "port ID (%s) channel ID (%s)", portID, channelID)
+	}
+	return chanCap, nil
+}
+
+// portKeeperAdapter adapts the ibc port keeper to the types.PortKeeper by claiming the port capabilities
+// with the wasm scoped keeper
+type portKeeperAdapter struct {
+	*portkeeper.Keeper
+	scopedKeeper capabilitykeeper.ScopedKeeper
+}
+
+func (p portKeeperAdapter) BindPort(ctx sdk.Context, portID string) error {
+	portCap := p.Keeper.BindPort(ctx, portID)
+	return p.scopedKeeper.ClaimCapability(ctx, portCap, host.PortPath(portID))
+}
+
 // TestHandler returns a wasm handler for tests (to avoid circular imports)
 func TestHandler(k types.ContractOpsKeeper) Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
@@ -432,7 +598,7 @@ func TestHandler(k types.ContractOpsKeeper) Handler {
 			return handleExecute(ctx, k, msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized wasm message type: %T", msg)
-			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+			return nil, errors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
 		}
 	}
 }
@@ -440,7 +606,7 @@ func TestHandler(k types.ContractOpsKeeper) Handler {
 func handleStoreCode(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.MsgStoreCode) (*sdk.Result, error) {
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "sender")
+		return nil, errors.Wrap(err, "sender")
 	}
 	codeID, err := k.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
 	if err != nil {
@@ -456,12 +622,12 @@ func handleStoreCode(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.MsgS
 func handleInstantiate(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.MsgInstantiateContract) (*sdk.Result, error) {
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "sender")
+		return nil, errors.Wrap(err, "sender")
 	}
 	var adminAddr sdk.AccAddress
 	if msg.Admin != "" {
 		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
-			return nil, sdkerrors.Wrap(err, "admin")
+			return nil, errors.Wrap(err, "admin")
 		}
 	}
 
@@ -479,11 +645,11 @@ func handleInstantiate(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.Ms
 func handleExecute(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.MsgExecuteContract) (*sdk.Result, error) {
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "sender")
+		return nil, errors.Wrap(err, "sender")
 	}
 	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "admin")
+		return nil, errors.Wrap(err, "admin")
 	}
 	data, err := k.Execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
 	if err != nil {
@@ -525,7 +691,7 @@ func StoreIBCReflectContract(t testing.TB, ctx sdk.Context, keepers TestKeepers)
 }
 
 func StoreReflectContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) uint64 {
-	wasmCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	wasmCode, err := os.ReadFile("./testdata/reflect.wasm")
 	require.NoError(t, err)
 
 	_, _, creatorAddr := keyPubAddr()
@@ -539,7 +705,7 @@ func StoreExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers, wa
 	creator, _, creatorAddr := keyPubAddr()
 	fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, creatorAddr, anyAmount)
 
-	wasmCode, err := ioutil.ReadFile(wasmFile)
+	wasmCode, err := os.ReadFile(wasmFile)
 	require.NoError(t, err)
 
 	codeID, err := keepers.ContractKeeper.Create(ctx, creatorAddr, wasmCode, nil)
@@ -57,14 +57,9 @@ func FuzzStateModel(m *types.Model, c fuzz.Continue) {
 }
 
 func FuzzAccessType(m *types.AccessType, c fuzz.Continue) {
-	pos := c.Int() % len(types.AllAccessTypes)
-	for _, v := range types.AllAccessTypes {
-		if pos == 0 {
-			*m = v
-			return
-		}
-		pos--
-	}
+	// the deprecated only address type is not valid input anymore
+	accessTypes := []types.AccessType{types.AccessTypeNobody, types.AccessTypeEverybody, types.AccessTypeAnyOfAddresses}
+	*m = accessTypes[c.Intn(len(accessTypes))]
 }
 func FuzzAccessConfig(m *types.AccessConfig, c fuzz.Continue) {
 	FuzzAccessType(&m.Permission, c)
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	_ "embed"
+)
+
+var (
+	//go:embed reflect.wasm
+	reflectContract []byte
+	//go:embed hackatom.wasm
+	hackatomContract []byte
+)
+
+// ReflectContractWasm returns the reflect contract byte code. The contract
+// dispatches the cosmos messages it receives on behalf of its owner.
+func ReflectContractWasm() []byte {
+	return reflectContract
+}
+
+// HackatomContractWasm returns the hackatom contract byte code. The contract
+// releases its funds to a beneficiary when the verifier executes it.
+func HackatomContractWasm() []byte {
+	return hackatomContract
+}
@@ -0,0 +1,245 @@
+package keeper
+
+import (
+	"fmt"
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// contractTracer records the contract calls of the txs that are executed in a block. The traces
+// are node local debug information that is kept in memory for the last txs only. They are never
+// part of the state, so that it is safe to enable the tracing on a single node.
+type contractTracer struct {
+	mu       sync.Mutex
+	capacity int
+	// traces by tx hash
+	traces map[string]*types.TxTrace
+	// order of the tx hashes for eviction, oldest first
+	order []string
+	// stack of the calls in progress, the last one is the current call
+	stack []*types.ContractCall
+}
+
+// newContractTracer returns a tracer that keeps the traces of the last txs up to the capacity.
+// Tracing is disabled with a nil tracer when the capacity is 0.
+func newContractTracer(capacity uint32) *contractTracer {
+	if capacity == 0 {
+		return nil
+	}
+	return &contractTracer{capacity: int(capacity), traces: make(map[string]*types.TxTrace)}
+}
+
+// begin starts a new contract call as a child of the current call. It returns nil when the call
+// is not traced, for example in queries, simulations or block hooks without a tx.
+func (t *contractTracer) begin(ctx sdk.Context, callType types.ContractCallType, contractAddr sdk.AccAddress) *traceSpan {
+	if t == nil || ctx.ExecMode() != sdk.ExecModeFinalize || len(ctx.TxBytes()) == 0 {
+		return nil
+	}
+	call := &types.ContractCall{Type: callType}
+	if contractAddr != nil {
+		call.ContractAddress = contractAddr.String()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n := len(t.stack); n != 0 {
+		parent := t.stack[n-1]
+		parent.Calls = append(parent.Calls, call)
+	} else {
+		trace := t.txTrace(txHash(ctx), ctx.BlockHeight())
+		trace.Calls = append(trace.Calls, call)
+	}
+	t.stack = append(t.stack, call)
+	return &traceSpan{tracer: t, call: call, gasMeter: ctx.GasMeter(), startGas: ctx.GasMeter().GasConsumed()}
+}
+
+// txTrace returns the trace for the tx or adds a new one. The oldest trace is dropped when the
+// capacity is exceeded.
+func (t *contractTracer) txTrace(hash string, height int64) *types.TxTrace {
+	if trace, ok := t.traces[hash]; ok && trace.Height == height {
+		return trace
+	}
+	if _, ok := t.traces[hash]; !ok {
+		t.order = append(t.order, hash)
+	}
+	trace := &types.TxTrace{TxHash: hash, Height: height}
+	t.traces[hash] = trace
+	for len(t.order) > t.capacity {
+		delete(t.traces, t.order[0])
+		t.order = t.order[1:]
+	}
+	return trace
+}
+
+// recordEvents adds the events to the current call
+func (t *contractTracer) recordEvents(ctx sdk.Context, events sdk.Events) {
+	if t == nil || ctx.ExecMode() != sdk.ExecModeFinalize {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(t.stack)
+	if n == 0 {
+		return
+	}
+	call := t.stack[n-1]
+	for _, e := range events {
+		event := types.ContractCallEvent{Type: e.Type}
+		for _, a := range e.Attributes {
+			event.Attributes = append(event.Attributes, types.ContractCallEventAttribute{Key: a.Key, Value: a.Value})
+		}
+		call.Events = append(call.Events, event)
+	}
+}
+
+// TxTrace returns a copy of the trace for the hex encoded tx hash
+func (t *contractTracer) TxTrace(hash string) (*types.TxTrace, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	trace, ok := t.traces[hash]
+	if !ok {
+		return nil, false
+	}
+	bz, err := trace.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	var result types.TxTrace
+	if err := result.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return &result, true
+}
+
+// traceSpan is a contract call in progress. All methods are no-ops on a nil span.
+type traceSpan struct {
+	tracer   *contractTracer
+	call     *types.ContractCall
+	gasMeter storetypes.GasMeter
+	startGas storetypes.Gas
+}
+
+// setContract sets the contract address when it is not known when the call begins
+func (s *traceSpan) setContract(contractAddr sdk.AccAddress) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.call.ContractAddress = contractAddr.String()
+}
+
+// setCodeID sets the code that is executed
+func (s *traceSpan) setCodeID(codeID uint64) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.call.CodeID = codeID
+}
+
+// store wraps the contract store to count the reads and writes
+func (s *traceSpan) store(store storetypes.KVStore) storetypes.KVStore {
+	if s == nil {
+		return store
+	}
+	return &tracedStore{KVStore: store, span: s}
+}
+
+// end completes the call with the gas used and the error. It removes the call and any nested
+// call that was not completed due to a panic from the stack.
+func (s *traceSpan) end(err error) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.call.GasUsed = s.gasMeter.GasConsumed() - s.startGas
+	switch {
+	case err != nil:
+		s.call.Error = err.Error()
+	case s.gasMeter.IsOutOfGas():
+		s.call.Error = "out of gas"
+	}
+	for i := len(s.tracer.stack) - 1; i >= 0; i-- {
+		if s.tracer.stack[i] == s.call {
+			s.tracer.stack = s.tracer.stack[:i]
+			break
+		}
+	}
+}
+
+func (s *traceSpan) countRead() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.call.StoreReads++
+}
+
+func (s *traceSpan) countWrite() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.call.StoreWrites++
+}
+
+// tracedStore counts the reads and writes of a contract to its store
+type tracedStore struct {
+	storetypes.KVStore
+	span *traceSpan
+}
+
+func (s *tracedStore) Get(key []byte) []byte {
+	s.span.countRead()
+	return s.KVStore.Get(key)
+}
+
+func (s *tracedStore) Has(key []byte) bool {
+	s.span.countRead()
+	return s.KVStore.Has(key)
+}
+
+func (s *tracedStore) Set(key, value []byte) {
+	s.span.countWrite()
+	s.KVStore.Set(key, value)
+}
+
+func (s *tracedStore) Delete(key []byte) {
+	s.span.countWrite()
+	s.KVStore.Delete(key)
+}
+
+func (s *tracedStore) Iterator(start, end []byte) storetypes.Iterator {
+	return &tracedIterator{Iterator: s.KVStore.Iterator(start, end), span: s.span}
+}
+
+func (s *tracedStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return &tracedIterator{Iterator: s.KVStore.ReverseIterator(start, end), span: s.span}
+}
+
+// tracedIterator counts each visited element as a read
+type tracedIterator struct {
+	storetypes.Iterator
+	span *traceSpan
+}
+
+func (i *tracedIterator) Value() []byte {
+	i.span.countRead()
+	return i.Iterator.Value()
+}
+
+// txHash returns the hex encoded hash of the tx that is executed or an empty string
+func txHash(ctx sdk.Context) string {
+	txBytes := ctx.TxBytes()
+	if len(txBytes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%X", tmhash.Sum(txBytes))
+}
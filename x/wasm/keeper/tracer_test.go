@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractTracerRecordsCallTree(t *testing.T) {
+	ctx, keepers := CreateDefaultTestInput(t)
+	k := keepers.WasmKeeper
+	ctx = ctx.WithExecMode(sdk.ExecModeFinalize).WithTxBytes([]byte("myTx")).WithBlockHeight(7).
+		WithGasMeter(storetypes.NewInfiniteGasMeter())
+	store := prefix.NewStore(ctx.MultiStore().GetKVStore(k.storeKey), []byte{0x01}) // without store gas
+	contractA := sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen))
+	contractB := sdk.AccAddress(bytes.Repeat([]byte{2}, types.ContractAddrLen))
+	tracer := newContractTracer(10)
+
+	// when
+	execSpan := tracer.begin(ctx, types.ContractCallTypeExecute, contractA)
+	execSpan.setCodeID(1)
+	ctx.GasMeter().ConsumeGas(100, "testing")
+	execStore := execSpan.store(store)
+	execStore.Set([]byte("a"), []byte("1"))
+	execStore.Set([]byte("b"), []byte("2"))
+	querySpan := tracer.begin(ctx, types.ContractCallTypeQuery, contractB)
+	querySpan.setCodeID(2)
+	ctx.GasMeter().ConsumeGas(10, "testing")
+	querySpan.store(store).Get([]byte("a"))
+	querySpan.end(nil)
+	iter := execStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		iter.Value()
+	}
+	require.NoError(t, iter.Close())
+	tracer.recordEvents(ctx, sdk.Events{sdk.NewEvent("wasm", sdk.NewAttribute("action", "settle"))})
+	replySpan := tracer.begin(ctx, types.ContractCallTypeReply, contractA)
+	replySpan.end(errors.New("testing"))
+	execSpan.end(nil)
+
+	// then
+	trace, ok := tracer.TxTrace(fmt.Sprintf("%X", tmhash.Sum([]byte("myTx"))))
+	require.True(t, ok)
+	exp := &types.TxTrace{
+		TxHash: fmt.Sprintf("%X", tmhash.Sum([]byte("myTx"))),
+		Height: 7,
+		Calls: []*types.ContractCall{{
+			Type:            types.ContractCallTypeExecute,
+			ContractAddress: contractA.String(),
+			CodeID:          1,
+			GasUsed:         110,
+			StoreReads:      2,
+			StoreWrites:     2,
+			Events: []types.ContractCallEvent{{
+				Type:       "wasm",
+				Attributes: []types.ContractCallEventAttribute{{Key: "action", Value: "settle"}},
+			}},
+			Calls: []*types.ContractCall{
+				{Type: types.ContractCallTypeQuery, ContractAddress: contractB.String(), CodeID: 2, GasUsed: 10, StoreReads: 1},
+				{Type: types.ContractCallTypeReply, ContractAddress: contractA.String(), Error: "testing"},
+			},
+		}},
+	}
+	assert.Equal(t, exp, trace)
+	assert.Empty(t, tracer.stack)
+}
+
+func TestContractTracerSkipsUntracedCalls(t *testing.T) {
+	ctx, _ := CreateDefaultTestInput(t)
+	specs := map[string]struct {
+		tracer *contractTracer
+		ctx    sdk.Context
+	}{
+		"disabled": {
+			ctx: ctx.WithExecMode(sdk.ExecModeFinalize).WithTxBytes([]byte("myTx")),
+		},
+		"not finalize": {
+			tracer: newContractTracer(1),
+			ctx:    ctx.WithExecMode(sdk.ExecModeSimulate).WithTxBytes([]byte("myTx")),
+		},
+		"without tx": {
+			tracer: newContractTracer(1),
+			ctx:    ctx.WithExecMode(sdk.ExecModeFinalize),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			span := spec.tracer.begin(spec.ctx, types.ContractCallTypeExecute, sdk.AccAddress(bytes.Repeat([]byte{1}, types.ContractAddrLen)))
+			require.Nil(t, span)
+			span.setCodeID(1)
+			span.end(nil)
+			_, ok := spec.tracer.TxTrace(fmt.Sprintf("%X", tmhash.Sum([]byte("myTx"))))
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestContractTracerEvictsOldestTrace(t *testing.T) {
+	ctx, _ := CreateDefaultTestInput(t)
+	ctx = ctx.WithExecMode(sdk.ExecModeFinalize)
+	tracer := newContractTracer(2)
+	for _, tx := range []string{"tx1", "tx2", "tx3"} {
+		tracer.begin(ctx.WithTxBytes([]byte(tx)), types.ContractCallTypeExecute, nil).end(nil)
+	}
+	for tx, exp := range map[string]bool{"tx1": false, "tx2": true, "tx3": true} {
+		_, ok := tracer.TxTrace(fmt.Sprintf("%X", tmhash.Sum([]byte(tx))))
+		assert.Equal(t, exp, ok, tx)
+	}
+}
+
+func TestDebugQuerierTraceTx(t *testing.T) {
+	ctx, _ := CreateDefaultTestInput(t)
+	ctx = ctx.WithExecMode(sdk.ExecModeFinalize).WithTxBytes([]byte("myTx"))
+	tracer := newContractTracer(1)
+	tracer.begin(ctx, types.ContractCallTypeExecute, nil).end(nil)
+	myTxHash := fmt.Sprintf("%x", tmhash.Sum([]byte("myTx")))
+	specs := map[string]struct {
+		tracer  *contractTracer
+		req     *types.QueryTraceTxRequest
+		expCode codes.Code
+	}{
+		"found with lower case hash": {
+			tracer: tracer,
+			req:    &types.QueryTraceTxRequest{TxHash: myTxHash},
+		},
+		"not found": {
+			tracer:  tracer,
+			req:     &types.QueryTraceTxRequest{TxHash: fmt.Sprintf("%X", tmhash.Sum([]byte("other")))},
+			expCode: codes.NotFound,
+		},
+		"tracing disabled": {
+			req:     &types.QueryTraceTxRequest{TxHash: myTxHash},
+			expCode: codes.Unavailable,
+		},
+		"empty request": {
+			tracer:  tracer,
+			expCode: codes.InvalidArgument,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := DebugQuerier(&Keeper{tracer: spec.tracer})
+			got, gotErr := q.TraceTx(ctx, spec.req)
+			if spec.expCode != codes.OK {
+				assert.Equal(t, spec.expCode, status.Code(gotErr))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Len(t, got.Trace.Calls, 1)
+		})
+	}
+}
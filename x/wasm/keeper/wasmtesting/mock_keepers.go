@@ -9,12 +9,13 @@ import (
 )
 
 type MockChannelKeeper struct {
-	GetChannelFn          func(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
-	GetNextSequenceSendFn func(ctx sdk.Context, portID, channelID string) (uint64, bool)
-	SendPacketFn          func(ctx sdk.Context, packet ibcexported.PacketI) error
-	ChanCloseInitFn       func(ctx sdk.Context, portID, channelID string) error
-	GetAllChannelsFn      func(ctx sdk.Context) []channeltypes.IdentifiedChannel
-	IterateChannelsFn     func(ctx sdk.Context, cb func(channeltypes.IdentifiedChannel) bool)
+	GetChannelFn           func(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool)
+	GetNextSequenceSendFn  func(ctx sdk.Context, portID, channelID string) (uint64, bool)
+	SendPacketFn           func(ctx sdk.Context, packet ibcexported.PacketI) error
+	WriteAcknowledgementFn func(ctx sdk.Context, packet ibcexported.PacketI, acknowledgement ibcexported.Acknowledgement) error
+	ChanCloseInitFn        func(ctx sdk.Context, portID, channelID string) error
+	GetAllChannelsFn       func(ctx sdk.Context) []channeltypes.IdentifiedChannel
+	IterateChannelsFn      func(ctx sdk.Context, cb func(channeltypes.IdentifiedChannel) bool)
 }
 
 func (m *MockChannelKeeper) GetChannel(ctx sdk.Context, srcPort, srcChan string) (channel channeltypes.Channel, found bool) {
@@ -45,6 +46,13 @@ func (m *MockChannelKeeper) SendPacket(ctx sdk.Context, packet ibcexported.Packe
 	return m.SendPacketFn(ctx, packet)
 }
 
+func (m *MockChannelKeeper) WriteAcknowledgement(ctx sdk.Context, packet ibcexported.PacketI, acknowledgement ibcexported.Acknowledgement) error {
+	if m.WriteAcknowledgementFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.WriteAcknowledgementFn(ctx, packet, acknowledgement)
+}
+
 func (m *MockChannelKeeper) ChanCloseInit(ctx sdk.Context, portID, channelID string) error {
 	if m.ChanCloseInitFn == nil {
 		panic("not supposed to be called!")
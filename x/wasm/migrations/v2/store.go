@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"reflect"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/exported"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MigrateStore migrates the x/wasm module state from the consensus version 1 to
+// version 2. Specifically, it takes the parameters that are currently stored
+// and managed by the x/params module and stores them directly into the x/wasm
+// module state.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey, legacySubspace exported.Subspace, cdc codec.BinaryCodec) error {
+	store := ctx.KVStore(storeKey)
+	var currParams types.Params
+	legacySubspace.GetParamSet(ctx, &currParams)
+
+	// validate with the rules of the legacy subspace. They still accept the deprecated
+	// AccessTypeOnlyAddress that is replaced by the v4 migration.
+	for _, pair := range currParams.ParamSetPairs() {
+		if err := pair.ValidatorFn(reflect.ValueOf(pair.Value).Elem().Interface()); err != nil {
+			return err
+		}
+	}
+
+	bz, err := cdc.Marshal(&currParams)
+	if err != nil {
+		return err
+	}
+
+	store.Set(types.ParamsKey, bz)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package v2_test
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/stretchr/testify/require"
+
+	v2 "github.com/CosmWasm/wasmd/x/wasm/migrations/v2"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	paramsKey := storetypes.NewKVStoreKey(paramtypes.StoreKey)
+	tParamsKey := storetypes.NewTransientStoreKey(paramtypes.TStoreKey)
+	ctx := testutil.DefaultContextWithKeys(
+		map[string]*storetypes.KVStoreKey{types.StoreKey: storeKey, paramtypes.StoreKey: paramsKey},
+		map[string]*storetypes.TransientStoreKey{paramtypes.TStoreKey: tParamsKey},
+		nil,
+	)
+	legacyAmino := moduletestutil.MakeTestEncodingConfig().Amino
+	legacySubspace := paramtypes.NewSubspace(cdc, legacyAmino, paramsKey, tParamsKey, types.ModuleName).
+		WithKeyTable(types.ParamKeyTable())
+
+	myAddr := sdk.AccAddress(make([]byte, types.SDKAddrLen))
+	params := types.Params{
+		CodeUploadAccess:             types.AccessTypeOnlyAddress.With(myAddr),
+		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+	}
+	legacySubspace.SetParamSet(ctx, &params)
+
+	// when
+	require.NoError(t, v2.MigrateStore(ctx, storeKey, legacySubspace, cdc))
+
+	// then
+	var res types.Params
+	bz := ctx.KVStore(storeKey).Get(types.ParamsKey)
+	require.NotNil(t, bz)
+	require.NoError(t, cdc.Unmarshal(bz, &res))
+	require.Equal(t, params, res)
+}
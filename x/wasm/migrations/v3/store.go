@@ -0,0 +1,56 @@
+package v3
+
+import (
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MigrateStore migrates the x/wasm module state from the consensus version 2 to
+// version 3. Specifically, it back-fills the contracts-by-creator and the
+// code-by-checksum secondary indexes for all contracts and codes that were
+// stored before the indexes existed.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey, cdc codec.BinaryCodec) error {
+	store := ctx.KVStore(storeKey)
+
+	var indexKeys [][]byte
+	contractStore := prefix.NewStore(store, types.ContractKeyPrefix)
+	contractIter := contractStore.Iterator(nil, nil)
+	for ; contractIter.Valid(); contractIter.Next() {
+		var contract types.ContractInfo
+		if err := cdc.Unmarshal(contractIter.Value(), &contract); err != nil {
+			contractIter.Close()
+			return err
+		}
+		creator, err := sdk.AccAddressFromBech32(contract.Creator)
+		if err != nil {
+			contractIter.Close()
+			return err
+		}
+		contractAddr := sdk.AccAddress(contractIter.Key())
+		indexKeys = append(indexKeys, types.GetContractByCreatorSecondaryIndexKey(creator, contract.Created, contractAddr))
+	}
+	contractIter.Close()
+
+	codeStore := prefix.NewStore(store, types.CodeKeyPrefix)
+	codeIter := codeStore.Iterator(nil, nil)
+	for ; codeIter.Valid(); codeIter.Next() {
+		var code types.CodeInfo
+		if err := cdc.Unmarshal(codeIter.Value(), &code); err != nil {
+			codeIter.Close()
+			return err
+		}
+		codeID := sdk.BigEndianToUint64(codeIter.Key())
+		indexKeys = append(indexKeys, types.GetCodeByChecksumSecondaryIndexKey(code.CodeHash, codeID))
+	}
+	codeIter.Close()
+
+	// write after iteration to not modify the store while iterating
+	for _, k := range indexKeys {
+		store.Set(k, []byte{})
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package v3_test
+
+import (
+	"bytes"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v3 "github.com/CosmWasm/wasmd/x/wasm/migrations/v3"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	store := ctx.KVStore(storeKey)
+
+	creator := sdk.AccAddress(bytes.Repeat([]byte{0x1}, types.SDKAddrLen))
+	otherCreator := sdk.AccAddress(bytes.Repeat([]byte{0x2}, types.SDKAddrLen))
+	checksum := bytes.Repeat([]byte{0x3}, types.ChecksumLen)
+
+	// given two codes with the same checksum
+	for _, codeID := range []uint64{1, 2} {
+		codeInfo := types.NewCodeInfo(checksum, creator, types.AllowEverybody)
+		store.Set(types.GetCodeKey(codeID), cdc.MustMarshal(&codeInfo))
+	}
+	// and three contracts by two creators
+	contracts := []struct {
+		addr    sdk.AccAddress
+		creator sdk.AccAddress
+		created *types.AbsoluteTxPosition
+	}{
+		{addr: bytes.Repeat([]byte{0x4}, types.ContractAddrLen), creator: creator, created: &types.AbsoluteTxPosition{BlockHeight: 2}},
+		{addr: bytes.Repeat([]byte{0x5}, types.ContractAddrLen), creator: otherCreator, created: &types.AbsoluteTxPosition{BlockHeight: 3}},
+		{addr: bytes.Repeat([]byte{0x6}, types.ContractAddrLen), creator: creator, created: &types.AbsoluteTxPosition{BlockHeight: 1}},
+	}
+	for _, c := range contracts {
+		info := types.NewContractInfo(1, c.creator, nil, "label", c.created)
+		store.Set(types.GetContractAddressKey(c.addr), cdc.MustMarshal(&info))
+	}
+
+	// when
+	require.NoError(t, v3.MigrateStore(ctx, storeKey, cdc))
+
+	// then
+	for _, codeID := range []uint64{1, 2} {
+		assert.True(t, store.Has(types.GetCodeByChecksumSecondaryIndexKey(checksum, codeID)))
+	}
+	for _, c := range contracts {
+		assert.True(t, store.Has(types.GetContractByCreatorSecondaryIndexKey(c.creator, c.created, c.addr)))
+	}
+	// and contracts are ordered by creation
+	iter := storetypes.KVStorePrefixIterator(store, types.GetContractsByCreatorPrefix(creator))
+	defer iter.Close()
+	var got []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		got = append(got, iter.Key()[len(types.GetContractsByCreatorPrefix(creator))+types.AbsoluteTxPositionLen:])
+	}
+	assert.Equal(t, []sdk.AccAddress{contracts[2].addr, contracts[0].addr}, got)
+}
@@ -0,0 +1,68 @@
+package v4
+
+import (
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MigrateStore migrates the x/wasm module state from the consensus version 3 to
+// version 4. Specifically, it replaces all deprecated AccessTypeOnlyAddress
+// access configs in the params and the code infos by AccessTypeAnyOfAddresses
+// with the single address. A deprecated default instantiate permission becomes
+// AccessTypeAnyOfAddresses, which still grants the code creator only.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey, cdc codec.BinaryCodec) error {
+	store := ctx.KVStore(storeKey)
+
+	var params types.Params
+	if bz := store.Get(types.ParamsKey); bz != nil {
+		if err := cdc.Unmarshal(bz, &params); err != nil {
+			return err
+		}
+		modified := migrateAccessConfig(&params.CodeUploadAccess)
+		if params.InstantiateDefaultPermission == types.AccessTypeOnlyAddress {
+			params.InstantiateDefaultPermission = types.AccessTypeAnyOfAddresses
+			modified = true
+		}
+		if modified {
+			store.Set(types.ParamsKey, cdc.MustMarshal(&params))
+		}
+	}
+
+	codeStore := prefix.NewStore(store, types.CodeKeyPrefix)
+	type update struct {
+		codeID uint64
+		info   types.CodeInfo
+	}
+	var updates []update
+	iter := codeStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		var code types.CodeInfo
+		if err := cdc.Unmarshal(iter.Value(), &code); err != nil {
+			iter.Close()
+			return err
+		}
+		if migrateAccessConfig(&code.InstantiateConfig) {
+			updates = append(updates, update{codeID: sdk.BigEndianToUint64(iter.Key()), info: code})
+		}
+	}
+	iter.Close()
+
+	// write after iteration to not modify the store while iterating
+	for _, u := range updates {
+		store.Set(types.GetCodeKey(u.codeID), cdc.MustMarshal(&u.info))
+	}
+	return nil
+}
+
+// migrateAccessConfig converts an AccessTypeOnlyAddress config in place. Returns true when modified.
+func migrateAccessConfig(c *types.AccessConfig) bool {
+	if c.Permission != types.AccessTypeOnlyAddress {
+		return false
+	}
+	*c = types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{c.Address}}
+	return true
+}
@@ -0,0 +1,64 @@
+package v4_test
+
+import (
+	"bytes"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v4 "github.com/CosmWasm/wasmd/x/wasm/migrations/v4"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	store := ctx.KVStore(storeKey)
+
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{0x1}, types.SDKAddrLen))
+	checksum := bytes.Repeat([]byte{0x3}, types.ChecksumLen)
+
+	params := types.Params{
+		CodeUploadAccess:             types.AccessTypeOnlyAddress.With(myAddr),
+		InstantiateDefaultPermission: types.AccessTypeOnlyAddress,
+		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+	}
+	store.Set(types.ParamsKey, cdc.MustMarshal(&params))
+
+	codeConfigs := map[uint64]types.AccessConfig{
+		1: types.AccessTypeOnlyAddress.With(myAddr),
+		2: types.AllowEverybody,
+		3: types.AllowNobody,
+	}
+	for codeID, c := range codeConfigs {
+		codeInfo := types.NewCodeInfo(checksum, myAddr, c)
+		store.Set(types.GetCodeKey(codeID), cdc.MustMarshal(&codeInfo))
+	}
+
+	// when
+	require.NoError(t, v4.MigrateStore(ctx, storeKey, cdc))
+
+	// then
+	var gotParams types.Params
+	require.NoError(t, cdc.Unmarshal(store.Get(types.ParamsKey), &gotParams))
+	assert.Equal(t, types.AccessTypeAnyOfAddresses.With(myAddr), gotParams.CodeUploadAccess)
+	assert.Equal(t, types.AccessTypeAnyOfAddresses, gotParams.InstantiateDefaultPermission)
+	require.NoError(t, gotParams.ValidateBasic())
+
+	expCodeConfigs := map[uint64]types.AccessConfig{
+		1: types.AccessTypeAnyOfAddresses.With(myAddr),
+		2: types.AllowEverybody,
+		3: types.AllowNobody,
+	}
+	for codeID, exp := range expCodeConfigs {
+		var got types.CodeInfo
+		require.NoError(t, cdc.Unmarshal(store.Get(types.GetCodeKey(codeID)), &got))
+		assert.Equal(t, exp, got.InstantiateConfig, "code id: %d", codeID)
+	}
+}
@@ -0,0 +1,37 @@
+package v5
+
+import (
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MigrateStore migrates the x/wasm module state from the consensus version 4 to
+// version 5. Specifically, it back-fills the contracts-by-label secondary index
+// for all contracts that were stored before the index existed.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey, cdc codec.BinaryCodec) error {
+	store := ctx.KVStore(storeKey)
+
+	var indexKeys [][]byte
+	contractStore := prefix.NewStore(store, types.ContractKeyPrefix)
+	iter := contractStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		var contract types.ContractInfo
+		if err := cdc.Unmarshal(iter.Value(), &contract); err != nil {
+			iter.Close()
+			return err
+		}
+		contractAddr := sdk.AccAddress(iter.Key())
+		indexKeys = append(indexKeys, types.GetContractByLabelSecondaryIndexKey(contract.Label, contractAddr))
+	}
+	iter.Close()
+
+	// write after iteration to not modify the store while iterating
+	for _, k := range indexKeys {
+		store.Set(k, []byte{})
+	}
+	return nil
+}
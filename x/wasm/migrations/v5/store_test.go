@@ -0,0 +1,50 @@
+package v5_test
+
+import (
+	"bytes"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v5 "github.com/CosmWasm/wasmd/x/wasm/migrations/v5"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	store := ctx.KVStore(storeKey)
+
+	creator := sdk.AccAddress(bytes.Repeat([]byte{0x1}, types.SDKAddrLen))
+	// given three contracts where two share a label
+	contracts := []struct {
+		addr  sdk.AccAddress
+		label string
+	}{
+		{addr: bytes.Repeat([]byte{0x4}, types.ContractAddrLen), label: "foo"},
+		{addr: bytes.Repeat([]byte{0x5}, types.ContractAddrLen), label: "bar"},
+		{addr: bytes.Repeat([]byte{0x6}, types.ContractAddrLen), label: "foo"},
+	}
+	for i, c := range contracts {
+		info := types.NewContractInfo(1, creator, nil, c.label, &types.AbsoluteTxPosition{BlockHeight: uint64(i)})
+		store.Set(types.GetContractAddressKey(c.addr), cdc.MustMarshal(&info))
+	}
+
+	// when
+	require.NoError(t, v5.MigrateStore(ctx, storeKey, cdc))
+
+	// then
+	for _, c := range contracts {
+		assert.True(t, store.Has(types.GetContractByLabelSecondaryIndexKey(c.label, c.addr)))
+	}
+	// and labels do not match by prefix
+	iter := storetypes.KVStorePrefixIterator(store, types.GetContractsByLabelPrefix("fo"))
+	defer iter.Close()
+	assert.False(t, iter.Valid())
+}
@@ -3,6 +3,7 @@ package wasm
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -14,11 +15,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	simKeeper "github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 
 	"github.com/CosmWasm/wasmd/x/wasm/client/cli"
+	"github.com/CosmWasm/wasmd/x/wasm/exported"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/simulation"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -29,6 +32,8 @@ var (
 	_ module.AppModuleBasic      = AppModuleBasic{}
 	_ module.HasABCIEndBlock     = AppModule{}
 	_ module.AppModuleSimulation = AppModule{}
+	_ module.HasProposalMsgs     = AppModule{}
+	_ module.HasInvariants       = AppModule{}
 )
 
 // Module init related flags
@@ -36,6 +41,13 @@ const (
 	flagWasmMemoryCacheSize    = "wasm.memory_cache_size"
 	flagWasmQueryGasLimit      = "wasm.query_gas_limit"
 	flagWasmSimulationGasLimit = "wasm.simulation_gas_limit"
+	flagWasmContractTraceCache = "wasm.contract_trace_cache_size"
+)
+
+// Export related flags
+const (
+	flagWasmGenesisStream  = "wasm.genesis_stream"
+	flagWasmGenesisHistory = "wasm.genesis_history"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -50,6 +62,10 @@ func (b AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, serv
 	if err != nil {
 		panic(err)
 	}
+	err = types.RegisterDebugHandlerClient(context.Background(), serveMux, types.NewDebugClient(clientCtx))
+	if err != nil {
+		panic(err)
+	}
 }
 
 // Name returns the wasm module's name.
@@ -98,13 +114,17 @@ type AppModule struct {
 	cdc                codec.Codec
 	keeper             *Keeper
 	validatorSetSource keeper.ValidatorSetSource
+	accountKeeper      simKeeper.AccountKeeper
+	bankKeeper         simulation.BankKeeper
+	// legacySubspace is used solely for migration of x/params managed parameters
+	legacySubspace exported.Subspace
 }
 
 // ConsensusVersion is a sequence number for state-breaking change of the
 // module. It should be incremented on each consensus-breaking change
 // introduced by the module. To avoid wrong/empty versions, the initial version
 // should be set to 1.
-func (AppModule) ConsensusVersion() uint64 { return 1 }
+func (AppModule) ConsensusVersion() uint64 { return 5 }
 
 // IsOnePerModuleType implements the depinject.OnePerModuleType interface.
 func (AppModule) IsOnePerModuleType() {}
@@ -113,22 +133,49 @@ func (AppModule) IsOnePerModuleType() {}
 func (AppModule) IsAppModule() {}
 
 // NewAppModule creates a new AppModule object
-func NewAppModule(cdc codec.Codec, keeper *Keeper, validatorSetSource keeper.ValidatorSetSource) AppModule {
+func NewAppModule(
+	cdc codec.Codec,
+	keeper *Keeper,
+	validatorSetSource keeper.ValidatorSetSource,
+	ak simKeeper.AccountKeeper,
+	bk simulation.BankKeeper,
+	ss exported.Subspace,
+) AppModule {
 	return AppModule{
 		AppModuleBasic:     AppModuleBasic{},
 		cdc:                cdc,
 		keeper:             keeper,
 		validatorSetSource: validatorSetSource,
+		accountKeeper:      ak,
+		bankKeeper:         bk,
+		legacySubspace:     ss,
 	}
 }
 
 func (am AppModule) RegisterServices(cfg module.Configurator) {
-	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(keeper.NewDefaultPermissionKeeper(am.keeper)))
+	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.keeper))
 	types.RegisterQueryServer(cfg.QueryServer(), NewQuerier(am.keeper))
+	types.RegisterDebugServer(cfg.QueryServer(), NewDebugQuerier(am.keeper))
+
+	m := keeper.NewMigrator(*am.keeper, am.legacySubspace)
+	if err := cfg.RegisterMigration(types.ModuleName, 1, m.Migrate1to2); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 1 to 2: %v", types.ModuleName, err))
+	}
+	if err := cfg.RegisterMigration(types.ModuleName, 2, m.Migrate2to3); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 2 to 3: %v", types.ModuleName, err))
+	}
+	if err := cfg.RegisterMigration(types.ModuleName, 3, m.Migrate3to4); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 3 to 4: %v", types.ModuleName, err))
+	}
+	if err := cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 4 to 5: %v", types.ModuleName, err))
+	}
 }
 
 // RegisterInvariants registers the wasm module invariants.
-func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper)
+}
 
 // QuerierRoute returns the wasm module's querier route name.
 // Deprecated: QuerierRoute is kept for backward compatibility but queries should use gRPC
@@ -142,7 +189,7 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 	var genesisState GenesisState
 	cdc.MustUnmarshalJSON(data, &genesisState)
 	// Create handler for InitGenesis
-	handler := NewHandler(keeper.NewDefaultPermissionKeeper(am.keeper))
+	handler := NewHandler(am.keeper)
 	validators, err := InitGenesis(ctx, am.keeper, genesisState, am.validatorSetSource, handler)
 	if err != nil {
 		panic(err)
@@ -157,10 +204,11 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	return cdc.MustMarshalJSON(gs)
 }
 
-// EndBlock returns the end blocker for the wasm module. It returns no validator
-// updates. This implements the HasABCIEndBlock interface for SDK 0.50+
-func (AppModule) EndBlock(ctx context.Context) ([]abci.ValidatorUpdate, error) {
-	// No end block logic needed for wasm module
+// EndBlock returns the end blocker for the wasm module. It removes the deleted codes that are
+// due for removal from the wasmvm cache and returns no validator updates.
+// This implements the HasABCIEndBlock interface for SDK 0.50+
+func (am AppModule) EndBlock(ctx context.Context) ([]abci.ValidatorUpdate, error) {
+	am.keeper.ProcessCodeRemovals(sdk.UnwrapSDKContext(ctx))
 	return []abci.ValidatorUpdate{}, nil
 }
 
@@ -185,13 +233,19 @@ func (am AppModule) RandomizedParams(r *rand.Rand) []simtypes.LegacyParamChange
 	return nil
 }
 
-// RegisterStoreDecoder registers a decoder for supply module's types
+// ProposalMsgs returns the msgs used by the simulator for the gov v1 proposals.
+func (am AppModule) ProposalMsgs(simState module.SimulationState) []simtypes.WeightedProposalMsg {
+	return simulation.ProposalMsgs(am.keeper)
+}
+
+// RegisterStoreDecoder registers a decoder for wasm module's types
 func (am AppModule) RegisterStoreDecoder(sdr simtypes.StoreDecoderRegistry) {
+	sdr[types.StoreKey] = simulation.NewDecodeStore(am.cdc)
 }
 
-// WeightedOperations returns the all the gov module operations with their respective weights.
+// WeightedOperations returns the all the wasm module operations with their respective weights.
 func (am AppModule) WeightedOperations(simState module.SimulationState) []simtypes.WeightedOperation {
-	return nil
+	return simulation.WeightedOperations(&simState, am.accountKeeper, am.bankKeeper, am.keeper)
 }
 
 // ____________________________________________________________________________
@@ -202,6 +256,25 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Uint32(flagWasmMemoryCacheSize, defaults.MemoryCacheSize, "Sets the size in MiB (NOT bytes) of an in-memory cache for Wasm modules. Set to 0 to disable.")
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
+	startCmd.Flags().Uint32(flagWasmContractTraceCache, defaults.ContractTraceCacheSize, "Set the number of txs for which the contract call traces are kept in memory for the debug queries. Set to 0 to disable.")
+}
+
+// AddExportFlags adds the wasm flags to the export command
+func AddExportFlags(exportCmd *cobra.Command) {
+	exportCmd.Flags().String(flagWasmGenesisStream, "", "Stream the wasm codes and contracts to the given file instead of embedding them in the genesis. The file must be placed in the config directory of the node that imports the genesis.")
+	exportCmd.Flags().Bool(flagWasmGenesisHistory, false, "Export the full contract code history and creation positions instead of resetting them to the genesis on import")
+}
+
+// ReadExportOptions reads the wasm keeper options for the export command
+func ReadExportOptions(opts servertypes.AppOptions) []Option {
+	var wasmOpts []Option
+	if v := cast.ToString(opts.Get(flagWasmGenesisStream)); v != "" {
+		wasmOpts = append(wasmOpts, keeper.WithGenesisStreamExport(v))
+	}
+	if cast.ToBool(opts.Get(flagWasmGenesisHistory)) {
+		wasmOpts = append(wasmOpts, keeper.WithGenesisHistoryExport())
+	}
+	return wasmOpts
 }
 
 // ReadWasmConfig reads the wasm specifig configuration
@@ -227,6 +300,11 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 			cfg.SimulationGasLimit = &limit
 		}
 	}
+	if v := opts.Get(flagWasmContractTraceCache); v != nil {
+		if cfg.ContractTraceCacheSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
 	// attach contract debugging to global "trace" flag
 	if v := opts.Get(server.FlagTrace); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
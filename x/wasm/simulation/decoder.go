@@ -0,0 +1,81 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// NewDecodeStore returns a decoder function closure that unmarshals the KVPair's
+// Value to the corresponding wasm type.
+func NewDecodeStore(cdc codec.Codec) func(kvA, kvB kv.Pair) string {
+	return func(kvA, kvB kv.Pair) string {
+		switch {
+		case bytes.Equal(kvA.Key[:1], types.CodeKeyPrefix):
+			var codeInfoA, codeInfoB types.CodeInfo
+			cdc.MustUnmarshal(kvA.Value, &codeInfoA)
+			cdc.MustUnmarshal(kvB.Value, &codeInfoB)
+			return fmt.Sprintf("%v\n%v", codeInfoA, codeInfoB)
+
+		case bytes.Equal(kvA.Key[:1], types.ContractKeyPrefix):
+			var contractInfoA, contractInfoB types.ContractInfo
+			cdc.MustUnmarshal(kvA.Value, &contractInfoA)
+			cdc.MustUnmarshal(kvB.Value, &contractInfoB)
+			return fmt.Sprintf("%v\n%v", contractInfoA, contractInfoB)
+
+		case bytes.Equal(kvA.Key[:1], types.ContractCodeHistoryElementPrefix):
+			var entryA, entryB types.ContractCodeHistoryEntry
+			cdc.MustUnmarshal(kvA.Value, &entryA)
+			cdc.MustUnmarshal(kvB.Value, &entryB)
+			return fmt.Sprintf("%v\n%v", entryA, entryB)
+
+		case bytes.Equal(kvA.Key[:1], types.SequenceKeyPrefix),
+			bytes.Equal(kvA.Key[:1], types.CodeRemovalQueuePrefix):
+			return fmt.Sprintf("%d\n%d", sdk.BigEndianToUint64(kvA.Value), sdk.BigEndianToUint64(kvB.Value))
+
+		case bytes.Equal(kvA.Key[:1], types.AsyncAckPacketPrefix):
+			var packetA, packetB channeltypes.Packet
+			cdc.MustUnmarshal(kvA.Value, &packetA)
+			cdc.MustUnmarshal(kvB.Value, &packetB)
+			return fmt.Sprintf("%v\n%v", packetA, packetB)
+
+		case bytes.Equal(kvA.Key[:1], types.ClassTracePrefix):
+			var traceA, traceB types.ClassTrace
+			cdc.MustUnmarshal(kvA.Value, &traceA)
+			cdc.MustUnmarshal(kvB.Value, &traceB)
+			return fmt.Sprintf("%v\n%v", traceA, traceB)
+
+		case bytes.Equal(kvA.Key[:1], types.TokenTracePrefix):
+			var traceA, traceB types.TokenTrace
+			cdc.MustUnmarshal(kvA.Value, &traceA)
+			cdc.MustUnmarshal(kvB.Value, &traceB)
+			return fmt.Sprintf("%v\n%v", traceA, traceB)
+
+		case bytes.Equal(kvA.Key[:1], types.ParamsKey):
+			var paramsA, paramsB types.Params
+			cdc.MustUnmarshal(kvA.Value, &paramsA)
+			cdc.MustUnmarshal(kvB.Value, &paramsB)
+			return fmt.Sprintf("%v\n%v", paramsA, paramsB)
+
+		// the contract state is opaque to the chain and the secondary indexes
+		// and the tx counter store raw bytes only
+		case bytes.Equal(kvA.Key[:1], types.ContractStorePrefix),
+			bytes.Equal(kvA.Key[:1], types.ContractByCodeIDAndCreatedSecondaryIndexPrefix),
+			bytes.Equal(kvA.Key[:1], types.PinnedCodeIndexPrefix),
+			bytes.Equal(kvA.Key[:1], types.TXCounterPrefix),
+			bytes.Equal(kvA.Key[:1], types.ContractsByCreatorPrefix),
+			bytes.Equal(kvA.Key[:1], types.CodeByChecksumSecondaryIndexPrefix),
+			bytes.Equal(kvA.Key[:1], types.ContractsByLabelPrefix):
+			return fmt.Sprintf("%X\n%X", kvA.Value, kvB.Value)
+
+		default:
+			panic(fmt.Sprintf("invalid wasm key prefix %X", kvA.Key[:1]))
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDecodeStore(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	dec := NewDecodeStore(cdc)
+	contractAddr := sdk.AccAddress(make([]byte, types.ContractAddrLen))
+	codeInfo := types.CodeInfo{CodeHash: []byte("myChecksum"), InstantiateConfig: types.AllowEverybody}
+	contractInfo := types.ContractInfo{CodeID: 1, Label: "myLabel"}
+	params := types.DefaultParams()
+
+	specs := map[string]struct {
+		pair   kv.Pair
+		exp    string
+		expErr bool
+	}{
+		"code": {
+			pair: kv.Pair{Key: types.GetCodeKey(1), Value: cdc.MustMarshal(&codeInfo)},
+			exp:  fmt.Sprintf("%v\n%v", codeInfo, codeInfo),
+		},
+		"contract": {
+			pair: kv.Pair{Key: types.GetContractAddressKey(contractAddr), Value: cdc.MustMarshal(&contractInfo)},
+			exp:  fmt.Sprintf("%v\n%v", contractInfo, contractInfo),
+		},
+		"sequence": {
+			pair: kv.Pair{Key: types.KeyLastCodeID, Value: sdk.Uint64ToBigEndian(7)},
+			exp:  "7\n7",
+		},
+		"code removal queue": {
+			pair: kv.Pair{Key: types.GetCodeRemovalQueueKey([]byte{1}), Value: sdk.Uint64ToBigEndian(9)},
+			exp:  "9\n9",
+		},
+		"params": {
+			pair: kv.Pair{Key: types.ParamsKey, Value: cdc.MustMarshal(&params)},
+			exp:  fmt.Sprintf("%v\n%v", params, params),
+		},
+		"contract state": {
+			pair: kv.Pair{Key: append(types.GetContractStorePrefix(contractAddr), []byte("foo")...), Value: []byte{0x1, 0x2}},
+			exp:  "0102\n0102",
+		},
+		"pinned code index": {
+			pair: kv.Pair{Key: types.GetPinnedCodeIndexPrefix(1), Value: []byte{1}},
+			exp:  "01\n01",
+		},
+		"unknown prefix": {
+			pair:   kv.Pair{Key: []byte{0x99}, Value: []byte{0x1}},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expErr {
+				require.Panics(t, func() { dec(spec.pair, spec.pair) })
+				return
+			}
+			assert.Equal(t, spec.exp, dec(spec.pair, spec.pair))
+		})
+	}
+}
@@ -1,14 +1,17 @@
 package simulation
 
 import (
+	"math/rand"
+
 	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 // RandomizeGenState generates a random GenesisState for wasm
 func RandomizedGenState(simstate *module.SimulationState) {
-	params := RandomParams(simstate.Rand)
+	params := randomParams(simstate.Rand, simstate.Accounts)
 	wasmGenesis := types.GenesisState{
 		Params:    params,
 		Codes:     nil,
@@ -27,3 +30,13 @@ func RandomizedGenState(simstate *module.SimulationState) {
 
 	simstate.GenState[types.ModuleName] = simstate.Cdc.MustMarshalJSON(&wasmGenesis)
 }
+
+// randomParams returns params that allow the code uploads by everybody or a random set of the
+// simulation accounts and a max code size that fits the test contracts
+func randomParams(r *rand.Rand, accs []simtypes.Account) types.Params {
+	params := types.DefaultParams()
+	params.CodeUploadAccess = randomAccessConfig(r, accs)
+	params.InstantiateDefaultPermission = params.CodeUploadAccess.Permission
+	params.MaxWasmCodeSize = uint64(simtypes.RandIntBetween(r, 600, 1200) * 1024)
+	return params
+}
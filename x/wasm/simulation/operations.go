@@ -0,0 +1,450 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgStoreCode           = "op_weight_msg_store_code"
+	OpWeightMsgInstantiateContract = "op_weight_msg_instantiate_contract"
+	OpWeightMsgExecuteContract     = "op_weight_msg_execute_contract"
+	OpWeightMsgMigrateContract     = "op_weight_msg_migrate_contract"
+	OpWeightMsgUpdateAdmin         = "op_weight_msg_update_admin"
+	OpWeightMsgClearAdmin          = "op_weight_msg_clear_admin"
+	OpWeightMsgUpdateContractLabel = "op_weight_msg_update_contract_label"
+
+	DefaultWeightMsgStoreCode           = 50
+	DefaultWeightMsgInstantiateContract = 100
+	DefaultWeightMsgExecuteContract     = 100
+	DefaultWeightMsgMigrateContract     = 25
+	DefaultWeightMsgUpdateAdmin         = 25
+	DefaultWeightMsgClearAdmin          = 10
+	DefaultWeightMsgUpdateContractLabel = 25
+)
+
+// BankKeeper is a subset of the sdk bank keeper methods used by the simulations
+type BankKeeper interface {
+	simulation.BankKeeper
+	GetAllBalances(ctx context.Context, addr sdk.AccAddress) sdk.Coins
+	IsSendEnabledCoins(ctx context.Context, coins ...sdk.Coin) error
+}
+
+// WasmKeeper is a subset of the wasm keeper methods used by the simulations
+type WasmKeeper interface {
+	GetAuthority() string
+	GetParams(ctx sdk.Context) types.Params
+	GetCodeInfo(ctx sdk.Context, codeID uint64) *types.CodeInfo
+	IterateCodeInfos(ctx sdk.Context, cb func(uint64, types.CodeInfo) bool)
+	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
+	IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+}
+
+// simContract is a test contract with the messages that are sent to it in the simulations
+type simContract struct {
+	wasmCode []byte
+	checksum []byte
+	// instantiateMsg returns the init message for the contract creator
+	instantiateMsg func(r *rand.Rand, creator sdk.AccAddress, accs []simtypes.Account) []byte
+	// executeMsg returns the message for the creator that sends the funds to the contract
+	executeMsg func(creator sdk.AccAddress, funds sdk.Coins) []byte
+	// migrateMsg returns the migrate message for the admin or nil when the contract can not be migrated
+	migrateMsg func(admin sdk.AccAddress) []byte
+}
+
+// testContracts are the contracts from the testdata that are used in the simulations
+var testContracts = simContracts()
+
+func simContracts() []simContract {
+	contracts := []simContract{
+		{
+			wasmCode: testdata.ReflectContractWasm(),
+			instantiateMsg: func(*rand.Rand, sdk.AccAddress, []simtypes.Account) []byte {
+				return []byte(`{}`)
+			},
+			// the owner reflects a bank send of the funds back to itself
+			executeMsg: func(creator sdk.AccAddress, funds sdk.Coins) []byte {
+				return mustMarshalJSON(map[string]any{
+					"reflect_msg": map[string]any{
+						"msgs": []any{map[string]any{
+							"bank": map[string]any{
+								"send": map[string]any{"to_address": creator.String(), "amount": funds},
+							},
+						}},
+					},
+				})
+			},
+		},
+		{
+			wasmCode: testdata.HackatomContractWasm(),
+			instantiateMsg: func(r *rand.Rand, creator sdk.AccAddress, accs []simtypes.Account) []byte {
+				beneficiary, _ := simtypes.RandomAcc(r, accs)
+				return mustMarshalJSON(map[string]string{
+					"verifier":    creator.String(),
+					"beneficiary": beneficiary.Address.String(),
+				})
+			},
+			// the verifier releases the contract funds to the beneficiary
+			executeMsg: func(sdk.AccAddress, sdk.Coins) []byte {
+				return []byte(`{"release":{}}`)
+			},
+			migrateMsg: func(admin sdk.AccAddress) []byte {
+				return mustMarshalJSON(map[string]string{"verifier": admin.String()})
+			},
+		},
+	}
+	for i, c := range contracts {
+		checksum := sha256.Sum256(c.wasmCode)
+		contracts[i].checksum = checksum[:]
+	}
+	return contracts
+}
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	simState *module.SimulationState,
+	ak simulation.AccountKeeper,
+	bk BankKeeper,
+	wasmKeeper WasmKeeper,
+) simulation.WeightedOperations {
+	var (
+		weightMsgStoreCode           int
+		weightMsgInstantiateContract int
+		weightMsgExecuteContract     int
+		weightMsgMigrateContract     int
+		weightMsgUpdateAdmin         int
+		weightMsgClearAdmin          int
+		weightMsgUpdateContractLabel int
+	)
+	simState.AppParams.GetOrGenerate(OpWeightMsgStoreCode, &weightMsgStoreCode, nil, func(_ *rand.Rand) {
+		weightMsgStoreCode = DefaultWeightMsgStoreCode
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgInstantiateContract, &weightMsgInstantiateContract, nil, func(_ *rand.Rand) {
+		weightMsgInstantiateContract = DefaultWeightMsgInstantiateContract
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgExecuteContract, &weightMsgExecuteContract, nil, func(_ *rand.Rand) {
+		weightMsgExecuteContract = DefaultWeightMsgExecuteContract
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgMigrateContract, &weightMsgMigrateContract, nil, func(_ *rand.Rand) {
+		weightMsgMigrateContract = DefaultWeightMsgMigrateContract
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgUpdateAdmin, &weightMsgUpdateAdmin, nil, func(_ *rand.Rand) {
+		weightMsgUpdateAdmin = DefaultWeightMsgUpdateAdmin
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgClearAdmin, &weightMsgClearAdmin, nil, func(_ *rand.Rand) {
+		weightMsgClearAdmin = DefaultWeightMsgClearAdmin
+	})
+	simState.AppParams.GetOrGenerate(OpWeightMsgUpdateContractLabel, &weightMsgUpdateContractLabel, nil, func(_ *rand.Rand) {
+		weightMsgUpdateContractLabel = DefaultWeightMsgUpdateContractLabel
+	})
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgStoreCode,
+			SimulateMsgStoreCode(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgInstantiateContract,
+			SimulateMsgInstantiateContract(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgExecuteContract,
+			SimulateMsgExecuteContract(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgMigrateContract,
+			SimulateMsgMigrateContract(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgUpdateAdmin,
+			SimulateMsgUpdateAdmin(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgClearAdmin,
+			SimulateMsgClearAdmin(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgUpdateContractLabel,
+			SimulateMsgUpdateContractLabel(simState.TxConfig, ak, bk, wasmKeeper),
+		),
+	}
+}
+
+// SimulateMsgStoreCode generates a MsgStoreCode with one of the test contracts
+func SimulateMsgStoreCode(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgStoreCode{})
+		simAccount, _ := simtypes.RandomAcc(r, accs)
+		params := wasmKeeper.GetParams(ctx)
+		if !params.CodeUploadAccess.Allowed(simAccount.Address) {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no upload permission"), nil, nil
+		}
+		contract := testContracts[r.Intn(len(testContracts))]
+		if uint64(len(contract.wasmCode)) > params.MaxWasmCodeSize {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "code exceeds max size"), nil, nil
+		}
+		msg := &types.MsgStoreCode{
+			Sender:       simAccount.Address.String(),
+			WASMByteCode: contract.wasmCode,
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgInstantiateContract generates a MsgInstantiateContract for a stored test contract
+func SimulateMsgInstantiateContract(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgInstantiateContract{})
+		var codeIDs []uint64
+		wasmKeeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+			if findContract(info.CodeHash) != nil {
+				codeIDs = append(codeIDs, codeID)
+			}
+			return false
+		})
+		if len(codeIDs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no code to instantiate"), nil, nil
+		}
+		codeID := codeIDs[r.Intn(len(codeIDs))]
+		codeInfo := wasmKeeper.GetCodeInfo(ctx, codeID)
+		var creators []simtypes.Account
+		for _, acc := range accs {
+			if codeInfo.InstantiateConfig.Allowed(acc.Address) {
+				creators = append(creators, acc)
+			}
+		}
+		if len(creators) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no instantiate permission"), nil, nil
+		}
+		simAccount, _ := simtypes.RandomAcc(r, creators)
+		contract := findContract(codeInfo.CodeHash)
+		funds := randomFunds(r, ctx, bk, simAccount.Address)
+		msg := &types.MsgInstantiateContract{
+			Sender: simAccount.Address.String(),
+			CodeID: codeID,
+			Label:  simtypes.RandStringOfLength(r, 10),
+			Msg:    contract.instantiateMsg(r, simAccount.Address, accs),
+			Funds:  funds,
+		}
+		if r.Intn(2) == 0 {
+			msg.Admin = simAccount.Address.String()
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, funds)
+	}
+}
+
+// SimulateMsgExecuteContract generates a MsgExecuteContract that sends funds to a test contract
+// which are paid out by the contract again
+func SimulateMsgExecuteContract(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgExecuteContract{})
+		// the test contracts can only be executed by their creators
+		addrs, infos := collectContracts(ctx, wasmKeeper, func(info types.ContractInfo) string { return info.Creator }, accs)
+		if len(addrs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no contract to execute"), nil, nil
+		}
+		i := r.Intn(len(addrs))
+		contract := findContract(wasmKeeper.GetCodeInfo(ctx, infos[i].CodeID).CodeHash)
+		if contract == nil {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "unknown contract code"), nil, nil
+		}
+		simAccount, _ := simtypes.FindAccount(accs, sdk.MustAccAddressFromBech32(infos[i].Creator))
+		funds := randomFunds(r, ctx, bk, simAccount.Address)
+		if funds.Empty() {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no funds to pay out"), nil, nil
+		}
+		// the contract pays out its whole balance
+		if err := bk.IsSendEnabledCoins(ctx, bk.GetAllBalances(ctx, addrs[i]).Add(funds...)...); err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, err.Error()), nil, nil
+		}
+		msg := &types.MsgExecuteContract{
+			Sender:   simAccount.Address.String(),
+			Contract: addrs[i].String(),
+			Msg:      contract.executeMsg(simAccount.Address, funds),
+			Funds:    funds,
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, funds)
+	}
+}
+
+// SimulateMsgMigrateContract generates a MsgMigrateContract to a test contract code that
+// supports the migration
+func SimulateMsgMigrateContract(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgMigrateContract{})
+		addrs, infos := collectContracts(ctx, wasmKeeper, func(info types.ContractInfo) string { return info.Admin }, accs)
+		if len(addrs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no contract with admin"), nil, nil
+		}
+		i := r.Intn(len(addrs))
+		contract := findContract(wasmKeeper.GetCodeInfo(ctx, infos[i].CodeID).CodeHash)
+		if contract == nil || contract.migrateMsg == nil {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "contract can not be migrated"), nil, nil
+		}
+		// migrate to any code of the same contract
+		var codeIDs []uint64
+		wasmKeeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+			if bytes.Equal(info.CodeHash, contract.checksum) {
+				codeIDs = append(codeIDs, codeID)
+			}
+			return false
+		})
+		simAccount, _ := simtypes.FindAccount(accs, sdk.MustAccAddressFromBech32(infos[i].Admin))
+		msg := &types.MsgMigrateContract{
+			Sender:   simAccount.Address.String(),
+			Contract: addrs[i].String(),
+			CodeID:   codeIDs[r.Intn(len(codeIDs))],
+			Msg:      contract.migrateMsg(simAccount.Address),
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgUpdateAdmin generates a MsgUpdateAdmin with a random new admin
+func SimulateMsgUpdateAdmin(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgUpdateAdmin{})
+		addrs, infos := collectContracts(ctx, wasmKeeper, func(info types.ContractInfo) string { return info.Admin }, accs)
+		if len(addrs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no contract with admin"), nil, nil
+		}
+		i := r.Intn(len(addrs))
+		simAccount, _ := simtypes.FindAccount(accs, sdk.MustAccAddressFromBech32(infos[i].Admin))
+		newAdmin, _ := simtypes.RandomAcc(r, accs)
+		if newAdmin.Address.Equals(simAccount.Address) {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "new admin is the current admin"), nil, nil
+		}
+		msg := &types.MsgUpdateAdmin{
+			Sender:   simAccount.Address.String(),
+			NewAdmin: newAdmin.Address.String(),
+			Contract: addrs[i].String(),
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgClearAdmin generates a MsgClearAdmin
+func SimulateMsgClearAdmin(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgClearAdmin{})
+		addrs, infos := collectContracts(ctx, wasmKeeper, func(info types.ContractInfo) string { return info.Admin }, accs)
+		if len(addrs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no contract with admin"), nil, nil
+		}
+		i := r.Intn(len(addrs))
+		simAccount, _ := simtypes.FindAccount(accs, sdk.MustAccAddressFromBech32(infos[i].Admin))
+		msg := &types.MsgClearAdmin{
+			Sender:   simAccount.Address.String(),
+			Contract: addrs[i].String(),
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgUpdateContractLabel generates a MsgUpdateContractLabel with a random new label
+func SimulateMsgUpdateContractLabel(txGen client.TxConfig, ak simulation.AccountKeeper, bk BankKeeper, wasmKeeper WasmKeeper) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msgType := sdk.MsgTypeURL(&types.MsgUpdateContractLabel{})
+		addrs, infos := collectContracts(ctx, wasmKeeper, func(info types.ContractInfo) string { return info.Admin }, accs)
+		if len(addrs) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msgType, "no contract with admin"), nil, nil
+		}
+		i := r.Intn(len(addrs))
+		simAccount, _ := simtypes.FindAccount(accs, sdk.MustAccAddressFromBech32(infos[i].Admin))
+		msg := &types.MsgUpdateContractLabel{
+			Sender:   simAccount.Address.String(),
+			NewLabel: simtypes.RandStringOfLength(r, 10),
+			Contract: addrs[i].String(),
+		}
+		return deliverTx(r, app, ctx, txGen, ak, bk, simAccount, msg, nil)
+	}
+}
+
+// collectContracts returns the contracts where the actor returned by the selector is a simulation account
+func collectContracts(ctx sdk.Context, wasmKeeper WasmKeeper, actor func(types.ContractInfo) string, accs []simtypes.Account) ([]sdk.AccAddress, []types.ContractInfo) {
+	var (
+		addrs []sdk.AccAddress
+		infos []types.ContractInfo
+	)
+	wasmKeeper.IterateContractInfo(ctx, func(addr sdk.AccAddress, info types.ContractInfo) bool {
+		actorAddr, err := sdk.AccAddressFromBech32(actor(info))
+		if err != nil {
+			return false
+		}
+		if _, found := simtypes.FindAccount(accs, actorAddr); found {
+			addrs = append(addrs, addr)
+			infos = append(infos, info)
+		}
+		return false
+	})
+	return addrs, infos
+}
+
+// findContract returns the test contract for the checksum or nil when not found
+func findContract(checksum []byte) *simContract {
+	for i := range testContracts {
+		if bytes.Equal(testContracts[i].checksum, checksum) {
+			return &testContracts[i]
+		}
+	}
+	return nil
+}
+
+// randomFunds returns a random subset of the spendable coins of the account or no coins when
+// they can not be sent
+func randomFunds(r *rand.Rand, ctx sdk.Context, bk BankKeeper, addr sdk.AccAddress) sdk.Coins {
+	funds := simtypes.RandSubsetCoins(r, bk.SpendableCoins(ctx, addr))
+	if err := bk.IsSendEnabledCoins(ctx, funds...); err != nil {
+		return sdk.NewCoins()
+	}
+	return funds
+}
+
+// deliverTx signs the msg with the simulation account and delivers it with random fees
+func deliverTx(
+	r *rand.Rand,
+	app *baseapp.BaseApp,
+	ctx sdk.Context,
+	txGen client.TxConfig,
+	ak simulation.AccountKeeper,
+	bk BankKeeper,
+	simAccount simtypes.Account,
+	msg sdk.Msg,
+	funds sdk.Coins,
+) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	txCtx := simulation.OperationInput{
+		R:               r,
+		App:             app,
+		TxGen:           txGen,
+		Msg:             msg,
+		CoinsSpentInMsg: funds,
+		Context:         ctx,
+		SimAccount:      simAccount,
+		AccountKeeper:   ak,
+		Bankkeeper:      bk,
+		ModuleName:      types.ModuleName,
+	}
+	return simulation.GenAndDeliverTxWithRandFees(txCtx)
+}
+
+func mustMarshalJSON(v any) []byte {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// Simulation proposal weights constants
+const (
+	OpWeightMsgUpdateParams            = "op_weight_msg_update_params"
+	OpWeightMsgPinCodes                = "op_weight_msg_pin_codes"
+	OpWeightMsgUnpinCodes              = "op_weight_msg_unpin_codes"
+	OpWeightMsgUpdateInstantiateConfig = "op_weight_msg_update_instantiate_config"
+	OpWeightMsgRemoveCodes             = "op_weight_msg_remove_codes"
+
+	DefaultWeightMsgUpdateParams            = 10
+	DefaultWeightMsgPinCodes                = 10
+	DefaultWeightMsgUnpinCodes              = 10
+	DefaultWeightMsgUpdateInstantiateConfig = 10
+	DefaultWeightMsgRemoveCodes             = 5
+)
+
+// ProposalMsgs defines the module weighted proposals' contents. The msgs with wasm byte code
+// are not simulated as they exceed the gas of the simulated proposal txs.
+func ProposalMsgs(wasmKeeper WasmKeeper) []simtypes.WeightedProposalMsg {
+	return []simtypes.WeightedProposalMsg{
+		simulation.NewWeightedProposalMsg(
+			OpWeightMsgUpdateParams,
+			DefaultWeightMsgUpdateParams,
+			SimulateMsgUpdateParams(wasmKeeper),
+		),
+		simulation.NewWeightedProposalMsg(
+			OpWeightMsgPinCodes,
+			DefaultWeightMsgPinCodes,
+			SimulateMsgPinCodes(wasmKeeper),
+		),
+		simulation.NewWeightedProposalMsg(
+			OpWeightMsgUnpinCodes,
+			DefaultWeightMsgUnpinCodes,
+			SimulateMsgUnpinCodes(wasmKeeper),
+		),
+		simulation.NewWeightedProposalMsg(
+			OpWeightMsgUpdateInstantiateConfig,
+			DefaultWeightMsgUpdateInstantiateConfig,
+			SimulateMsgUpdateInstantiateConfig(wasmKeeper),
+		),
+		simulation.NewWeightedProposalMsg(
+			OpWeightMsgRemoveCodes,
+			DefaultWeightMsgRemoveCodes,
+			SimulateMsgRemoveCodes(wasmKeeper),
+		),
+	}
+}
+
+// SimulateMsgUpdateParams returns a random MsgUpdateParams
+func SimulateMsgUpdateParams(wasmKeeper WasmKeeper) simtypes.MsgSimulatorFn {
+	return func(r *rand.Rand, _ sdk.Context, accs []simtypes.Account) sdk.Msg {
+		return &types.MsgUpdateParams{
+			Authority: wasmKeeper.GetAuthority(),
+			Params:    randomParams(r, accs),
+		}
+	}
+}
+
+// SimulateMsgPinCodes returns a MsgPinCodes for random stored codes
+func SimulateMsgPinCodes(wasmKeeper WasmKeeper) simtypes.MsgSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simtypes.Account) sdk.Msg {
+		codeIDs := randomCodeIDs(r, ctx, wasmKeeper)
+		if len(codeIDs) == 0 {
+			return nil
+		}
+		return &types.MsgPinCodes{
+			Authority: wasmKeeper.GetAuthority(),
+			CodeIDs:   codeIDs,
+		}
+	}
+}
+
+// SimulateMsgUnpinCodes returns a MsgUnpinCodes for random stored codes
+func SimulateMsgUnpinCodes(wasmKeeper WasmKeeper) simtypes.MsgSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simtypes.Account) sdk.Msg {
+		codeIDs := randomCodeIDs(r, ctx, wasmKeeper)
+		if len(codeIDs) == 0 {
+			return nil
+		}
+		return &types.MsgUnpinCodes{
+			Authority: wasmKeeper.GetAuthority(),
+			CodeIDs:   codeIDs,
+		}
+	}
+}
+
+// SimulateMsgUpdateInstantiateConfig returns a MsgUpdateInstantiateConfig with a random
+// permission for a random stored code
+func SimulateMsgUpdateInstantiateConfig(wasmKeeper WasmKeeper) simtypes.MsgSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, accs []simtypes.Account) sdk.Msg {
+		codeIDs := randomCodeIDs(r, ctx, wasmKeeper)
+		if len(codeIDs) == 0 {
+			return nil
+		}
+		permission := randomAccessConfig(r, accs)
+		return &types.MsgUpdateInstantiateConfig{
+			Sender:                   wasmKeeper.GetAuthority(),
+			CodeID:                   codeIDs[0],
+			NewInstantiatePermission: &permission,
+		}
+	}
+}
+
+// SimulateMsgRemoveCodes returns a MsgRemoveCodes for random stored codes without contract instances
+func SimulateMsgRemoveCodes(wasmKeeper WasmKeeper) simtypes.MsgSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, _ []simtypes.Account) sdk.Msg {
+		var codeIDs []uint64
+		for _, codeID := range randomCodeIDs(r, ctx, wasmKeeper) {
+			var inUse bool
+			wasmKeeper.IterateContractsByCode(ctx, codeID, func(sdk.AccAddress) bool {
+				inUse = true
+				return true
+			})
+			if !inUse {
+				codeIDs = append(codeIDs, codeID)
+			}
+		}
+		if len(codeIDs) == 0 {
+			return nil
+		}
+		return &types.MsgRemoveCodes{
+			Authority: wasmKeeper.GetAuthority(),
+			CodeIDs:   codeIDs,
+		}
+	}
+}
+
+// randomAccessConfig returns an access config that allows everybody or a random set of
+// simulation accounts
+func randomAccessConfig(r *rand.Rand, accs []simtypes.Account) types.AccessConfig {
+	if r.Intn(2) == 0 {
+		return types.AllowEverybody
+	}
+	var addrs []string
+	for _, acc := range accs {
+		if r.Intn(3) == 0 {
+			addrs = append(addrs, acc.Address.String())
+		}
+	}
+	if len(addrs) == 0 {
+		return types.AllowNobody
+	}
+	return types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: addrs}
+}
+
+// randomCodeIDs returns a random subset of the stored code ids
+func randomCodeIDs(r *rand.Rand, ctx sdk.Context, wasmKeeper WasmKeeper) []uint64 {
+	var codeIDs []uint64
+	wasmKeeper.IterateCodeInfos(ctx, func(codeID uint64, _ types.CodeInfo) bool {
+		if r.Intn(2) == 0 {
+			codeIDs = append(codeIDs, codeID)
+		}
+		return false
+	})
+	return codeIDs
+}
@@ -0,0 +1,524 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractAuthzFilterValidate(t *testing.T) {
+	specs := map[string]struct {
+		src    ContractAuthzFilterX
+		expErr bool
+	}{
+		"allow all": {
+			src: &AllowAllMessagesFilter{},
+		},
+		"allow keys - single": {
+			src: NewAcceptedMessageKeysFilter("foo"),
+		},
+		"allow keys - multi": {
+			src: NewAcceptedMessageKeysFilter("foo", "bar"),
+		},
+		"allow keys - empty": {
+			src:    NewAcceptedMessageKeysFilter(),
+			expErr: true,
+		},
+		"allow keys - duplicates": {
+			src:    NewAcceptedMessageKeysFilter("foo", "foo"),
+			expErr: true,
+		},
+		"allow keys - whitespaces": {
+			src:    NewAcceptedMessageKeysFilter(" foo"),
+			expErr: true,
+		},
+		"allow keys - empty key": {
+			src:    NewAcceptedMessageKeysFilter("", "bar"),
+			expErr: true,
+		},
+		"allow message - single": {
+			src: NewAcceptedMessagesFilter([]byte(`{}`)),
+		},
+		"allow message - multiple": {
+			src: NewAcceptedMessagesFilter([]byte(`{}`), []byte(`{"foo":"bar"}`)),
+		},
+		"allow message - multiple with empty": {
+			src:    NewAcceptedMessagesFilter([]byte(`{}`), nil),
+			expErr: true,
+		},
+		"allow message - duplicate": {
+			src:    NewAcceptedMessagesFilter([]byte(`{}`), []byte(`{}`)),
+			expErr: true,
+		},
+		"allow message - non json": {
+			src:    NewAcceptedMessagesFilter([]byte("non-json")),
+			expErr: true,
+		},
+		"undefined": {
+			src:    &UndefinedFilter{},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestContractAuthzFilterAccept(t *testing.T) {
+	specs := map[string]struct {
+		filter         ContractAuthzFilterX
+		src            RawContractMessage
+		exp            bool
+		expGasConsumed storetypes.Gas
+		expErr         bool
+	}{
+		"allow all - accepts json obj": {
+			filter: &AllowAllMessagesFilter{},
+			src:    []byte(`{}`),
+			exp:    true,
+		},
+		"allow all - rejects non json msg": {
+			filter: &AllowAllMessagesFilter{},
+			src:    []byte(``),
+			expErr: true,
+		},
+		"allowed key - single": {
+			filter:         NewAcceptedMessageKeysFilter("foo"),
+			src:            []byte(`{"foo": "bar"}`),
+			exp:            true,
+			expGasConsumed: storetypes.Gas(len(`{"foo": "bar"}`)),
+		},
+		"allowed key - multiple": {
+			filter:         NewAcceptedMessageKeysFilter("foo", "other"),
+			src:            []byte(`{"other": "value"}`),
+			exp:            true,
+			expGasConsumed: storetypes.Gas(len(`{"other": "value"}`)),
+		},
+		"allowed key - non accepted key": {
+			filter:         NewAcceptedMessageKeysFilter("foo"),
+			src:            []byte(`{"bar": "value"}`),
+			exp:            false,
+			expGasConsumed: storetypes.Gas(len(`{"bar": "value"}`)),
+		},
+		"allowed key - unsupported array msg": {
+			filter:         NewAcceptedMessageKeysFilter("foo", "other"),
+			src:            []byte(`[{"foo":"bar"}]`),
+			expErr:         false,
+			expGasConsumed: storetypes.Gas(len(`[{"foo":"bar"}]`)),
+		},
+		"allowed key - multiple top level keys": {
+			filter:         NewAcceptedMessageKeysFilter("foo", "bar"),
+			src:            []byte(`{"foo": {}, "bar": {}}`),
+			exp:            false,
+			expGasConsumed: storetypes.Gas(len(`{"foo": {}, "bar": {}}`)),
+		},
+		"allowed key - invalid json": {
+			filter:         NewAcceptedMessageKeysFilter("foo"),
+			src:            []byte(`not json`),
+			expErr:         true,
+			expGasConsumed: storetypes.Gas(len(`not json`)),
+		},
+		"allow message - single": {
+			filter: NewAcceptedMessagesFilter([]byte(`{}`)),
+			src:    []byte(`{}`),
+			exp:    true,
+		},
+		"allow message - multiple": {
+			filter: NewAcceptedMessagesFilter([]byte(`[{"foo":"bar"}]`), []byte(`{"other":"value"}`)),
+			src:    []byte(`[{"foo":"bar"}]`),
+			exp:    true,
+		},
+		"allow message - no match": {
+			filter: NewAcceptedMessagesFilter([]byte(`{"foo":"bar"}`)),
+			src:    []byte(`{"other":"value"}`),
+			exp:    false,
+		},
+		"undefined": {
+			filter: &UndefinedFilter{},
+			src:    []byte(`{}`),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gm := storetypes.NewGasMeter(1_000_000)
+			ctx := sdk.NewContext(nil, tmproto.Header{}, false, log.NewNopLogger()).WithGasMeter(gm)
+			allowed, gotErr := spec.filter.Accept(ctx, spec.src)
+
+			// then
+			assert.Equal(t, spec.expGasConsumed, gm.GasConsumed())
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, allowed)
+		})
+	}
+}
+
+func TestContractAuthzLimitValidate(t *testing.T) {
+	oneToken := sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)
+	specs := map[string]struct {
+		src    ContractAuthzLimitX
+		expErr bool
+	}{
+		"max calls": {
+			src: NewMaxCallsLimit(1),
+		},
+		"max calls - max uint64": {
+			src: NewMaxCallsLimit(math.MaxUint64),
+		},
+		"max calls - empty": {
+			src:    NewMaxCallsLimit(0),
+			expErr: true,
+		},
+		"max funds": {
+			src: NewMaxFundsLimit(oneToken),
+		},
+		"max funds - empty coins": {
+			src:    NewMaxFundsLimit(),
+			expErr: true,
+		},
+		"max funds - zero coin": {
+			src:    &MaxFundsLimit{Amounts: sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 0)}},
+			expErr: true,
+		},
+		"combined": {
+			src: NewCombinedLimit(1, oneToken),
+		},
+		"combined - empty calls": {
+			src:    NewCombinedLimit(0, oneToken),
+			expErr: true,
+		},
+		"combined - empty amounts": {
+			src:    NewCombinedLimit(1),
+			expErr: true,
+		},
+		"undefined": {
+			src:    &UndefinedLimit{},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestContractAuthzLimitAccept(t *testing.T) {
+	oneToken := sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)
+	otherToken := sdk.NewInt64Coin("other", 1)
+	specs := map[string]struct {
+		limit  ContractAuthzLimitX
+		src    AuthzableWasmMsg
+		exp    *ContractAuthzLimitAcceptResult
+		expErr bool
+	}{
+		"max calls - updated": {
+			limit: NewMaxCallsLimit(2),
+			src:   &MsgExecuteContract{},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxCallsLimit(1)},
+		},
+		"max calls - removed": {
+			limit: NewMaxCallsLimit(1),
+			src:   &MsgExecuteContract{},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"max calls - accepted with zero fund set": {
+			limit: NewMaxCallsLimit(1),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 0))},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"max calls - rejected with some fund transfer": {
+			limit: NewMaxCallsLimit(1),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"max calls - invalid": {
+			limit:  &MaxCallsLimit{},
+			src:    &MsgExecuteContract{},
+			expErr: true,
+		},
+		"max funds - single updated": {
+			limit: NewMaxFundsLimit(oneToken.Add(oneToken)),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxFundsLimit(oneToken)},
+		},
+		"max funds - single removed": {
+			limit: NewMaxFundsLimit(oneToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"max funds - single with unknown token": {
+			limit: NewMaxFundsLimit(oneToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(otherToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"max funds - single exceeds limit": {
+			limit: NewMaxFundsLimit(oneToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken.Add(oneToken))},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"max funds - multi with other left": {
+			limit: NewMaxFundsLimit(oneToken, otherToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxFundsLimit(otherToken)},
+		},
+		"max funds - without funds": {
+			limit: NewMaxFundsLimit(oneToken),
+			src:   &MsgExecuteContract{},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true},
+		},
+		"combined - updated": {
+			limit: NewCombinedLimit(2, oneToken.Add(oneToken)),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewCombinedLimit(1, oneToken)},
+		},
+		"combined - removed on last call": {
+			limit: NewCombinedLimit(1, oneToken.Add(oneToken)),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"combined - removed on last token": {
+			limit: NewCombinedLimit(2, oneToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"combined - exceeds limit": {
+			limit: NewCombinedLimit(2, oneToken),
+			src:   &MsgExecuteContract{Funds: sdk.NewCoins(oneToken.Add(oneToken))},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"combined - invalid calls": {
+			limit:  &CombinedLimit{Amounts: sdk.NewCoins(oneToken)},
+			src:    &MsgExecuteContract{},
+			expErr: true,
+		},
+		"undefined": {
+			limit:  &UndefinedLimit{},
+			src:    &MsgExecuteContract{},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(nil, tmproto.Header{}, false, log.NewNopLogger())
+			gotResult, gotErr := spec.limit.Accept(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, gotResult)
+		})
+	}
+}
+
+func TestAcceptGrantedMessage(t *testing.T) {
+	myContractAddr := sdk.AccAddress(randBytes(ContractAddrLen))
+	otherContractAddr := sdk.AccAddress(randBytes(ContractAddrLen))
+	senderAddr := sdk.AccAddress(randBytes(SDKAddrLen))
+	specs := map[string]struct {
+		auth      authztypes.Authorization
+		msg       sdk.Msg
+		expResult authztypes.AcceptResponse
+		expErr    bool
+	}{
+		"accepted and updated - contract execution": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(2), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept:  true,
+				Updated: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			},
+		},
+		"accepted and not updated - limit not touched": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxFundsLimit(sdk.NewInt64Coin("foo", 1)), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: true},
+		},
+		"accepted and removed - single": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: true, Delete: true},
+		},
+		"accepted and removed - multiple": {
+			auth: NewContractExecutionAuthorization(
+				mustGrant(otherContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter()),
+				mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter()),
+			),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept:  true,
+				Updated: NewContractExecutionAuthorization(mustGrant(otherContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			},
+		},
+		"accepted and updated - contract migration": {
+			auth: NewContractMigrationAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(2), NewAcceptedMessageKeysFilter("foo"))),
+			msg: &MsgMigrateContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				CodeID:   1,
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept:  true,
+				Updated: NewContractMigrationAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAcceptedMessageKeysFilter("foo"))),
+			},
+		},
+		"not accepted - no matching contract address": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: otherContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - max calls but tokens": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+				Funds:    sdk.NewCoins(sdk.NewInt64Coin("foo", 1)),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - message key not allowed": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAcceptedMessageKeysFilter("foo"))),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"bar":"baz"}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"error - wrong message type": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			msg: &MsgMigrateContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				CodeID:   1,
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expErr: true,
+		},
+		"error - invalid message": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`not json`),
+			},
+			expErr: true,
+		},
+		"error - undefined limit": {
+			auth: NewContractExecutionAuthorization(ContractGrant{Contract: myContractAddr.String()}),
+			msg: &MsgExecuteContract{
+				Sender:   senderAddr.String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(nil, tmproto.Header{}, false, log.NewNopLogger()).WithGasMeter(storetypes.NewInfiniteGasMeter())
+			gotResult, gotErr := spec.auth.Accept(ctx, spec.msg)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResult, gotResult)
+		})
+	}
+}
+
+func TestContractAuthorizationValidate(t *testing.T) {
+	validGrant := mustGrant(sdk.AccAddress(randBytes(ContractAddrLen)), NewMaxCallsLimit(1), NewAllowAllMessagesFilter())
+	specs := map[string]struct {
+		src    authztypes.Authorization
+		expErr bool
+	}{
+		"execution - valid": {
+			src: NewContractExecutionAuthorization(validGrant),
+		},
+		"execution - empty grants": {
+			src:    NewContractExecutionAuthorization(),
+			expErr: true,
+		},
+		"execution - invalid contract address": {
+			src:    NewContractExecutionAuthorization(ContractGrant{Contract: "invalid", Limit: validGrant.Limit, Filter: validGrant.Filter}),
+			expErr: true,
+		},
+		"execution - undefined filter": {
+			src:    NewContractExecutionAuthorization(ContractGrant{Contract: validGrant.Contract, Limit: validGrant.Limit}),
+			expErr: true,
+		},
+		"migration - valid": {
+			src: NewContractMigrationAuthorization(validGrant),
+		},
+		"migration - empty grants": {
+			src:    NewContractMigrationAuthorization(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func mustGrant(contract sdk.AccAddress, limit ContractAuthzLimitX, filter ContractAuthzFilterX) ContractGrant {
+	g, err := NewContractGrant(contract, limit, filter)
+	if err != nil {
+		panic(err)
+	}
+	return *g
+}
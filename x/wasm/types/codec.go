@@ -6,16 +6,27 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/gogoproto/proto"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 )
 
 // RegisterLegacyAminoCodec registers the account types and interface
 func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MsgStoreCode{}, "wasm/MsgStoreCode", nil)
 	cdc.RegisterConcrete(&MsgInstantiateContract{}, "wasm/MsgInstantiateContract", nil)
+	cdc.RegisterConcrete(&MsgInstantiateContract2{}, "wasm/MsgInstantiateContract2", nil)
 	cdc.RegisterConcrete(&MsgExecuteContract{}, "wasm/MsgExecuteContract", nil)
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgUpdateContractLabel{}, "wasm/MsgUpdateContractLabel", nil)
+	cdc.RegisterConcrete(&MsgUpdateInstantiateConfig{}, "wasm/MsgUpdateInstantiateConfig", nil)
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "wasm/MsgUpdateParams", nil)
+	cdc.RegisterConcrete(&MsgSudoContract{}, "wasm/MsgSudoContract", nil)
+	cdc.RegisterConcrete(&MsgPinCodes{}, "wasm/MsgPinCodes", nil)
+	cdc.RegisterConcrete(&MsgUnpinCodes{}, "wasm/MsgUnpinCodes", nil)
+	cdc.RegisterConcrete(&MsgRemoveCodes{}, "wasm/MsgRemoveCodes", nil)
+	cdc.RegisterConcrete(&MsgStoreAndInstantiateContract{}, "wasm/MsgStoreAndInstantiateContract", nil)
+	cdc.RegisterConcrete(&MsgStoreAndMigrateContract{}, "wasm/MsgStoreAndMigrateContract", nil)
 	cdc.RegisterConcrete(&PinCodesProposal{}, "wasm/PinCodesProposal", nil)
 	cdc.RegisterConcrete(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal", nil)
 
@@ -24,10 +35,25 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MigrateContractProposal{}, "wasm/MigrateContractProposal", nil)
 	cdc.RegisterConcrete(&UpdateAdminProposal{}, "wasm/UpdateAdminProposal", nil)
 	cdc.RegisterConcrete(&ClearAdminProposal{}, "wasm/ClearAdminProposal", nil)
+
+	cdc.RegisterInterface((*ContractAuthzLimitX)(nil), nil)
+	cdc.RegisterConcrete(&MaxCallsLimit{}, "wasm/MaxCallsLimit", nil)
+	cdc.RegisterConcrete(&MaxFundsLimit{}, "wasm/MaxFundsLimit", nil)
+	cdc.RegisterConcrete(&CombinedLimit{}, "wasm/CombinedLimit", nil)
+
+	cdc.RegisterInterface((*ContractAuthzFilterX)(nil), nil)
+	cdc.RegisterConcrete(&AllowAllMessagesFilter{}, "wasm/AllowAllMessagesFilter", nil)
+	cdc.RegisterConcrete(&AcceptedMessageKeysFilter{}, "wasm/AcceptedMessageKeysFilter", nil)
+	cdc.RegisterConcrete(&AcceptedMessagesFilter{}, "wasm/AcceptedMessagesFilter", nil)
+
+	cdc.RegisterConcrete(&ContractExecutionAuthorization{}, "wasm/ContractExecutionAuthorization", nil)
+	cdc.RegisterConcrete(&ContractMigrationAuthorization{}, "wasm/ContractMigrationAuthorization", nil)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
 	registry.RegisterInterface("ContractInfoExtension", (*ContractInfoExtension)(nil))
+	registry.RegisterInterface("cosmwasm.wasm.v1.ContractAuthzLimitX", (*ContractAuthzLimitX)(nil))
+	registry.RegisterInterface("cosmwasm.wasm.v1.ContractAuthzFilterX", (*ContractAuthzFilterX)(nil))
 
 	// SDK 0.50: Register message implementations with explicit type URLs
 	// Use a type assertion to access the concrete registry's RegisterCustomTypeURL method
@@ -45,12 +71,31 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 	
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgStoreCode", &MsgStoreCode{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgInstantiateContract", &MsgInstantiateContract{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgInstantiateContract2", &MsgInstantiateContract2{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgExecuteContract", &MsgExecuteContract{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgMigrateContract", &MsgMigrateContract{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgUpdateAdmin", &MsgUpdateAdmin{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgClearAdmin", &MsgClearAdmin{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgUpdateContractLabel", &MsgUpdateContractLabel{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgUpdateInstantiateConfig", &MsgUpdateInstantiateConfig{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgUpdateParams", &MsgUpdateParams{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgSudoContract", &MsgSudoContract{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgPinCodes", &MsgPinCodes{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgUnpinCodes", &MsgUnpinCodes{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgRemoveCodes", &MsgRemoveCodes{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgStoreAndInstantiateContract", &MsgStoreAndInstantiateContract{})
+	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgStoreAndMigrateContract", &MsgStoreAndMigrateContract{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgIBCCloseChannel", &MsgIBCCloseChannel{})
 	cr.RegisterCustomTypeURL((*sdk.Msg)(nil), "/cosmwasm.wasm.v1.MsgIBCSend", &MsgIBCSend{})
+
+	cr.RegisterCustomTypeURL((*ContractAuthzLimitX)(nil), "/cosmwasm.wasm.v1.MaxCallsLimit", &MaxCallsLimit{})
+	cr.RegisterCustomTypeURL((*ContractAuthzLimitX)(nil), "/cosmwasm.wasm.v1.MaxFundsLimit", &MaxFundsLimit{})
+	cr.RegisterCustomTypeURL((*ContractAuthzLimitX)(nil), "/cosmwasm.wasm.v1.CombinedLimit", &CombinedLimit{})
+	cr.RegisterCustomTypeURL((*ContractAuthzFilterX)(nil), "/cosmwasm.wasm.v1.AllowAllMessagesFilter", &AllowAllMessagesFilter{})
+	cr.RegisterCustomTypeURL((*ContractAuthzFilterX)(nil), "/cosmwasm.wasm.v1.AcceptedMessageKeysFilter", &AcceptedMessageKeysFilter{})
+	cr.RegisterCustomTypeURL((*ContractAuthzFilterX)(nil), "/cosmwasm.wasm.v1.AcceptedMessagesFilter", &AcceptedMessagesFilter{})
+	cr.RegisterCustomTypeURL((*authz.Authorization)(nil), "/cosmwasm.wasm.v1.ContractExecutionAuthorization", &ContractExecutionAuthorization{})
+	cr.RegisterCustomTypeURL((*authz.Authorization)(nil), "/cosmwasm.wasm.v1.ContractMigrationAuthorization", &ContractMigrationAuthorization{})
 }
 
 var (
@@ -0,0 +1,51 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// LatestErrorDetailsVersion is the most recent version of the error details registry
+const LatestErrorDetailsVersion uint32 = 1
+
+// errorDetailsKey identifies a registered error by its codespace and code
+type errorDetailsKey struct {
+	codespace string
+	code      uint32
+}
+
+func newErrorDetailsKey(err *errorsmod.Error) errorDetailsKey {
+	return errorDetailsKey{codespace: err.Codespace(), code: err.ABCICode()}
+}
+
+// errorDetails are the stable messages of well known errors by registry version. They are
+// passed to contracts and become part of the state, so that a released version must never
+// be modified. Changes require a new version that is activated with the params.
+var errorDetails = map[uint32]map[errorDetailsKey]string{
+	1: {
+		newErrorDetailsKey(sdkerrors.ErrUnauthorized):      "unauthorized",
+		newErrorDetailsKey(sdkerrors.ErrInsufficientFunds): "insufficient funds",
+		newErrorDetailsKey(sdkerrors.ErrUnknownRequest):    "unknown request",
+		newErrorDetailsKey(sdkerrors.ErrInvalidAddress):    "invalid address",
+		newErrorDetailsKey(sdkerrors.ErrInvalidCoins):      "invalid coins",
+		newErrorDetailsKey(sdkerrors.ErrOutOfGas):          "out of gas",
+		newErrorDetailsKey(sdkerrors.ErrKeyNotFound):       "key not found",
+		newErrorDetailsKey(sdkerrors.ErrNotFound):          "not found",
+		newErrorDetailsKey(ErrNotFound):                    "not found",
+		newErrorDetailsKey(ErrLimit):                       "exceeds limit",
+	},
+}
+
+// ErrorDetails returns the stable message for the error code in the given registry version.
+// Version 0 has no messages.
+func ErrorDetails(version uint32, codespace string, code uint32) (string, bool) {
+	msg, ok := errorDetails[version][errorDetailsKey{codespace: codespace, code: code}]
+	return msg, ok
+}
+
+func validateErrorDetailsVersion(version uint32) error {
+	if version > LatestErrorDetailsVersion {
+		return errorsmod.Wrapf(ErrInvalid, "unknown version: %d", version)
+	}
+	return nil
+}
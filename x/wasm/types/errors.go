@@ -72,6 +72,9 @@ var (
 
 	//  error if an address does not belong to a contract (just for registration)
 	_ = errors.Register(DefaultCodespace, 22, "no such contract")
+
+	// ErrCodeInUse error if a code that is referenced by contracts should be removed
+	ErrCodeInUse = errors.Register(DefaultCodespace, 23, "code in use")
 )
 
 type ErrNoSuchContract struct {
@@ -6,23 +6,38 @@ const (
 	// CustomContractEventPrefix contracts can create custom events. To not mix them with other system events they got the `wasm-` prefix.
 	CustomContractEventPrefix = "wasm-"
 
-	EventTypeStoreCode         = "store_code"
-	EventTypeInstantiate       = "instantiate"
-	EventTypeExecute           = "execute"
-	EventTypeMigrate           = "migrate"
-	EventTypePinCode           = "pin_code"
-	EventTypeUnpinCode         = "unpin_code"
-	EventTypeSudo              = "sudo"
-	EventTypeReply             = "reply"
-	EventTypeGovContractResult = "gov_contract_result"
+	EventTypeStoreCode              = "store_code"
+	EventTypeInstantiate            = "instantiate"
+	EventTypeExecute                = "execute"
+	EventTypeMigrate                = "migrate"
+	EventTypePinCode                = "pin_code"
+	EventTypeUnpinCode              = "unpin_code"
+	EventTypeRemoveCode             = "remove_code"
+	EventTypeUpdateCodeAccessConfig = "update_code_access_config"
+	EventTypeUpdateContractLabel    = "update_contract_label"
+	EventTypeSudo                   = "sudo"
+	EventTypeReply                  = "reply"
+	EventTypeGovContractResult      = "gov_contract_result"
+	EventTypePacketRecv             = "ibc_packet_received"
+	EventTypeIBCCallback            = "ibc_callback"
 )
 
 // event attributes returned from contract execution
 const (
 	AttributeReservedPrefix = "_"
 
-	AttributeKeyContractAddr  = "_contract_address"
-	AttributeKeyCodeID        = "code_id"
-	AttributeKeyResultDataHex = "result"
-	AttributeKeyFeature       = "feature"
+	AttributeKeyContractAddr        = "_contract_address"
+	AttributeKeyCodeID              = "code_id"
+	AttributeKeyChecksum            = "checksum"
+	AttributeKeyNewLabel            = "new_label"
+	AttributeKeyResultDataHex       = "result"
+	AttributeKeyFeature             = "feature"
+	AttributeKeyCodePermission      = "code_permission"
+	AttributeKeyAuthorizedAddresses = "authorized_addresses"
+	AttributeKeyAckSuccess          = "success"
+	AttributeKeyAckAsync            = "async_ack"
+	AttributeKeyAckError            = "error"
+	AttributeKeyCallbackType        = "callback_type"
+	AttributeKeyCallbackSuccess     = "success"
+	AttributeKeyCallbackError       = "error"
 )
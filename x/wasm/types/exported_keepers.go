@@ -3,6 +3,7 @@ package types
 import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 )
 
 // ViewKeeper provides read only operations
@@ -14,11 +15,15 @@ type ViewKeeper interface {
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *ContractInfo
 	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, ContractInfo) bool)
 	IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+	IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool)
+	IterateContractsByLabel(ctx sdk.Context, label string, cb func(address sdk.AccAddress) bool)
 	IterateContractState(ctx sdk.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool)
 	GetCodeInfo(ctx sdk.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, CodeInfo) bool)
+	IterateCodesByChecksum(ctx sdk.Context, checksum []byte, cb func(codeID uint64) bool)
 	GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error)
 	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
+	GetGasSchedule(ctx sdk.Context) GasSchedule
 }
 
 // ContractOpsKeeper contains mutable operations on a contract.
@@ -29,6 +34,9 @@ type ContractOpsKeeper interface {
 	// Instantiate creates an instance of a WASM contract
 	Instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error)
 
+	// Instantiate2 creates an instance of a WASM contract using the predictable address generator
+	Instantiate2(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, salt []byte, fixMsg bool) (sdk.AccAddress, []byte, error)
+
 	// Execute executes the contract instance
 	Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 
@@ -44,12 +52,21 @@ type ContractOpsKeeper interface {
 	// ClearContractAdmin sets the admin value on the ContractInfo to nil, to disable further migrations/ updates.
 	ClearContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress) error
 
+	// UpdateContractLabel sets a new label on the ContractInfo. The caller must be the contract admin.
+	UpdateContractLabel(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newLabel string) error
+
+	// SetAccessConfig updates the access config of a code id.
+	SetAccessConfig(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, newConfig AccessConfig) error
+
 	// PinCode pins the wasm contract in wasmvm cache
 	PinCode(ctx sdk.Context, codeID uint64) error
 
 	// UnpinCode removes the wasm contract from wasmvm cache
 	UnpinCode(ctx sdk.Context, codeID uint64) error
 
+	// RemoveCode deletes a code without contract instances from the store and the wasmvm cache
+	RemoveCode(ctx sdk.Context, codeID uint64) error
+
 	// SetContractInfoExtension updates the extension point data that is stored with the contract info
 	SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra ContractInfoExtension) error
 }
@@ -71,11 +88,13 @@ type IBCContractKeeper interface {
 		contractAddr sdk.AccAddress,
 		msg wasmvmtypes.IBCChannelCloseMsg,
 	) error
+	// OnRecvPacket returns the acknowledgement for the packet. A nil ack without error
+	// means that the contract writes the acknowledgement asynchronously.
 	OnRecvPacket(
 		ctx sdk.Context,
 		contractAddr sdk.AccAddress,
 		msg wasmvmtypes.IBCPacketReceiveMsg,
-	) ([]byte, error)
+	) (ibcexported.Acknowledgement, error)
 	OnAckPacket(
 		ctx sdk.Context,
 		contractAddr sdk.AccAddress,
@@ -87,3 +106,21 @@ type IBCContractKeeper interface {
 		msg wasmvmtypes.IBCPacketTimeoutMsg,
 	) error
 }
+
+// ICAControllerCallbackKeeper notifies contracts about the lifecycle of the
+// interchain accounts they own via sudo
+type ICAControllerCallbackKeeper interface {
+	HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool
+	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
+}
+
+// ICS721Keeper handles the packets of the ICS-721 nft-transfer port with the contract
+// configured in the params and stores the traces of the received NFTs
+type ICS721Keeper interface {
+	IBCContractKeeper
+	GetParams(ctx sdk.Context) Params
+	SetClassTrace(ctx sdk.Context, trace ClassTrace)
+	GetClassTrace(ctx sdk.Context, fullClassID string) (ClassTrace, bool)
+	SetTokenTrace(ctx sdk.Context, trace TokenTrace)
+	DeleteTokenTrace(ctx sdk.Context, classID, tokenID string)
+}
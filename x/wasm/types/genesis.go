@@ -1,6 +1,9 @@
 package types
 
 import (
+	"crypto/sha256"
+	"path/filepath"
+
 	"cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -36,6 +39,36 @@ func (s GenesisState) ValidateBasic() error {
 			return errors.Wrapf(err, "gen message: %d", i)
 		}
 	}
+	for i := range s.ClassTraces {
+		if err := s.ClassTraces[i].ValidateBasic(); err != nil {
+			return errors.Wrapf(err, "class trace: %d", i)
+		}
+	}
+	for i := range s.TokenTraces {
+		if err := s.TokenTraces[i].ValidateBasic(); err != nil {
+			return errors.Wrapf(err, "token trace: %d", i)
+		}
+	}
+	if s.Stream != nil {
+		if err := s.Stream.ValidateBasic(); err != nil {
+			return errors.Wrap(err, "stream")
+		}
+	}
+	return nil
+}
+
+// ValidateBasic performs a basic validation of the stream reference. The records in the
+// file are validated one by one on import.
+func (s GenesisStream) ValidateBasic() error {
+	if s.File == "" {
+		return errors.Wrap(ErrEmpty, "file")
+	}
+	if s.File != filepath.Base(s.File) || s.File == "." || s.File == ".." {
+		return errors.Wrap(ErrInvalid, "file must be a file name without path")
+	}
+	if len(s.SHA256) != sha256.Size {
+		return errors.Wrapf(ErrInvalid, "sha256: expected %d bytes", sha256.Size)
+	}
 	return nil
 }
 
@@ -60,8 +93,12 @@ func (c Contract) ValidateBasic() error {
 		return errors.Wrap(err, "contract info")
 	}
 
-	if c.ContractInfo.Created != nil {
-		return errors.Wrap(ErrInvalid, "created must be empty")
+	if len(c.ContractCodeHistory) == 0 {
+		if c.ContractInfo.Created != nil {
+			return errors.Wrap(ErrInvalid, "created must be empty")
+		}
+	} else if err := c.validateCodeHistory(); err != nil {
+		return errors.Wrap(err, "contract code history")
 	}
 	for i := range c.ContractState {
 		if err := c.ContractState[i].ValidateBasic(); err != nil {
@@ -71,6 +108,40 @@ func (c Contract) ValidateBasic() error {
 	return nil
 }
 
+// validateCodeHistory ensures that the history starts with the creation of the contract, is
+// ordered by position and ends with the current code id.
+func (c Contract) validateCodeHistory() error {
+	created := c.ContractInfo.Created
+	if created == nil {
+		return errors.Wrap(ErrEmpty, "created")
+	}
+	for i, e := range c.ContractCodeHistory {
+		if e.CodeID == 0 {
+			return errors.Wrapf(ErrEmpty, "code id in entry %d", i)
+		}
+		if e.Updated == nil {
+			return errors.Wrapf(ErrEmpty, "updated in entry %d", i)
+		}
+		switch {
+		case i == 0:
+			if e.Operation != ContractCodeHistoryOperationTypeInit && e.Operation != ContractCodeHistoryOperationTypeGenesis {
+				return errors.Wrapf(ErrInvalid, "first entry must be init or genesis, got: %s", e.Operation)
+			}
+			if *e.Updated != *created {
+				return errors.Wrap(ErrInvalid, "first entry must match the created position")
+			}
+		case e.Operation != ContractCodeHistoryOperationTypeMigrate:
+			return errors.Wrapf(ErrInvalid, "entry %d must be a migration, got: %s", i, e.Operation)
+		case e.Updated.LessThan(c.ContractCodeHistory[i-1].Updated):
+			return errors.Wrapf(ErrInvalid, "entry %d is before the previous entry", i)
+		}
+	}
+	if last := c.ContractCodeHistory[len(c.ContractCodeHistory)-1]; last.CodeID != c.ContractInfo.CodeID {
+		return errors.Wrapf(ErrInvalid, "last entry code id %d does not match contract code id %d", last.CodeID, c.ContractInfo.CodeID)
+	}
+	return nil
+}
+
 // AsMsg returns the underlying cosmos-sdk message instance. Null when can not be mapped to a known type.
 func (m GenesisState_GenMsgs) AsMsg() sdk.Msg {
 	if msg := m.GetStoreCode(); msg != nil {
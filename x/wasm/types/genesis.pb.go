@@ -25,11 +25,16 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // GenesisState - genesis state of x/wasm
 type GenesisState struct {
-	Params    Params                 `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
-	Codes     []Code                 `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
-	Contracts []Contract             `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
-	Sequences []Sequence             `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
-	GenMsgs   []GenesisState_GenMsgs `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	Params      Params                 `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Codes       []Code                 `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	Contracts   []Contract             `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Sequences   []Sequence             `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	GenMsgs     []GenesisState_GenMsgs `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	ClassTraces []ClassTrace           `protobuf:"bytes,6,rep,name=class_traces,json=classTraces,proto3" json:"class_traces,omitempty"`
+	TokenTraces []TokenTrace           `protobuf:"bytes,7,rep,name=token_traces,json=tokenTraces,proto3" json:"token_traces,omitempty"`
+	// stream references the sidecar file with the codes and contracts when the
+	// state was exported in streaming mode
+	Stream *GenesisStream `protobuf:"bytes,8,opt,name=stream,proto3" json:"stream,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -100,6 +105,27 @@ func (m *GenesisState) GetGenMsgs() []GenesisState_GenMsgs {
 	return nil
 }
 
+func (m *GenesisState) GetClassTraces() []ClassTrace {
+	if m != nil {
+		return m.ClassTraces
+	}
+	return nil
+}
+
+func (m *GenesisState) GetTokenTraces() []TokenTrace {
+	if m != nil {
+		return m.TokenTraces
+	}
+	return nil
+}
+
+func (m *GenesisState) GetStream() *GenesisStream {
+	if m != nil {
+		return m.Stream
+	}
+	return nil
+}
+
 // GenMsgs define the messages that can be executed during genesis phase in
 // order. The intention is to have more human readable data that is auditable.
 type GenesisState_GenMsgs struct {
@@ -202,6 +228,81 @@ func (*GenesisState_GenMsgs) XXX_OneofWrappers() []interface{} {
 	}
 }
 
+// GenesisStream references a sidecar file with length delimited records of the
+// codes, the contracts and their state. The file is resolved in the node's
+// config directory and verified against the checksum on import.
+type GenesisStream struct {
+	// file is the name of the sidecar file
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// sha256 is the checksum of the file content
+	SHA256 []byte `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// codes is the number of code records in the file
+	Codes uint64 `protobuf:"varint,3,opt,name=codes,proto3" json:"codes,omitempty"`
+	// contracts is the number of contract records in the file
+	Contracts uint64 `protobuf:"varint,4,opt,name=contracts,proto3" json:"contracts,omitempty"`
+}
+
+func (m *GenesisStream) Reset()         { *m = GenesisStream{} }
+func (m *GenesisStream) String() string { return proto.CompactTextString(m) }
+func (*GenesisStream) ProtoMessage()    {}
+func (*GenesisStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab3f539b23472a6, []int{1}
+}
+func (m *GenesisStream) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GenesisStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GenesisStream.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GenesisStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenesisStream.Merge(m, src)
+}
+func (m *GenesisStream) XXX_Size() int {
+	return m.Size()
+}
+func (m *GenesisStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenesisStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenesisStream proto.InternalMessageInfo
+
+func (m *GenesisStream) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *GenesisStream) GetSHA256() []byte {
+	if m != nil {
+		return m.SHA256
+	}
+	return nil
+}
+
+func (m *GenesisStream) GetCodes() uint64 {
+	if m != nil {
+		return m.Codes
+	}
+	return 0
+}
+
+func (m *GenesisStream) GetContracts() uint64 {
+	if m != nil {
+		return m.Contracts
+	}
+	return 0
+}
+
 // Code struct encompasses CodeInfo and CodeBytes
 type Code struct {
 	CodeID    uint64   `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
@@ -215,7 +316,7 @@ func (m *Code) Reset()         { *m = Code{} }
 func (m *Code) String() string { return proto.CompactTextString(m) }
 func (*Code) ProtoMessage()    {}
 func (*Code) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab3f539b23472a6, []int{1}
+	return fileDescriptor_2ab3f539b23472a6, []int{2}
 }
 func (m *Code) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,13 +378,17 @@ type Contract struct {
 	ContractAddress string       `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo    ContractInfo `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState   []Model      `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
+	// contract_code_history is the full code history of the contract. It is
+	// exported in the full history mode only. The contract info keeps its
+	// created position when set.
+	ContractCodeHistory []ContractCodeHistoryEntry `protobuf:"bytes,4,rep,name=contract_code_history,json=contractCodeHistory,proto3" json:"contract_code_history"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
 func (m *Contract) String() string { return proto.CompactTextString(m) }
 func (*Contract) ProtoMessage()    {}
 func (*Contract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab3f539b23472a6, []int{2}
+	return fileDescriptor_2ab3f539b23472a6, []int{3}
 }
 func (m *Contract) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -333,6 +438,13 @@ func (m *Contract) GetContractState() []Model {
 	return nil
 }
 
+func (m *Contract) GetContractCodeHistory() []ContractCodeHistoryEntry {
+	if m != nil {
+		return m.ContractCodeHistory
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -343,7 +455,7 @@ func (m *Sequence) Reset()         { *m = Sequence{} }
 func (m *Sequence) String() string { return proto.CompactTextString(m) }
 func (*Sequence) ProtoMessage()    {}
 func (*Sequence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab3f539b23472a6, []int{3}
+	return fileDescriptor_2ab3f539b23472a6, []int{4}
 }
 func (m *Sequence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -389,6 +501,7 @@ func (m *Sequence) GetValue() uint64 {
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "cosmwasm.wasm.v1.GenesisState")
 	proto.RegisterType((*GenesisState_GenMsgs)(nil), "cosmwasm.wasm.v1.GenesisState.GenMsgs")
+	proto.RegisterType((*GenesisStream)(nil), "cosmwasm.wasm.v1.GenesisStream")
 	proto.RegisterType((*Code)(nil), "cosmwasm.wasm.v1.Code")
 	proto.RegisterType((*Contract)(nil), "cosmwasm.wasm.v1.Contract")
 	proto.RegisterType((*Sequence)(nil), "cosmwasm.wasm.v1.Sequence")
@@ -397,48 +510,58 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 646 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0xe3, 0x26, 0x4e, 0x93, 0x69, 0x7e, 0xbf, 0x56, 0xdb, 0xaa, 0x35, 0x06, 0x9c, 0x28,
-	0xa0, 0x2a, 0x48, 0x28, 0x51, 0x8b, 0xc4, 0x0d, 0x01, 0x6e, 0x2b, 0x6a, 0x55, 0x95, 0xc0, 0x15,
-	0x42, 0x42, 0xaa, 0x22, 0xd7, 0xde, 0x1a, 0x8b, 0xda, 0x1b, 0xb2, 0x9b, 0x52, 0x9f, 0x79, 0x01,
-	0x1e, 0x01, 0x5e, 0x06, 0xf5, 0xd8, 0x23, 0xa7, 0x08, 0xa5, 0x37, 0x9e, 0x02, 0xed, 0x1f, 0xbb,
-	0x06, 0xa7, 0x17, 0x2b, 0x3b, 0xf3, 0x9d, 0xcf, 0xfc, 0xc9, 0xec, 0x82, 0xe5, 0x13, 0x1a, 0x7f,
-	0xf6, 0x68, 0x3c, 0x10, 0x9f, 0xf3, 0xad, 0x41, 0x88, 0x13, 0x4c, 0x23, 0xda, 0x1f, 0x8d, 0x09,
-	0x23, 0x68, 0x25, 0xf3, 0xf7, 0xc5, 0xe7, 0x7c, 0xcb, 0x5c, 0x0b, 0x49, 0x48, 0x84, 0x73, 0xc0,
-	0x7f, 0x49, 0x9d, 0x79, 0xaf, 0xc4, 0x61, 0xe9, 0x08, 0x2b, 0x8a, 0x79, 0xa7, 0xec, 0xbd, 0x90,
-	0xae, 0xee, 0x37, 0x1d, 0x5a, 0xaf, 0x64, 0xca, 0x23, 0xe6, 0x31, 0x8c, 0x9e, 0x42, 0x7d, 0xe4,
-	0x8d, 0xbd, 0x98, 0x1a, 0x5a, 0x47, 0xeb, 0x2d, 0x6d, 0x1b, 0xfd, 0x7f, 0x4b, 0xe8, 0xbf, 0x16,
-	0x7e, 0xbb, 0x76, 0x39, 0x6d, 0x57, 0x5c, 0xa5, 0x46, 0x7b, 0xa0, 0xfb, 0x24, 0xc0, 0xd4, 0x58,
-	0xe8, 0x54, 0x7b, 0x4b, 0xdb, 0xeb, 0xe5, 0xb0, 0x1d, 0x12, 0x60, 0x7b, 0x83, 0x07, 0xfd, 0x9e,
-	0xb6, 0x97, 0x85, 0xf8, 0x31, 0x89, 0x23, 0x86, 0xe3, 0x11, 0x4b, 0x5d, 0x19, 0x8d, 0xde, 0x42,
-	0xd3, 0x27, 0x09, 0x1b, 0x7b, 0x3e, 0xa3, 0x46, 0x55, 0xa0, 0xcc, 0x79, 0x28, 0x29, 0xb1, 0xef,
-	0x2a, 0xdc, 0x6a, 0x1e, 0x54, 0x40, 0xde, 0x90, 0x38, 0x96, 0xe2, 0x4f, 0x13, 0x9c, 0xf8, 0x98,
-	0x1a, 0xb5, 0xdb, 0xb0, 0x47, 0x4a, 0x72, 0x83, 0xcd, 0x83, 0x8a, 0xd8, 0xdc, 0x88, 0x8e, 0xa1,
-	0x11, 0xe2, 0x64, 0x18, 0xd3, 0x90, 0x1a, 0xba, 0xa0, 0x6e, 0x96, 0xa9, 0xc5, 0xf1, 0xf2, 0xc3,
-	0x21, 0x0d, 0xa9, 0x6d, 0xaa, 0x0c, 0x28, 0x8b, 0x2f, 0x24, 0x58, 0x0c, 0xa5, 0xc8, 0xfc, 0xb2,
-	0x00, 0x8b, 0x2a, 0x00, 0x3d, 0x07, 0xa0, 0x8c, 0x8c, 0xf1, 0x90, 0xcf, 0x49, 0xfd, 0x37, 0x56,
-	0x39, 0xd9, 0x21, 0x0d, 0x8f, 0xb8, 0x8c, 0x0f, 0x7b, 0xbf, 0xe2, 0x36, 0x69, 0x76, 0x40, 0xc7,
-	0xb0, 0x16, 0x25, 0x94, 0x79, 0x09, 0x8b, 0x3c, 0xc6, 0x31, 0x72, 0x36, 0xc6, 0x82, 0x40, 0xf5,
-	0xe6, 0xa2, 0x9c, 0x9b, 0x80, 0x6c, 0xe4, 0xfb, 0x15, 0x77, 0x35, 0x2a, 0x9b, 0xd1, 0x1b, 0x58,
-	0xc1, 0x17, 0xd8, 0x9f, 0x14, 0xd1, 0x55, 0x81, 0x7e, 0x38, 0x17, 0xbd, 0x27, 0xc5, 0x05, 0xec,
-	0x32, 0xfe, 0xdb, 0x64, 0xeb, 0x50, 0xa5, 0x93, 0xb8, 0xfb, 0x5d, 0x83, 0x9a, 0xe8, 0xe0, 0x01,
-	0x2c, 0xf2, 0xe6, 0x87, 0x51, 0x20, 0xfa, 0xaf, 0xd9, 0x30, 0x9b, 0xb6, 0xeb, 0xdc, 0xe5, 0xec,
-	0xba, 0x75, 0xee, 0x72, 0x02, 0xf4, 0x8c, 0x2f, 0x10, 0x17, 0x25, 0xa7, 0x44, 0xf5, 0x66, 0xce,
-	0xdf, 0x45, 0x27, 0x39, 0x25, 0x6a, 0x89, 0x1b, 0xbe, 0x3a, 0xa3, 0xfb, 0x00, 0x22, 0xfc, 0x24,
-	0x65, 0x98, 0x8a, 0x06, 0x5a, 0xae, 0x00, 0xda, 0xdc, 0x80, 0xd6, 0xa1, 0x3e, 0x8a, 0x92, 0x04,
-	0x07, 0x46, 0xad, 0xa3, 0xf5, 0x1a, 0xae, 0x3a, 0x75, 0x7f, 0x68, 0xd0, 0xc8, 0x47, 0xf1, 0x08,
-	0x56, 0xb2, 0x11, 0x0c, 0xbd, 0x20, 0x18, 0x63, 0x2a, 0x2f, 0x53, 0xd3, 0x5d, 0xce, 0xec, 0x2f,
-	0xa5, 0x19, 0x39, 0xf0, 0x5f, 0x2e, 0x2d, 0x54, 0x6c, 0xdd, 0xbe, 0xf2, 0x85, 0xaa, 0x5b, 0x7e,
-	0xc1, 0x86, 0x76, 0xe1, 0xff, 0x1c, 0x45, 0xf9, 0xae, 0xa9, 0xeb, 0xb3, 0x31, 0x67, 0xfc, 0x24,
-	0xc0, 0x67, 0x0a, 0x92, 0xe7, 0x17, 0xfb, 0xd9, 0xb5, 0xa1, 0x91, 0xdd, 0x02, 0xd4, 0x81, 0x7a,
-	0x14, 0x0c, 0x3f, 0xe2, 0x54, 0x54, 0xdf, 0xb2, 0x9b, 0xb3, 0x69, 0x5b, 0x77, 0x76, 0x0f, 0x70,
-	0xea, 0xea, 0x51, 0x70, 0x80, 0x53, 0xb4, 0x06, 0xfa, 0xb9, 0x77, 0x36, 0xc1, 0xa2, 0xec, 0x9a,
-	0x2b, 0x0f, 0xf6, 0x8b, 0xcb, 0x99, 0xa5, 0x5d, 0xcd, 0x2c, 0xed, 0xd7, 0xcc, 0xd2, 0xbe, 0x5e,
-	0x5b, 0x95, 0xab, 0x6b, 0xab, 0xf2, 0xf3, 0xda, 0xaa, 0xbc, 0xdf, 0x0c, 0x23, 0xf6, 0x61, 0x72,
-	0xd2, 0xf7, 0x49, 0x3c, 0xd8, 0x21, 0x34, 0x7e, 0x97, 0xbd, 0x49, 0xc1, 0xe0, 0x42, 0xbe, 0x4d,
-	0xe2, 0xd9, 0x3a, 0xa9, 0x8b, 0xc7, 0xe9, 0xc9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x05,
-	0x87, 0xde, 0x1f, 0x05, 0x00, 0x00,
+	// 815 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x95, 0xcd, 0x4e, 0xeb, 0x46,
+	0x14, 0xc7, 0xf3, 0xe1, 0x38, 0xc9, 0x10, 0x4a, 0x34, 0xa4, 0xe0, 0xa6, 0xd4, 0x41, 0x69, 0x85,
+	0x68, 0x55, 0x25, 0x22, 0x15, 0xec, 0xaa, 0x16, 0x03, 0x2a, 0x11, 0x42, 0x6a, 0x1d, 0xaa, 0x4a,
+	0x95, 0x50, 0x64, 0xec, 0xc1, 0x58, 0xc4, 0x9e, 0x34, 0x33, 0xa1, 0x58, 0x5d, 0xf6, 0x05, 0xee,
+	0x2b, 0xdc, 0xfd, 0xdd, 0xdc, 0xb7, 0x60, 0xc9, 0xf2, 0xae, 0xa2, 0xab, 0xb0, 0xe3, 0x29, 0xae,
+	0xe6, 0xc3, 0xce, 0x40, 0x92, 0xbb, 0x89, 0x32, 0xe7, 0xfc, 0xcf, 0xef, 0x9c, 0x9c, 0x9c, 0x39,
+	0x03, 0x4c, 0x17, 0x93, 0xf0, 0x5f, 0x87, 0x84, 0x6d, 0xfe, 0x71, 0xb7, 0xd7, 0xf6, 0x51, 0x84,
+	0x48, 0x40, 0x5a, 0xc3, 0x11, 0xa6, 0x18, 0x56, 0x13, 0x7f, 0x8b, 0x7f, 0xdc, 0xed, 0xd5, 0x6b,
+	0x3e, 0xf6, 0x31, 0x77, 0xb6, 0xd9, 0x37, 0xa1, 0xab, 0x6f, 0xcd, 0x71, 0x68, 0x3c, 0x44, 0x92,
+	0x52, 0xff, 0x6a, 0xde, 0x7b, 0x2f, 0x5c, 0xcd, 0xf7, 0x45, 0x50, 0xf9, 0x4d, 0xa4, 0xec, 0x51,
+	0x87, 0x22, 0x78, 0x00, 0xf4, 0xa1, 0x33, 0x72, 0x42, 0x62, 0x64, 0xb7, 0xb3, 0xbb, 0x2b, 0x1d,
+	0xa3, 0xf5, 0xba, 0x84, 0xd6, 0xef, 0xdc, 0x6f, 0x69, 0x0f, 0x93, 0x46, 0xc6, 0x96, 0x6a, 0x78,
+	0x02, 0x0a, 0x2e, 0xf6, 0x10, 0x31, 0x72, 0xdb, 0xf9, 0xdd, 0x95, 0xce, 0xc6, 0x7c, 0xd8, 0x11,
+	0xf6, 0x90, 0xb5, 0xc9, 0x82, 0x9e, 0x27, 0x8d, 0x35, 0x2e, 0xfe, 0x11, 0x87, 0x01, 0x45, 0xe1,
+	0x90, 0xc6, 0xb6, 0x88, 0x86, 0x7f, 0x82, 0xb2, 0x8b, 0x23, 0x3a, 0x72, 0x5c, 0x4a, 0x8c, 0x3c,
+	0x47, 0xd5, 0x17, 0xa1, 0x84, 0xc4, 0xfa, 0x5a, 0xe2, 0xd6, 0xd3, 0x20, 0x05, 0x39, 0x23, 0x31,
+	0x2c, 0x41, 0xff, 0x8c, 0x51, 0xe4, 0x22, 0x62, 0x68, 0xcb, 0xb0, 0x3d, 0x29, 0x99, 0x61, 0xd3,
+	0x20, 0x15, 0x9b, 0x1a, 0xe1, 0x25, 0x28, 0xf9, 0x28, 0xea, 0x87, 0xc4, 0x27, 0x46, 0x81, 0x53,
+	0x77, 0xe6, 0xa9, 0x6a, 0x7b, 0xd9, 0xe1, 0x9c, 0xf8, 0xc4, 0xaa, 0xcb, 0x0c, 0x30, 0x89, 0x57,
+	0x12, 0x14, 0x7d, 0x21, 0x82, 0x7d, 0x50, 0x71, 0x07, 0x0e, 0x21, 0x7d, 0xf6, 0x2b, 0x10, 0x31,
+	0x74, 0x9e, 0x62, 0x6b, 0x41, 0x3f, 0x98, 0xea, 0x82, 0x89, 0x2c, 0x53, 0x82, 0x37, 0xd4, 0x48,
+	0x05, 0xbe, 0xe2, 0xa6, 0x5a, 0x9e, 0x80, 0xe2, 0x5b, 0x14, 0x25, 0x09, 0x8a, 0xcb, 0x12, 0x5c,
+	0x30, 0xd5, 0xab, 0x04, 0x6a, 0xa4, 0x9a, 0x80, 0xa6, 0x5a, 0x02, 0xcf, 0x81, 0x4e, 0xe8, 0x08,
+	0x39, 0xa1, 0x51, 0xe2, 0xd3, 0xd4, 0xf8, 0x4c, 0x7b, 0x98, 0xcc, 0xaa, 0x3d, 0x4f, 0x1a, 0x55,
+	0x11, 0xa2, 0x30, 0x25, 0xa4, 0xfe, 0x7f, 0x0e, 0x14, 0x65, 0x07, 0xe1, 0x2f, 0x00, 0x10, 0x8a,
+	0x47, 0xa8, 0xcf, 0x06, 0x47, 0x0e, 0xab, 0x39, 0x8f, 0x3f, 0x27, 0x7e, 0x8f, 0xc9, 0xd8, 0xf4,
+	0x9d, 0x66, 0xec, 0x32, 0x49, 0x0e, 0xf0, 0x12, 0xd4, 0x82, 0x88, 0x50, 0x27, 0xa2, 0x81, 0x43,
+	0x19, 0x46, 0x0c, 0x8b, 0x91, 0xe3, 0xa8, 0xdd, 0x85, 0xa8, 0xee, 0x2c, 0x20, 0x99, 0xc1, 0xd3,
+	0x8c, 0xbd, 0x1e, 0xcc, 0x9b, 0xe1, 0x1f, 0xa0, 0x8a, 0xee, 0x91, 0x3b, 0x56, 0xd1, 0x79, 0x8e,
+	0xfe, 0x6e, 0x21, 0xfa, 0x44, 0x88, 0x15, 0xec, 0x1a, 0x7a, 0x69, 0xb2, 0x0a, 0x20, 0x4f, 0xc6,
+	0x61, 0xf3, 0x3f, 0xb0, 0xfa, 0xa2, 0x69, 0x10, 0x02, 0xed, 0x3a, 0x18, 0x88, 0x26, 0x94, 0x6d,
+	0xfe, 0x1d, 0x36, 0x81, 0x4e, 0x6e, 0x9c, 0xce, 0xfe, 0x01, 0xff, 0x3d, 0x15, 0x0b, 0x4c, 0x27,
+	0x0d, 0xbd, 0x77, 0x7a, 0xd8, 0xd9, 0x3f, 0xb0, 0xa5, 0x07, 0xd6, 0x92, 0x3b, 0xcb, 0xea, 0xd2,
+	0x92, 0x2b, 0xb8, 0xa5, 0x5e, 0x41, 0x8d, 0x7b, 0x66, 0x86, 0xe6, 0xdb, 0x2c, 0xd0, 0x78, 0xfb,
+	0xbe, 0x05, 0x45, 0xa6, 0xef, 0x07, 0x1e, 0xcf, 0xab, 0x89, 0x0c, 0xcc, 0xd5, 0x3d, 0xb6, 0x75,
+	0xe6, 0xea, 0x7a, 0xf0, 0x67, 0xc6, 0x62, 0xa2, 0xe8, 0x1a, 0xcb, 0xc6, 0xd6, 0x17, 0x6f, 0x86,
+	0x6e, 0x74, 0x8d, 0xe5, 0x4a, 0x29, 0xb9, 0xf2, 0x0c, 0xbf, 0x01, 0x80, 0x87, 0x5f, 0xc5, 0x54,
+	0x56, 0x59, 0xb1, 0x39, 0xd0, 0x62, 0x06, 0xb8, 0x01, 0xf4, 0x61, 0x10, 0x45, 0xc8, 0xe3, 0x65,
+	0x96, 0x6c, 0x79, 0x6a, 0xbe, 0xcb, 0x81, 0x52, 0xfa, 0x3f, 0x7c, 0x0f, 0xaa, 0x49, 0xf5, 0x7d,
+	0xc7, 0xf3, 0x46, 0x88, 0x10, 0xd9, 0xa8, 0xb5, 0xc4, 0x7e, 0x28, 0xcc, 0xb0, 0x0b, 0x56, 0x53,
+	0xa9, 0x52, 0xb1, 0xb9, 0x7c, 0x01, 0x29, 0x55, 0x57, 0x5c, 0xc5, 0x06, 0x8f, 0xc1, 0x17, 0x29,
+	0x8a, 0xb0, 0x9b, 0x2f, 0x97, 0xd9, 0xe6, 0x82, 0xff, 0x1e, 0x7b, 0x68, 0x20, 0x21, 0x69, 0x7e,
+	0xb1, 0x8c, 0x3d, 0xf0, 0x65, 0x4a, 0xe1, 0x8d, 0xb8, 0x09, 0xd8, 0xfc, 0xc6, 0x72, 0x85, 0xfd,
+	0xb0, 0xbc, 0x30, 0x3e, 0xee, 0x42, 0x7c, 0x12, 0xd1, 0x51, 0x2c, 0xf9, 0xe9, 0x9e, 0x54, 0xfc,
+	0x4d, 0x0b, 0x94, 0x92, 0xcd, 0x07, 0xb7, 0x81, 0x1e, 0x78, 0xfd, 0x5b, 0x14, 0xf3, 0x1e, 0x55,
+	0xac, 0xf2, 0x74, 0xd2, 0x28, 0x74, 0x8f, 0xcf, 0x50, 0x6c, 0x17, 0x02, 0xef, 0x0c, 0xc5, 0x6c,
+	0x68, 0xee, 0x9c, 0xc1, 0x18, 0xf1, 0xe6, 0x68, 0xb6, 0x38, 0x58, 0xbf, 0x3e, 0x4c, 0xcd, 0xec,
+	0xe3, 0xd4, 0xcc, 0x7e, 0x9c, 0x9a, 0xd9, 0x37, 0x4f, 0x66, 0xe6, 0xf1, 0xc9, 0xcc, 0x7c, 0x78,
+	0x32, 0x33, 0x7f, 0xef, 0xf8, 0x01, 0xbd, 0x19, 0x5f, 0xb5, 0x5c, 0x1c, 0xb6, 0x8f, 0x30, 0x09,
+	0xff, 0x4a, 0xde, 0x21, 0xaf, 0x7d, 0x2f, 0xde, 0x23, 0xfe, 0x54, 0x5d, 0xe9, 0xfc, 0x41, 0xfa,
+	0xe9, 0x53, 0x00, 0x00, 0x00, 0xff, 0xff, 0x56, 0xfc, 0x28, 0xfa, 0x13, 0x07, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -461,6 +584,46 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Stream != nil {
+		{
+			size, err := m.Stream.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.TokenTraces) > 0 {
+		for iNdEx := len(m.TokenTraces) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TokenTraces[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.ClassTraces) > 0 {
+		for iNdEx := len(m.ClassTraces) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ClassTraces[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.GenMsgs) > 0 {
 		for iNdEx := len(m.GenMsgs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -625,6 +788,53 @@ func (m *GenesisState_GenMsgs_ExecuteContract) MarshalToSizedBuffer(dAtA []byte)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *GenesisStream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenesisStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenesisStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Contracts != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Contracts))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Codes != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Codes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.SHA256) > 0 {
+		i -= len(m.SHA256)
+		copy(dAtA[i:], m.SHA256)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.SHA256)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.File) > 0 {
+		i -= len(m.File)
+		copy(dAtA[i:], m.File)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.File)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Code) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -700,6 +910,20 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ContractCodeHistory) > 0 {
+		for iNdEx := len(m.ContractCodeHistory) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ContractCodeHistory[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.ContractState) > 0 {
 		for iNdEx := len(m.ContractState) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -812,6 +1036,22 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.ClassTraces) > 0 {
+		for _, e := range m.ClassTraces {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.TokenTraces) > 0 {
+		for _, e := range m.TokenTraces {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.Stream != nil {
+		l = m.Stream.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
 	return n
 }
 
@@ -863,6 +1103,29 @@ func (m *GenesisState_GenMsgs_ExecuteContract) Size() (n int) {
 	}
 	return n
 }
+func (m *GenesisStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.File)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.SHA256)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.Codes != 0 {
+		n += 1 + sovGenesis(uint64(m.Codes))
+	}
+	if m.Contracts != 0 {
+		n += 1 + sovGenesis(uint64(m.Contracts))
+	}
+	return n
+}
+
 func (m *Code) Size() (n int) {
 	if m == nil {
 		return 0
@@ -902,6 +1165,12 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.ContractCodeHistory) > 0 {
+		for _, e := range m.ContractCodeHistory {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1125,6 +1394,110 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClassTraces", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClassTraces = append(m.ClassTraces, ClassTrace{})
+			if err := m.ClassTraces[len(m.ClassTraces)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenTraces", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenTraces = append(m.TokenTraces, TokenTrace{})
+			if err := m.TokenTraces[len(m.TokenTraces)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stream == nil {
+				m.Stream = &GenesisStream{}
+			}
+			if err := m.Stream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1301,6 +1674,160 @@ func (m *GenesisState_GenMsgs) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GenesisStream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenesisStream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenesisStream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.File = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SHA256", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SHA256 = append(m.SHA256[:0], dAtA[iNdEx:postIndex]...)
+			if m.SHA256 == nil {
+				m.SHA256 = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codes", wireType)
+			}
+			m.Codes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Codes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contracts", wireType)
+			}
+			m.Contracts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Contracts |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Code) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1585,6 +2112,40 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractCodeHistory", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractCodeHistory = append(m.ContractCodeHistory, ContractCodeHistoryEntry{})
+			if err := m.ContractCodeHistory[len(m.ContractCodeHistory)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
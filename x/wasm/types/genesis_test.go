@@ -57,6 +57,41 @@ func TestValidateGenesisState(t *testing.T) {
 			},
 			expError: true,
 		},
+		"class trace invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.ClassTraces = []ClassTrace{{Path: "nft-transfer", BaseClassID: "myClass"}}
+			},
+			expError: true,
+		},
+		"token trace invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.TokenTraces = []TokenTrace{{ClassID: "nft-transfer/channel-0/myClass"}}
+			},
+			expError: true,
+		},
+		"stream valid": {
+			srcMutator: func(s *GenesisState) {
+				s.Stream = &GenesisStream{File: "wasm.stream", SHA256: bytes.Repeat([]byte{1}, 32), Codes: 1, Contracts: 1}
+			},
+		},
+		"stream file empty": {
+			srcMutator: func(s *GenesisState) {
+				s.Stream = &GenesisStream{SHA256: bytes.Repeat([]byte{1}, 32)}
+			},
+			expError: true,
+		},
+		"stream file with path": {
+			srcMutator: func(s *GenesisState) {
+				s.Stream = &GenesisStream{File: "../wasm.stream", SHA256: bytes.Repeat([]byte{1}, 32)}
+			},
+			expError: true,
+		},
+		"stream checksum invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.Stream = &GenesisStream{File: "wasm.stream", SHA256: []byte{1}}
+			},
+			expError: true,
+		},
 		"genesis invalid message type": {
 			srcMutator: func(s *GenesisState) {
 				s.GenMsgs[0].Sum = nil
@@ -157,6 +192,71 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"contract with code history": {
+			srcMutator: withCodeHistoryFixture,
+		},
+		"contract with genesis code history": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[0].Operation = ContractCodeHistoryOperationTypeGenesis
+			},
+		},
+		"code history without created": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractInfo.Created = nil
+			},
+			expError: true,
+		},
+		"code history not starting with created": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractInfo.Created = &AbsoluteTxPosition{BlockHeight: 2}
+			},
+			expError: true,
+		},
+		"code history not starting with init": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[0].Operation = ContractCodeHistoryOperationTypeMigrate
+			},
+			expError: true,
+		},
+		"code history with init after first entry": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[1].Operation = ContractCodeHistoryOperationTypeInit
+			},
+			expError: true,
+		},
+		"code history out of order": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[1].Updated = &AbsoluteTxPosition{BlockHeight: 1, TxIndex: 0}
+			},
+			expError: true,
+		},
+		"code history entry without position": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[1].Updated = nil
+			},
+			expError: true,
+		},
+		"code history entry without code id": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractCodeHistory[0].CodeID = 0
+			},
+			expError: true,
+		},
+		"code history not ending with current code": {
+			srcMutator: func(c *Contract) {
+				withCodeHistoryFixture(c)
+				c.ContractInfo.CodeID = 3
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -170,3 +270,12 @@ func TestContractValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func withCodeHistoryFixture(c *Contract) {
+	c.ContractInfo.Created = &AbsoluteTxPosition{BlockHeight: 1, TxIndex: 1}
+	c.ContractInfo.CodeID = 2
+	c.ContractCodeHistory = []ContractCodeHistoryEntry{
+		{Operation: ContractCodeHistoryOperationTypeInit, CodeID: 1, Updated: &AbsoluteTxPosition{BlockHeight: 1, TxIndex: 1}, Msg: []byte(`{}`)},
+		{Operation: ContractCodeHistoryOperationTypeMigrate, CodeID: 2, Updated: &AbsoluteTxPosition{BlockHeight: 1, TxIndex: 1}, Msg: []byte(`{}`)},
+	}
+}
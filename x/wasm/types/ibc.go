@@ -0,0 +1,52 @@
+package types
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var _ ibcexported.Acknowledgement = ContractConfirmStateAck{}
+
+// ContractConfirmStateAck is a success acknowledgement with the raw contract data.
+// The state changes of the contract are committed when this ack is returned.
+type ContractConfirmStateAck []byte
+
+func (w ContractConfirmStateAck) Success() bool {
+	return true // always commit state
+}
+
+func (w ContractConfirmStateAck) Acknowledgement() []byte {
+	return w
+}
+
+// EmitAcknowledgementEvent emits an event that signals the outcome of a contract's packet receive.
+// A nil ack without error means the contract will write the acknowledgement asynchronously.
+func EmitAcknowledgementEvent(ctx sdk.Context, contractAddr sdk.AccAddress, ack ibcexported.Acknowledgement, err error) {
+	success := err == nil && (ack == nil || ack.Success())
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		sdk.NewAttribute(AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(AttributeKeyAckSuccess, strconv.FormatBool(success)),
+		sdk.NewAttribute(AttributeKeyAckAsync, strconv.FormatBool(err == nil && ack == nil)),
+	}
+	if err != nil {
+		attributes = append(attributes, sdk.NewAttribute(AttributeKeyAckError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypePacketRecv, attributes...))
+}
+
+// EmitIBCCallbackEvent emits an event with the result of a contract callback for a packet of another IBC app.
+func EmitIBCCallbackEvent(ctx sdk.Context, callbackType string, contractAddr sdk.AccAddress, err error) {
+	attributes := []sdk.Attribute{
+		sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+		sdk.NewAttribute(AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(AttributeKeyCallbackType, callbackType),
+		sdk.NewAttribute(AttributeKeyCallbackSuccess, strconv.FormatBool(err == nil)),
+	}
+	if err != nil {
+		attributes = append(attributes, sdk.NewAttribute(AttributeKeyCallbackError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeIBCCallback, attributes...))
+}
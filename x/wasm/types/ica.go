@@ -0,0 +1,54 @@
+package types
+
+import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+)
+
+// ICASudoMsg is sent via sudo to a contract that owns an interchain account on a
+// counterparty chain to notify it about the channel handshake and the results of
+// the txs it submitted with MsgSendTx.
+type ICASudoMsg struct {
+	ICA ICACallback `json:"ica"`
+}
+
+// ICACallback contains exactly one of the interchain account events
+type ICACallback struct {
+	// OpenAck is sent when the channel for the interchain account was opened
+	OpenAck *ICAOpenAck `json:"open_ack,omitempty"`
+	// Response is sent when the host chain executed the tx successfully
+	Response *ICAResponse `json:"response,omitempty"`
+	// Error is sent when the host chain failed to execute the tx
+	Error *ICAError `json:"error,omitempty"`
+	// Timeout is sent when the packet timed out. The channel is closed then and the
+	// account must be registered again to send more txs.
+	Timeout *ICATimeout `json:"timeout,omitempty"`
+}
+
+// ICAOpenAck contains the channel details and the address of the new interchain account
+type ICAOpenAck struct {
+	PortID                string `json:"port_id"`
+	ChannelID             string `json:"channel_id"`
+	CounterpartyChannelID string `json:"counterparty_channel_id"`
+	CounterpartyVersion   string `json:"counterparty_version"`
+	// Address of the interchain account on the host chain. Empty when it can not be
+	// read from the version metadata.
+	Address string `json:"address"`
+}
+
+// ICAResponse contains the original packet and the proto encoded tx result from the host chain
+type ICAResponse struct {
+	Packet wasmvmtypes.IBCPacket `json:"packet"`
+	// Data is the proto encoded `cosmos.base.abci.v1beta1.TxMsgData` with the msg responses
+	Data []byte `json:"data"`
+}
+
+// ICAError contains the original packet and the error returned by the host chain
+type ICAError struct {
+	Packet  wasmvmtypes.IBCPacket `json:"packet"`
+	Details string                `json:"details"`
+}
+
+// ICATimeout contains the original packet that timed out
+type ICATimeout struct {
+	Packet wasmvmtypes.IBCPacket `json:"packet"`
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+)
+
+const (
+	// ICS721PortID is the port of the ICS-721 nft-transfer app. The packets are handled by
+	// the contract configured in the params.
+	ICS721PortID = "nft-transfer"
+	// ICS721Version is the channel version of the ICS-721 nft-transfer app
+	ICS721Version = "ics721-1"
+	// ICS721RouteKey is the IBC router key and capability scope of the nft-transfer port
+	ICS721RouteKey = "ics721"
+	// MaxClassIDLength is the maximum length of a full class id. The class id is length prefixed
+	// in the store keys of the token traces.
+	MaxClassIDLength = 255
+)
+
+// NonFungibleTokenPacketData is the ICS-721 packet data. Only the fields required to trace the
+// classes and tokens are decoded, the packet is validated by the contract.
+type NonFungibleTokenPacketData struct {
+	ClassID  string   `json:"classId"`
+	TokenIDs []string `json:"tokenIds"`
+	Sender   string   `json:"sender"`
+	Receiver string   `json:"receiver"`
+}
+
+// ParseNonFungibleTokenPacketData decodes the ICS-721 packet data
+func ParseNonFungibleTokenPacketData(bz []byte) (NonFungibleTokenPacketData, error) {
+	var data NonFungibleTokenPacketData
+	if err := json.Unmarshal(bz, &data); err != nil {
+		return data, errorsmod.Wrap(ErrInvalid, err.Error())
+	}
+	if strings.TrimSpace(data.ClassID) == "" {
+		return data, errorsmod.Wrap(ErrEmpty, "class id")
+	}
+	if len(data.TokenIDs) == 0 {
+		return data, errorsmod.Wrap(ErrEmpty, "token ids")
+	}
+	return data, nil
+}
+
+// ParseClassTrace parses a full class id into the trace path and the base class id. The path
+// is made of the leading `{port}/{channel}` pairs.
+func ParseClassTrace(fullClassID string) ClassTrace {
+	parts := strings.Split(fullClassID, "/")
+	i := 0
+	for ; i+2 < len(parts); i += 2 {
+		if !channeltypes.IsValidChannelID(parts[i+1]) {
+			break
+		}
+	}
+	return ClassTrace{
+		Path:        strings.Join(parts[:i], "/"),
+		BaseClassID: strings.Join(parts[i:], "/"),
+	}
+}
+
+// GetFullClassID returns the class id on this chain including the trace path
+func (t ClassTrace) GetFullClassID() string {
+	if t.Path == "" {
+		return t.BaseClassID
+	}
+	return t.Path + "/" + t.BaseClassID
+}
+
+// ValidateBasic performs a basic validation of the class trace
+func (t ClassTrace) ValidateBasic() error {
+	if strings.TrimSpace(t.BaseClassID) == "" {
+		return errorsmod.Wrap(ErrEmpty, "base class id")
+	}
+	if err := ValidateClassIDLength(t.GetFullClassID()); err != nil {
+		return err
+	}
+	if t.Path == "" {
+		return nil
+	}
+	parts := strings.Split(t.Path, "/")
+	if len(parts)%2 != 0 {
+		return errorsmod.Wrapf(ErrInvalid, "path %q: expected port/channel pairs", t.Path)
+	}
+	for i := 0; i < len(parts); i += 2 {
+		if err := host.PortIdentifierValidator(parts[i]); err != nil {
+			return errorsmod.Wrapf(ErrInvalid, "path %q: port: %s", t.Path, err)
+		}
+		if !channeltypes.IsValidChannelID(parts[i+1]) {
+			return errorsmod.Wrapf(ErrInvalid, "path %q: channel: %s", t.Path, parts[i+1])
+		}
+	}
+	return nil
+}
+
+// ValidateBasic performs a basic validation of the token trace
+func (t TokenTrace) ValidateBasic() error {
+	if strings.TrimSpace(t.ClassID) == "" {
+		return errorsmod.Wrap(ErrEmpty, "class id")
+	}
+	if err := ValidateClassIDLength(t.ClassID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(t.TokenID) == "" {
+		return errorsmod.Wrap(ErrEmpty, "token id")
+	}
+	return nil
+}
+
+// ValidateClassIDLength returns an error when the full class id is longer than MaxClassIDLength
+func ValidateClassIDLength(classID string) error {
+	if len(classID) > MaxClassIDLength {
+		return errorsmod.Wrapf(ErrLimit, "class id length %d, max %d", len(classID), MaxClassIDLength)
+	}
+	return nil
+}
+
+// ReceiverChainIsSource returns true when the class was sent from this chain over the given
+// source port and channel before, so that the packet returns it to the origin chain.
+func ReceiverChainIsSource(sourcePort, sourceChannel, classID string) bool {
+	return strings.HasPrefix(classID, sourcePort+"/"+sourceChannel+"/")
+}
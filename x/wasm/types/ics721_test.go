@@ -0,0 +1,132 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClassTrace(t *testing.T) {
+	specs := map[string]struct {
+		src string
+		exp ClassTrace
+	}{
+		"native class": {
+			src: "myClass",
+			exp: ClassTrace{BaseClassID: "myClass"},
+		},
+		"single hop": {
+			src: "nft-transfer/channel-0/myClass",
+			exp: ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "myClass"},
+		},
+		"multiple hops": {
+			src: "nft-transfer/channel-1/nft-transfer/channel-0/myClass",
+			exp: ClassTrace{Path: "nft-transfer/channel-1/nft-transfer/channel-0", BaseClassID: "myClass"},
+		},
+		"base class with slashes": {
+			src: "nft-transfer/channel-0/my/class",
+			exp: ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "my/class"},
+		},
+		"base class looks like a hop": {
+			src: "nft-transfer/channel-0",
+			exp: ClassTrace{BaseClassID: "nft-transfer/channel-0"},
+		},
+		"no channel id": {
+			src: "nft-transfer/other/myClass",
+			exp: ClassTrace{BaseClassID: "nft-transfer/other/myClass"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := ParseClassTrace(spec.src)
+			assert.Equal(t, spec.exp, got)
+			assert.Equal(t, spec.src, got.GetFullClassID())
+			require.NoError(t, got.ValidateBasic())
+		})
+	}
+}
+
+func TestClassTraceValidateBasic(t *testing.T) {
+	specs := map[string]struct {
+		src    ClassTrace
+		expErr bool
+	}{
+		"all good": {
+			src: ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: "myClass"},
+		},
+		"all good without path": {
+			src: ClassTrace{BaseClassID: "myClass"},
+		},
+		"empty base class": {
+			src:    ClassTrace{Path: "nft-transfer/channel-0"},
+			expErr: true,
+		},
+		"incomplete hop": {
+			src:    ClassTrace{Path: "nft-transfer", BaseClassID: "myClass"},
+			expErr: true,
+		},
+		"invalid channel": {
+			src:    ClassTrace{Path: "nft-transfer/foo", BaseClassID: "myClass"},
+			expErr: true,
+		},
+		"invalid port": {
+			src:    ClassTrace{Path: "a/channel-0", BaseClassID: "myClass"},
+			expErr: true,
+		},
+		"max class id length": {
+			src: ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: strings.Repeat("a", MaxClassIDLength-len("nft-transfer/channel-0/"))},
+		},
+		"class id too long": {
+			src:    ClassTrace{Path: "nft-transfer/channel-0", BaseClassID: strings.Repeat("a", MaxClassIDLength-len("nft-transfer/channel-0/")+1)},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestParseNonFungibleTokenPacketData(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		exp    NonFungibleTokenPacketData
+		expErr bool
+	}{
+		"all good": {
+			src: `{"classId":"myClass","classUri":"https://example.com","tokenIds":["t1","t2"],"sender":"a","receiver":"b"}`,
+			exp: NonFungibleTokenPacketData{ClassID: "myClass", TokenIDs: []string{"t1", "t2"}, Sender: "a", Receiver: "b"},
+		},
+		"empty class": {
+			src:    `{"tokenIds":["t1"]}`,
+			expErr: true,
+		},
+		"empty tokens": {
+			src:    `{"classId":"myClass"}`,
+			expErr: true,
+		},
+		"not json": {
+			src:    `foo`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseNonFungibleTokenPacketData([]byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -31,6 +32,14 @@ var (
 	ContractByCodeIDAndCreatedSecondaryIndexPrefix = []byte{0x06}
 	PinnedCodeIndexPrefix                          = []byte{0x07}
 	TXCounterPrefix                                = []byte{0x08}
+	ContractsByCreatorPrefix                       = []byte{0x09}
+	CodeByChecksumSecondaryIndexPrefix             = []byte{0x0a}
+	AsyncAckPacketPrefix                           = []byte{0x0b}
+	ClassTracePrefix                               = []byte{0x0c}
+	TokenTracePrefix                               = []byte{0x0d}
+	CodeRemovalQueuePrefix                         = []byte{0x0e}
+	ContractsByLabelPrefix                         = []byte{0x0f}
+	ParamsKey                                      = []byte{0x10}
 
 	KeyLastCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
 	KeyLastInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
@@ -75,6 +84,60 @@ func GetContractByCodeIDSecondaryIndexPrefix(codeID uint64) []byte {
 	return r
 }
 
+// GetContractsByCreatorPrefix returns the prefix for the contracts-by-creator secondary index: `<prefix><len(creatorAddr)><creatorAddr>`
+func GetContractsByCreatorPrefix(creatorAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(creatorAddr)
+	return append(ContractsByCreatorPrefix, bz...)
+}
+
+// GetContractByCreatorSecondaryIndexKey returns the key for the secondary index:
+// `<prefix><len(creatorAddr)><creatorAddr><created><contractAddr>`
+func GetContractByCreatorSecondaryIndexKey(creatorAddr sdk.AccAddress, created *AbsoluteTxPosition, contractAddr sdk.AccAddress) []byte {
+	prefix := GetContractsByCreatorPrefix(creatorAddr)
+	prefixLen := len(prefix)
+	contractAddrLen := len(contractAddr)
+	r := make([]byte, prefixLen+AbsoluteTxPositionLen+contractAddrLen)
+	copy(r[0:], prefix)
+	copy(r[prefixLen:], created.Bytes())
+	copy(r[prefixLen+AbsoluteTxPositionLen:], contractAddr)
+	return r
+}
+
+// GetContractsByLabelPrefix returns the prefix for the contracts-by-label secondary index: `<prefix><len(label)><label>`
+func GetContractsByLabelPrefix(label string) []byte {
+	bz := address.MustLengthPrefix([]byte(label))
+	return append(ContractsByLabelPrefix, bz...)
+}
+
+// GetContractByLabelSecondaryIndexKey returns the key for the secondary index: `<prefix><len(label)><label><contractAddr>`
+func GetContractByLabelSecondaryIndexKey(label string, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractsByLabelPrefix(label), contractAddr...)
+}
+
+// GetCodeByChecksumSecondaryIndexPrefix returns the prefix for the code-by-checksum secondary index: `<prefix><checksum>`
+func GetCodeByChecksumSecondaryIndexPrefix(checksum []byte) []byte {
+	prefixLen := len(CodeByChecksumSecondaryIndexPrefix)
+	r := make([]byte, prefixLen+len(checksum))
+	copy(r[0:], CodeByChecksumSecondaryIndexPrefix)
+	copy(r[prefixLen:], checksum)
+	return r
+}
+
+// GetCodeByChecksumSecondaryIndexKey returns the key for the secondary index: `<prefix><checksum><codeID>`
+func GetCodeByChecksumSecondaryIndexKey(checksum []byte, codeID uint64) []byte {
+	prefix := GetCodeByChecksumSecondaryIndexPrefix(checksum)
+	prefixLen := len(prefix)
+	r := make([]byte, prefixLen+8)
+	copy(r[0:], prefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(codeID))
+	return r
+}
+
+// GetCodeRemovalQueueKey returns the key of a checksum to remove from the wasmvm cache, the value is the block height to remove it at: `<prefix><checksum>`
+func GetCodeRemovalQueueKey(checksum []byte) []byte {
+	return append(append([]byte{}, CodeRemovalQueuePrefix...), checksum...)
+}
+
 // GetContractCodeHistoryElementKey returns the key a contract code history entry: `<prefix><contractAddr><position>`
 func GetContractCodeHistoryElementKey(contractAddr sdk.AccAddress, pos uint64) []byte {
 	prefix := GetContractCodeHistoryElementPrefix(contractAddr)
@@ -108,3 +171,31 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
 }
+
+// GetAsyncAckPacketKey returns the key for a received packet that waits for an async acknowledgement:
+// `<prefix><len(portID)><portID><len(channelID)><channelID><sequence>`
+func GetAsyncAckPacketKey(portID, channelID string, sequence uint64) []byte {
+	portBz := address.MustLengthPrefix([]byte(portID))
+	channelBz := address.MustLengthPrefix([]byte(channelID))
+	r := make([]byte, 0, len(AsyncAckPacketPrefix)+len(portBz)+len(channelBz)+8)
+	r = append(r, AsyncAckPacketPrefix...)
+	r = append(r, portBz...)
+	r = append(r, channelBz...)
+	return append(r, sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// GetClassTraceKey returns the key of an ICS-721 class trace: `<prefix><fullClassID>`
+func GetClassTraceKey(fullClassID string) []byte {
+	return append(append([]byte{}, ClassTracePrefix...), fullClassID...)
+}
+
+// GetTokenTracePrefix returns the prefix of the ICS-721 token traces for a class: `<prefix><len(classID)><classID>`.
+// It panics when the class id is longer than MaxClassIDLength.
+func GetTokenTracePrefix(classID string) []byte {
+	return append(append([]byte{}, TokenTracePrefix...), address.MustLengthPrefix([]byte(classID))...)
+}
+
+// GetTokenTraceKey returns the key of an ICS-721 token trace: `<prefix><len(classID)><classID><tokenID>`
+func GetTokenTraceKey(classID, tokenID string) []byte {
+	return append(GetTokenTracePrefix(classID), tokenID...)
+}
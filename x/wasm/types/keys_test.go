@@ -80,3 +80,69 @@ func TestGetContractByCreatedSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestGetContractByCreatorSecondaryIndexKey(t *testing.T) {
+	creator := bytes.Repeat([]byte{2}, 20)
+	pos := &AbsoluteTxPosition{2 + 1<<(8*7), 3 + 1<<(8*7)}
+
+	// test that contract addresses of 20 length are still supported
+	addr := bytes.Repeat([]byte{4}, 20)
+	got := GetContractByCreatorSecondaryIndexKey(creator, pos, addr)
+	exp := []byte{9, // prefix
+		20,                           // creator address length
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, // creator address 20 bytes
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+	}
+	assert.Equal(t, exp, got)
+
+	addr = bytes.Repeat([]byte{4}, ContractAddrLen)
+	got = GetContractByCreatorSecondaryIndexKey(creator, pos, addr)
+	exp = []byte{9, // prefix
+		20,                           // creator address length
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, // creator address 20 bytes
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // address 32 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		4, 4,
+	}
+	assert.Equal(t, exp, got)
+}
+
+func TestGetCodeByChecksumSecondaryIndexKey(t *testing.T) {
+	checksum := bytes.Repeat([]byte{3}, ChecksumLen)
+	got := GetCodeByChecksumSecondaryIndexKey(checksum, 1<<(8*7)+1)
+	exp := []byte{10, // prefix
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3, // checksum 32 bytes
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+		3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+		3, 3,
+		1, 0, 0, 0, 0, 0, 0, 1, // codeID
+	}
+	assert.Equal(t, exp, got)
+}
+
+func TestGetAsyncAckPacketKey(t *testing.T) {
+	got := GetAsyncAckPacketKey("port", "ch-1", 1+1<<(8*7))
+	exp := []byte{0x0b, // prefix
+		4, 'p', 'o', 'r', 't', // port
+		4, 'c', 'h', '-', '1', // channel
+		1, 0, 0, 0, 0, 0, 0, 1, // sequence
+	}
+	assert.Equal(t, exp, got)
+}
+
+func TestGetTokenTraceKey(t *testing.T) {
+	got := GetTokenTraceKey("p/c/cl", "t1")
+	exp := []byte{0x0d, // prefix
+		6, 'p', '/', 'c', '/', 'c', 'l', // class id
+		't', '1', // token id
+	}
+	assert.Equal(t, exp, got)
+}
@@ -3,11 +3,12 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 
 	"cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
-	"github.com/gogo/protobuf/jsonpb"
+	"github.com/cosmos/gogoproto/jsonpb"
 	pkgerrors "github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
@@ -25,19 +26,34 @@ var AllAccessTypes = []AccessType{
 	AccessTypeNobody,
 	AccessTypeOnlyAddress,
 	AccessTypeEverybody,
+	AccessTypeAnyOfAddresses,
 }
 
-func (a AccessType) With(addr sdk.AccAddress) AccessConfig {
+// With creates a new AccessConfig instance for the access type. Addresses are only used by
+// AccessTypeOnlyAddress (exactly one) and AccessTypeAnyOfAddresses (at least one).
+func (a AccessType) With(addrs ...sdk.AccAddress) AccessConfig {
 	switch a {
 	case AccessTypeNobody:
 		return AllowNobody
 	case AccessTypeOnlyAddress:
-		if err := sdk.VerifyAddressFormat(addr); err != nil {
+		if n := len(addrs); n != 1 {
+			panic(fmt.Sprintf("expected exactly 1 address but got %d", n))
+		}
+		if err := sdk.VerifyAddressFormat(addrs[0]); err != nil {
 			panic(err)
 		}
-		return AccessConfig{Permission: AccessTypeOnlyAddress, Address: addr.String()}
+		return AccessConfig{Permission: AccessTypeOnlyAddress, Address: addrs[0].String()}
 	case AccessTypeEverybody:
 		return AllowEverybody
+	case AccessTypeAnyOfAddresses:
+		bech32Addrs := make([]string, len(addrs))
+		for i, v := range addrs {
+			bech32Addrs[i] = v.String()
+		}
+		if err := validateBech32Addresses(bech32Addrs); err != nil {
+			panic(err)
+		}
+		return AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: bech32Addrs}
 	}
 	panic("unsupported access type")
 }
@@ -50,6 +66,8 @@ func (a AccessType) String() string {
 		return "OnlyAddress"
 	case AccessTypeEverybody:
 		return "Everybody"
+	case AccessTypeAnyOfAddresses:
+		return "AnyOfAddresses"
 	}
 	return "Unspecified"
 }
@@ -77,7 +95,15 @@ func (a *AccessType) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, data []byte) error {
 }
 
 func (a AccessConfig) Equals(o AccessConfig) bool {
-	return a.Permission == o.Permission && a.Address == o.Address
+	if a.Permission != o.Permission || a.Address != o.Address || len(a.Addresses) != len(o.Addresses) {
+		return false
+	}
+	for i, v := range a.Addresses {
+		if v != o.Addresses[i] {
+			return false
+		}
+	}
+	return true
 }
 
 var (
@@ -87,6 +113,9 @@ var (
 )
 
 // ParamKeyTable returns the parameter key table.
+//
+// Deprecated: params are stored in the wasm module store. The key table is only kept to
+// read the legacy x/params subspace during the store migration.
 func ParamKeyTable() paramtypes.KeyTable {
 	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
 }
@@ -97,6 +126,7 @@ func DefaultParams() Params {
 		CodeUploadAccess:             AllowEverybody,
 		InstantiateDefaultPermission: AccessTypeEverybody,
 		MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+		ErrorDetailsVersion:          LatestErrorDetailsVersion,
 	}
 }
 
@@ -109,10 +139,12 @@ func (p Params) String() string {
 }
 
 // ParamSetPairs returns the parameter set pairs.
+//
+// Deprecated: only used for the migration from the legacy x/params subspace.
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
-		paramtypes.NewParamSetPair(ParamStoreKeyUploadAccess, &p.CodeUploadAccess, validateAccessConfig),
-		paramtypes.NewParamSetPair(ParamStoreKeyInstantiateAccess, &p.InstantiateDefaultPermission, validateAccessType),
+		paramtypes.NewParamSetPair(ParamStoreKeyUploadAccess, &p.CodeUploadAccess, validateLegacyAccessConfig),
+		paramtypes.NewParamSetPair(ParamStoreKeyInstantiateAccess, &p.InstantiateDefaultPermission, validateLegacyAccessType),
 		paramtypes.NewParamSetPair(ParamStoreKeyMaxWasmCodeSize, &p.MaxWasmCodeSize, validateMaxWasmCodeSize),
 	}
 }
@@ -128,6 +160,38 @@ func (p Params) ValidateBasic() error {
 	if err := validateMaxWasmCodeSize(p.MaxWasmCodeSize); err != nil {
 		return pkgerrors.Wrap(err, "max wasm code size")
 	}
+	if p.ICS721Contract != "" {
+		if _, err := sdk.AccAddressFromBech32(p.ICS721Contract); err != nil {
+			return pkgerrors.Wrap(err, "ics721 contract")
+		}
+	}
+	if err := validateErrorDetailsVersion(p.ErrorDetailsVersion); err != nil {
+		return pkgerrors.Wrap(err, "error details version")
+	}
+	if p.GasSchedule != nil {
+		if err := p.GasSchedule.ValidateBasic(); err != nil {
+			return pkgerrors.Wrap(err, "gas schedule")
+		}
+	}
+	return nil
+}
+
+// ValidateBasic ensures that the costs can be converted to CosmWasm gas
+func (g GasSchedule) ValidateBasic() error {
+	if g.GasMultiplier == 0 {
+		return errors.Wrap(ErrEmpty, "gas multiplier")
+	}
+	validateAddressCost := g.HumanizeAddressCost + g.CanonicalizeAddressCost
+	if validateAddressCost < g.HumanizeAddressCost {
+		return errors.Wrap(ErrInvalid, "address costs overflow")
+	}
+	maxCost := math.MaxUint64 / g.GasMultiplier
+	if validateAddressCost > maxCost {
+		return errors.Wrap(ErrInvalid, "address costs overflow with the gas multiplier")
+	}
+	if g.DeserializationCostPerByte > maxCost {
+		return errors.Wrap(ErrInvalid, "deserialization cost overflows with the gas multiplier")
+	}
 	return nil
 }
 
@@ -140,6 +204,19 @@ func validateAccessConfig(i interface{}) error {
 }
 
 func validateAccessType(i interface{}) error {
+	a, ok := i.(AccessType)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if a == AccessTypeOnlyAddress {
+		return errors.Wrap(ErrInvalid, "deprecated type, use AnyOfAddresses")
+	}
+	return validateLegacyAccessType(a)
+}
+
+// validateLegacyAccessType accepts the deprecated AccessTypeOnlyAddress that can still be stored
+// in the legacy x/params subspace
+func validateLegacyAccessType(i interface{}) error {
 	a, ok := i.(AccessType)
 	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
@@ -155,6 +232,23 @@ func validateAccessType(i interface{}) error {
 	return errors.Wrapf(ErrInvalid, "unknown type: %q", a)
 }
 
+// validateLegacyAccessConfig accepts the deprecated AccessTypeOnlyAddress that can still be stored
+// in the legacy x/params subspace
+func validateLegacyAccessConfig(i interface{}) error {
+	v, ok := i.(AccessConfig)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.Permission != AccessTypeOnlyAddress {
+		return v.ValidateBasic()
+	}
+	if len(v.Addresses) != 0 {
+		return errors.Wrap(ErrInvalid, "addresses not allowed for this type")
+	}
+	_, err := sdk.AccAddressFromBech32(v.Address)
+	return err
+}
+
 func validateMaxWasmCodeSize(i interface{}) error {
 	a, ok := i.(uint64)
 	if !ok {
@@ -167,14 +261,24 @@ func validateMaxWasmCodeSize(i interface{}) error {
 }
 
 func (a AccessConfig) AllowedClients() []sdk.AccAddress {
-	if a.Permission != AccessTypeOnlyAddress {
+	var bech32Addrs []string
+	switch a.Permission {
+	case AccessTypeOnlyAddress:
+		bech32Addrs = []string{a.Address}
+	case AccessTypeAnyOfAddresses:
+		bech32Addrs = a.Addresses
+	default:
 		return nil
 	}
-	addr, err := sdk.AccAddressFromBech32(a.Address)
-	if err != nil {
-		return nil
+	r := make([]sdk.AccAddress, 0, len(bech32Addrs))
+	for _, v := range bech32Addrs {
+		addr, err := sdk.AccAddressFromBech32(v)
+		if err != nil {
+			return nil
+		}
+		r = append(r, addr)
 	}
-	return []sdk.AccAddress{addr}
+	return r
 }
 
 func (a AccessConfig) ValidateBasic() error {
@@ -182,17 +286,67 @@ func (a AccessConfig) ValidateBasic() error {
 	case AccessTypeUnspecified:
 		return errors.Wrap(ErrEmpty, "type")
 	case AccessTypeNobody, AccessTypeEverybody:
-		if len(a.Address) != 0 {
+		if len(a.Address) != 0 || len(a.Addresses) != 0 {
 			return errors.Wrap(ErrInvalid, "address not allowed for this type")
 		}
 		return nil
 	case AccessTypeOnlyAddress:
-		_, err := sdk.AccAddressFromBech32(a.Address)
-		return err
+		// only readable from the state before the v4 migration
+		return errors.Wrap(ErrInvalid, "deprecated type, use AnyOfAddresses")
+	case AccessTypeAnyOfAddresses:
+		if len(a.Address) != 0 {
+			return errors.Wrap(ErrInvalid, "address not allowed for this type, use addresses")
+		}
+		return errors.Wrap(validateBech32Addresses(a.Addresses), "addresses")
 	}
 	return errors.Wrapf(ErrInvalid, "unknown type: %q", a.Permission)
 }
 
+// validateBech32Addresses ensures the list is not empty, has no duplicates and contains only valid bech32 addresses
+func validateBech32Addresses(addrs []string) error {
+	if len(addrs) == 0 {
+		return ErrEmpty
+	}
+	idx := make(map[string]struct{}, len(addrs))
+	for _, a := range addrs {
+		if _, err := sdk.AccAddressFromBech32(a); err != nil {
+			return errors.Wrapf(err, "address: %s", a)
+		}
+		if _, exists := idx[a]; exists {
+			return errors.Wrapf(ErrDuplicate, "address: %s", a)
+		}
+		idx[a] = struct{}{}
+	}
+	return nil
+}
+
+// IsSubset checks if the current AccessConfig grants the same or fewer permissions than the given superSet
+func (a AccessConfig) IsSubset(superSet AccessConfig) bool {
+	switch superSet.Permission {
+	case AccessTypeEverybody:
+		return a.Permission != AccessTypeUnspecified
+	case AccessTypeNobody:
+		return a.Permission == AccessTypeNobody
+	case AccessTypeOnlyAddress, AccessTypeAnyOfAddresses:
+		switch a.Permission {
+		case AccessTypeNobody:
+			return true
+		case AccessTypeOnlyAddress, AccessTypeAnyOfAddresses:
+			allowed := a.AllowedClients()
+			if len(allowed) == 0 {
+				return false
+			}
+			for _, addr := range allowed {
+				if !superSet.Allowed(addr) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func (a AccessConfig) Allowed(actor sdk.AccAddress) bool {
 	switch a.Permission {
 	case AccessTypeNobody:
@@ -201,6 +355,13 @@ func (a AccessConfig) Allowed(actor sdk.AccAddress) bool {
 		return true
 	case AccessTypeOnlyAddress:
 		return a.Address == actor.String()
+	case AccessTypeAnyOfAddresses:
+		for _, v := range a.Addresses {
+			if v == actor.String() {
+				return true
+			}
+		}
+		return false
 	default:
 		panic("unknown type")
 	}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -38,12 +40,28 @@ func TestValidateParams(t *testing.T) {
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 		},
-		"all good with only address": {
+		"reject deprecated only address": {
 			src: Params{
 				CodeUploadAccess:             AccessTypeOnlyAddress.With(anyAddress),
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject deprecated only address in instantiate": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
+			expErr: true,
+		},
+		"all good with anyOf addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessTypeAnyOfAddresses.With(anyAddress),
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
 		},
 		"reject empty type in instantiate permission": {
 			src: Params{
@@ -63,7 +81,133 @@ func TestValidateParams(t *testing.T) {
 		"reject invalid address in only address": {
 			src: Params{
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeOnlyAddress, Address: invalidAddress},
-				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject empty addresses in anyOf addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeAnyOfAddresses},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject invalid address in anyOf addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anyAddress.String(), invalidAddress}},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject duplicate address in anyOf addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anyAddress.String(), anyAddress.String()}},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject anyOf addresses with obsolete address": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeAnyOfAddresses, Address: anyAddress.String(), Addresses: []string{anyAddress.String()}},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"reject only address with addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeOnlyAddress, Address: anyAddress.String(), Addresses: []string{anyAddress.String()}},
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+			},
+			expErr: true,
+		},
+		"all good with ics721 contract": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ICS721Contract:               anyAddress.String(),
+			},
+		},
+		"reject invalid ics721 contract": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ICS721Contract:               invalidAddress,
+			},
+			expErr: true,
+		},
+		"all good without error details": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ErrorDetailsVersion:          0,
+			},
+		},
+		"reject unknown error details version": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ErrorDetailsVersion:          LatestErrorDetailsVersion + 1,
+			},
+			expErr: true,
+		},
+		"all good with gas schedule": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				GasSchedule:                  &GasSchedule{GasMultiplier: 1, HumanizeAddressCost: 5, CanonicalizeAddressCost: 4, DeserializationCostPerByte: 1},
+			},
+		},
+		"reject gas schedule without gas multiplier": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				GasSchedule:                  &GasSchedule{},
+			},
+			expErr: true,
+		},
+		"reject gas schedule with address costs overflow": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				GasSchedule:                  &GasSchedule{GasMultiplier: 1, HumanizeAddressCost: math.MaxUint64, CanonicalizeAddressCost: 1},
+			},
+			expErr: true,
+		},
+		"reject gas schedule with api costs overflow by gas multiplier": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				GasSchedule:                  &GasSchedule{GasMultiplier: math.MaxUint64 / 2, HumanizeAddressCost: 3},
+			},
+			expErr: true,
+		},
+		"reject gas schedule with deserialization cost overflow by gas multiplier": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				GasSchedule:                  &GasSchedule{GasMultiplier: math.MaxUint64 / 2, DeserializationCostPerByte: 3},
+			},
+			expErr: true,
+		},
+		"reject CodeUploadAccess Everybody with obsolete addresses": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeEverybody, Addresses: []string{anyAddress.String()}},
+				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 			expErr: true,
@@ -71,7 +215,7 @@ func TestValidateParams(t *testing.T) {
 		"reject CodeUploadAccess Everybody with obsolete address": {
 			src: Params{
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeEverybody, Address: anyAddress.String()},
-				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 			expErr: true,
@@ -79,14 +223,14 @@ func TestValidateParams(t *testing.T) {
 		"reject CodeUploadAccess Nobody with obsolete address": {
 			src: Params{
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeNobody, Address: anyAddress.String()},
-				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 			expErr: true,
 		},
 		"reject empty CodeUploadAccess": {
 			src: Params{
-				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 			expErr: true,
@@ -94,7 +238,7 @@ func TestValidateParams(t *testing.T) {
 		"reject undefined permission in CodeUploadAccess": {
 			src: Params{
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeUnspecified},
-				InstantiateDefaultPermission: AccessTypeOnlyAddress,
+				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
 			},
 			expErr: true,
@@ -124,11 +268,12 @@ func TestAccessTypeMarshalJson(t *testing.T) {
 		src AccessType
 		exp string
 	}{
-		"Unspecified": {src: AccessTypeUnspecified, exp: `"Unspecified"`},
-		"Nobody":      {src: AccessTypeNobody, exp: `"Nobody"`},
-		"OnlyAddress": {src: AccessTypeOnlyAddress, exp: `"OnlyAddress"`},
-		"Everybody":   {src: AccessTypeEverybody, exp: `"Everybody"`},
-		"unknown":     {src: 999, exp: `"Unspecified"`},
+		"Unspecified":    {src: AccessTypeUnspecified, exp: `"Unspecified"`},
+		"Nobody":         {src: AccessTypeNobody, exp: `"Nobody"`},
+		"OnlyAddress":    {src: AccessTypeOnlyAddress, exp: `"OnlyAddress"`},
+		"Everybody":      {src: AccessTypeEverybody, exp: `"Everybody"`},
+		"AnyOfAddresses": {src: AccessTypeAnyOfAddresses, exp: `"AnyOfAddresses"`},
+		"unknown":        {src: 999, exp: `"Unspecified"`},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -144,11 +289,12 @@ func TestAccessTypeUnmarshalJson(t *testing.T) {
 		src string
 		exp AccessType
 	}{
-		"Unspecified": {src: `"Unspecified"`, exp: AccessTypeUnspecified},
-		"Nobody":      {src: `"Nobody"`, exp: AccessTypeNobody},
-		"OnlyAddress": {src: `"OnlyAddress"`, exp: AccessTypeOnlyAddress},
-		"Everybody":   {src: `"Everybody"`, exp: AccessTypeEverybody},
-		"unknown":     {src: `""`, exp: AccessTypeUnspecified},
+		"Unspecified":    {src: `"Unspecified"`, exp: AccessTypeUnspecified},
+		"Nobody":         {src: `"Nobody"`, exp: AccessTypeNobody},
+		"OnlyAddress":    {src: `"OnlyAddress"`, exp: AccessTypeOnlyAddress},
+		"Everybody":      {src: `"Everybody"`, exp: AccessTypeEverybody},
+		"AnyOfAddresses": {src: `"AnyOfAddresses"`, exp: AccessTypeAnyOfAddresses},
+		"unknown":        {src: `""`, exp: AccessTypeUnspecified},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -168,7 +314,8 @@ func TestParamsUnmarshalJson(t *testing.T) {
 		"defaults": {
 			src: `{"code_upload_access": {"permission": "Everybody"},
 				"instantiate_default_permission": "Everybody",
-				"max_wasm_code_size": 1228800}`,
+				"max_wasm_code_size": 1228800,
+				"error_details_version": 1}`,
 			exp: DefaultParams(),
 		},
 	}
@@ -184,3 +331,73 @@ func TestParamsUnmarshalJson(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessConfigAllowed(t *testing.T) {
+	addr := sdk.AccAddress(make([]byte, 20))
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	specs := map[string]struct {
+		config AccessConfig
+		actor  sdk.AccAddress
+		exp    bool
+	}{
+		"nobody":                 {config: AllowNobody, actor: addr, exp: false},
+		"everybody":              {config: AllowEverybody, actor: addr, exp: true},
+		"only address - matches": {config: AccessTypeOnlyAddress.With(addr), actor: addr, exp: true},
+		"only address - other":   {config: AccessTypeOnlyAddress.With(addr), actor: otherAddr, exp: false},
+		"anyOf - first matches":  {config: AccessTypeAnyOfAddresses.With(addr, otherAddr), actor: addr, exp: true},
+		"anyOf - last matches":   {config: AccessTypeAnyOfAddresses.With(addr, otherAddr), actor: otherAddr, exp: true},
+		"anyOf - not contained":  {config: AccessTypeAnyOfAddresses.With(addr), actor: otherAddr, exp: false},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.config.Allowed(spec.actor))
+		})
+	}
+}
+
+func TestAccessTypeWith(t *testing.T) {
+	addr := sdk.AccAddress(make([]byte, 20))
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	assert.Equal(t, AllowNobody, AccessTypeNobody.With())
+	assert.Equal(t, AllowEverybody, AccessTypeEverybody.With(addr))
+	assert.Equal(t, AccessConfig{Permission: AccessTypeOnlyAddress, Address: addr.String()}, AccessTypeOnlyAddress.With(addr))
+	assert.Equal(t, AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{addr.String(), otherAddr.String()}},
+		AccessTypeAnyOfAddresses.With(addr, otherAddr))
+
+	assert.Panics(t, func() { AccessTypeOnlyAddress.With(addr, otherAddr) })
+	assert.Panics(t, func() { AccessTypeAnyOfAddresses.With() })
+	assert.Panics(t, func() { AccessTypeAnyOfAddresses.With(addr, addr) })
+}
+
+func TestAccessConfigIsSubset(t *testing.T) {
+	addr := sdk.AccAddress(make([]byte, 20))
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	specs := map[string]struct {
+		superSet AccessConfig
+		src      AccessConfig
+		exp      bool
+	}{
+		"everybody > everybody":          {superSet: AllowEverybody, src: AllowEverybody, exp: true},
+		"everybody > nobody":             {superSet: AllowEverybody, src: AllowNobody, exp: true},
+		"everybody > anyOf":              {superSet: AllowEverybody, src: AccessTypeAnyOfAddresses.With(addr), exp: true},
+		"everybody > unspecified":        {superSet: AllowEverybody, src: AccessConfig{}, exp: false},
+		"nobody > nobody":                {superSet: AllowNobody, src: AllowNobody, exp: true},
+		"nobody > everybody":             {superSet: AllowNobody, src: AllowEverybody, exp: false},
+		"nobody > anyOf":                 {superSet: AllowNobody, src: AccessTypeAnyOfAddresses.With(addr), exp: false},
+		"anyOf > nobody":                 {superSet: AccessTypeAnyOfAddresses.With(addr), src: AllowNobody, exp: true},
+		"anyOf > everybody":              {superSet: AccessTypeAnyOfAddresses.With(addr), src: AllowEverybody, exp: false},
+		"anyOf > same anyOf":             {superSet: AccessTypeAnyOfAddresses.With(addr), src: AccessTypeAnyOfAddresses.With(addr), exp: true},
+		"anyOf > fewer anyOf":            {superSet: AccessTypeAnyOfAddresses.With(addr, otherAddr), src: AccessTypeAnyOfAddresses.With(otherAddr), exp: true},
+		"anyOf > more anyOf":             {superSet: AccessTypeAnyOfAddresses.With(addr), src: AccessTypeAnyOfAddresses.With(addr, otherAddr), exp: false},
+		"anyOf > contained only address": {superSet: AccessTypeAnyOfAddresses.With(addr), src: AccessTypeOnlyAddress.With(addr), exp: true},
+		"only address > other anyOf":     {superSet: AccessTypeOnlyAddress.With(addr), src: AccessTypeAnyOfAddresses.With(otherAddr), exp: false},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.src.IsSubset(spec.superSet))
+		})
+	}
+}
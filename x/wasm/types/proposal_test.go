@@ -107,10 +107,17 @@ func TestValidateStoreCodeProposal(t *testing.T) {
 			src: StoreCodeProposalFixture(),
 		},
 		"with instantiate permission": {
+			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
+				accessConfig := AccessTypeAnyOfAddresses.With(anyAddress)
+				p.InstantiatePermission = &accessConfig
+			}),
+		},
+		"with deprecated only address instantiate permission": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
 				accessConfig := AccessTypeOnlyAddress.With(anyAddress)
 				p.InstantiatePermission = &accessConfig
 			}),
+			expErr: true,
 		},
 		"base data missing": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	stderrors "errors"
 	"strings"
@@ -42,6 +43,11 @@ func (r RawContractMessage) Bytes() []byte {
 	return r
 }
 
+// Equal content is equal json. Byte equal but this can change in the future.
+func (r RawContractMessage) Equal(o RawContractMessage) bool {
+	return bytes.Equal(r.Bytes(), o.Bytes())
+}
+
 func (msg MsgStoreCode) Route() string {
 	return RouterKey
 }
@@ -130,6 +136,57 @@ func (msg MsgInstantiateContract) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{senderAddr}
 }
 
+func (msg MsgInstantiateContract2) Route() string {
+	return RouterKey
+}
+
+func (msg MsgInstantiateContract2) Type() string {
+	return "instantiate2"
+}
+
+func (msg MsgInstantiateContract2) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errors.Wrap(err, "sender")
+	}
+
+	if msg.CodeID == 0 {
+		return errors.Wrap(ErrInvalid, "code id is required")
+	}
+
+	if err := validateLabel(msg.Label); err != nil {
+		return errors.Wrap(ErrInvalid, "label is required")
+	}
+
+	if !msg.Funds.IsValid() {
+		return sdkerrors.ErrInvalidCoins
+	}
+
+	if len(msg.Admin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return errors.Wrap(err, "admin")
+		}
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "payload msg")
+	}
+	if err := ValidateSalt(msg.Salt); err != nil {
+		return errors.Wrap(err, "salt")
+	}
+	return nil
+}
+
+func (msg MsgInstantiateContract2) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgInstantiateContract2) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
 func (msg MsgExecuteContract) Route() string {
 	return RouterKey
 }
@@ -169,6 +226,21 @@ func (msg MsgExecuteContract) GetSigners() []sdk.AccAddress {
 
 }
 
+// GetMsg returns the payload message send to the contract
+func (msg MsgExecuteContract) GetMsg() RawContractMessage {
+	return msg.Msg
+}
+
+// GetFunds returns tokens send to the contract
+func (msg MsgExecuteContract) GetFunds() sdk.Coins {
+	return msg.Funds
+}
+
+// GetContract returns the bech32 address of the contract
+func (msg MsgExecuteContract) GetContract() string {
+	return msg.Contract
+}
+
 func (msg MsgMigrateContract) Route() string {
 	return RouterKey
 }
@@ -209,6 +281,21 @@ func (msg MsgMigrateContract) GetSigners() []sdk.AccAddress {
 
 }
 
+// GetMsg returns the payload message send to the contract
+func (msg MsgMigrateContract) GetMsg() RawContractMessage {
+	return msg.Msg
+}
+
+// GetFunds returns tokens send to the contract
+func (msg MsgMigrateContract) GetFunds() sdk.Coins {
+	return sdk.NewCoins()
+}
+
+// GetContract returns the bech32 address of the contract
+func (msg MsgMigrateContract) GetContract() string {
+	return msg.Contract
+}
+
 func (msg MsgUpdateAdmin) Route() string {
 	return RouterKey
 }
@@ -247,6 +334,39 @@ func (msg MsgUpdateAdmin) GetSigners() []sdk.AccAddress {
 
 }
 
+func (msg MsgUpdateContractLabel) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateContractLabel) Type() string {
+	return "update-contract-label"
+}
+
+func (msg MsgUpdateContractLabel) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errors.Wrap(err, "contract")
+	}
+	if err := validateLabel(msg.NewLabel); err != nil {
+		return errors.Wrap(err, "new label")
+	}
+	return nil
+}
+
+func (msg MsgUpdateContractLabel) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateContractLabel) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
 func (msg MsgClearAdmin) Route() string {
 	return RouterKey
 }
@@ -279,6 +399,42 @@ func (msg MsgClearAdmin) GetSigners() []sdk.AccAddress {
 
 }
 
+func (msg MsgUpdateInstantiateConfig) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateInstantiateConfig) Type() string {
+	return "update-instantiate-config"
+}
+
+func (msg MsgUpdateInstantiateConfig) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errors.Wrap(err, "sender")
+	}
+	if msg.CodeID == 0 {
+		return errors.Wrap(ErrInvalid, "code id is required")
+	}
+	if msg.NewInstantiatePermission == nil {
+		return errors.Wrap(ErrEmpty, "instantiate config")
+	}
+	if err := msg.NewInstantiatePermission.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "instantiate config")
+	}
+	return nil
+}
+
+func (msg MsgUpdateInstantiateConfig) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateInstantiateConfig) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
 func (msg MsgIBCSend) Route() string {
 	return RouterKey
 }
@@ -318,3 +474,253 @@ func (msg MsgIBCCloseChannel) GetSignBytes() []byte {
 func (msg MsgIBCCloseChannel) GetSigners() []sdk.AccAddress {
 	return nil
 }
+
+func (msg MsgUpdateParams) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateParams) Type() string {
+	return "update-params"
+}
+
+func (msg MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	return msg.Params.ValidateBasic()
+}
+
+func (msg MsgUpdateParams) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgSudoContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSudoContract) Type() string {
+	return "sudo-contract"
+}
+
+func (msg MsgSudoContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errors.Wrap(err, "contract")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgSudoContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgSudoContract) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgPinCodes) Route() string {
+	return RouterKey
+}
+
+func (msg MsgPinCodes) Type() string {
+	return "pin-codes"
+}
+
+func (msg MsgPinCodes) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	if len(msg.CodeIDs) == 0 {
+		return errors.Wrap(ErrEmpty, "code ids")
+	}
+	return nil
+}
+
+func (msg MsgPinCodes) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgPinCodes) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgUnpinCodes) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUnpinCodes) Type() string {
+	return "unpin-codes"
+}
+
+func (msg MsgUnpinCodes) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	if len(msg.CodeIDs) == 0 {
+		return errors.Wrap(ErrEmpty, "code ids")
+	}
+	return nil
+}
+
+func (msg MsgUnpinCodes) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUnpinCodes) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgRemoveCodes) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRemoveCodes) Type() string {
+	return "remove-codes"
+}
+
+func (msg MsgRemoveCodes) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+	if len(msg.CodeIDs) == 0 {
+		return errors.Wrap(ErrEmpty, "code ids")
+	}
+	return nil
+}
+
+func (msg MsgRemoveCodes) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgRemoveCodes) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgStoreAndInstantiateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreAndInstantiateContract) Type() string {
+	return "store-and-instantiate-contract"
+}
+
+func (msg MsgStoreAndInstantiateContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+
+	if err := validateLabel(msg.Label); err != nil {
+		return errors.Wrap(ErrInvalid, "label is required")
+	}
+
+	if !msg.Funds.IsValid() {
+		return sdkerrors.ErrInvalidCoins
+	}
+
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "payload msg")
+	}
+
+	if len(msg.Admin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return errors.Wrap(err, "admin")
+		}
+	}
+
+	if err := validateWasmCode(msg.WASMByteCode); err != nil {
+		return errors.Wrapf(ErrInvalid, "code bytes %s", err.Error())
+	}
+
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return errors.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgStoreAndInstantiateContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgStoreAndInstantiateContract) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
+
+func (msg MsgStoreAndMigrateContract) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreAndMigrateContract) Type() string {
+	return "store-and-migrate-contract"
+}
+
+func (msg MsgStoreAndMigrateContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrap(err, "authority")
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errors.Wrap(err, "contract")
+	}
+
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "payload msg")
+	}
+
+	if err := validateWasmCode(msg.WASMByteCode); err != nil {
+		return errors.Wrapf(ErrInvalid, "code bytes %s", err.Error())
+	}
+
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return errors.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgStoreAndMigrateContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgStoreAndMigrateContract) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{authority}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -62,6 +63,14 @@ func TestStoreCodeValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"deprecated only address InstantiatePermission": {
+			msg: MsgStoreCode{
+				Sender:                goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: goodAddress},
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -183,6 +192,140 @@ func TestInstantiateContractValidation(t *testing.T) {
 	}
 }
 
+func TestInstantiateContract2Validation(t *testing.T) {
+	badAddress := "cosmos1invalid"
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgInstantiateContract2
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgInstantiateContract2{},
+			valid: false,
+		},
+		"correct minimal": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte("{}"),
+				Salt:   []byte{0},
+			},
+			valid: true,
+		},
+		"missing code": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				Label:  "foo",
+				Msg:    []byte("{}"),
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"missing label": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				Msg:    []byte("{}"),
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"label too long": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				Label:  strings.Repeat("food", 33),
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"bad sender minimal": {
+			msg: MsgInstantiateContract2{
+				Sender: badAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte("{}"),
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"correct maximal": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  strings.Repeat("a", MaxLabelSize),
+				Msg:    []byte(`{"some": "data"}`),
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
+				Salt:   bytes.Repeat([]byte{0}, MaxSaltSize),
+				FixMsg: true,
+			},
+			valid: true,
+		},
+		"negative funds": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				// we cannot use sdk.NewCoin() constructors as they panic on creating invalid data (before we can test)
+				Funds: sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(-200)}},
+				Salt:  []byte{0},
+			},
+			valid: false,
+		},
+		"non json init msg": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte("invalid-json"),
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"empty init msg": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Salt:   []byte{0},
+			},
+			valid: false,
+		},
+		"empty salt": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+			},
+			valid: false,
+		},
+		"salt too long": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Salt:   bytes.Repeat([]byte{0}, MaxSaltSize+1),
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestExecuteContractValidation(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -415,6 +558,69 @@ func TestMsgClearAdministrator(t *testing.T) {
 	}
 }
 
+func TestMsgUpdateContractLabelValidation(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateContractLabel
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: "new label",
+				Contract: otherGoodAddress,
+			},
+		},
+		"new label required": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"new label too long": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: strings.Repeat("a", MaxLabelSize+1),
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad sender": {
+			src: MsgUpdateContractLabel{
+				Sender:   badAddress,
+				NewLabel: "new label",
+				Contract: otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgUpdateContractLabel{
+				Sender:   goodAddress,
+				NewLabel: "new label",
+				Contract: badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestMsgMigrateContract(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -501,3 +707,455 @@ func TestMsgMigrateContract(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgUpdateParamsValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateParams
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    DefaultParams(),
+			},
+		},
+		"bad authority": {
+			src: MsgUpdateParams{
+				Authority: "cosmos1invalid",
+				Params:    DefaultParams(),
+			},
+			expErr: true,
+		},
+		"empty authority": {
+			src: MsgUpdateParams{
+				Params: DefaultParams(),
+			},
+			expErr: true,
+		},
+		"invalid params": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    Params{},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSudoContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSudoContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("{}"),
+			},
+		},
+		"bad authority": {
+			src: MsgSudoContract{
+				Authority: "cosmos1invalid",
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  "cosmos1invalid",
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"non json msg": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("invalid json"),
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgSudoContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgPinCodesValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgPinCodes
+		expErr bool
+	}{
+		"all good": {
+			src: MsgPinCodes{
+				Authority: goodAddress,
+				CodeIDs:   []uint64{1},
+			},
+		},
+		"bad authority": {
+			src: MsgPinCodes{
+				Authority: "cosmos1invalid",
+				CodeIDs:   []uint64{1},
+			},
+			expErr: true,
+		},
+		"empty code ids": {
+			src: MsgPinCodes{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUnpinCodesValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUnpinCodes
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUnpinCodes{
+				Authority: goodAddress,
+				CodeIDs:   []uint64{1},
+			},
+		},
+		"bad authority": {
+			src: MsgUnpinCodes{
+				Authority: "cosmos1invalid",
+				CodeIDs:   []uint64{1},
+			},
+			expErr: true,
+		},
+		"empty code ids": {
+			src: MsgUnpinCodes{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRemoveCodesValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgRemoveCodes
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRemoveCodes{
+				Authority: goodAddress,
+				CodeIDs:   []uint64{1},
+			},
+		},
+		"bad authority": {
+			src: MsgRemoveCodes{
+				Authority: "cosmos1invalid",
+				CodeIDs:   []uint64{1},
+			},
+			expErr: true,
+		},
+		"empty code ids": {
+			src: MsgRemoveCodes{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgStoreAndInstantiateContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgStoreAndInstantiateContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+		},
+		"all good with optional fields": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:             goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AllowNobody,
+				UnpinCode:             true,
+				Admin:                 goodAddress,
+				Label:                 "foo",
+				Msg:                   []byte("{}"),
+				Funds:                 sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
+			},
+		},
+		"bad authority": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:    "cosmos1invalid",
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+			expErr: true,
+		},
+		"missing code": {
+			src: MsgStoreAndInstantiateContract{
+				Authority: goodAddress,
+				Label:     "foo",
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"missing label": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Msg:          []byte("{}"),
+			},
+			expErr: true,
+		},
+		"bad admin": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Admin:        "cosmos1invalid",
+				Label:        "foo",
+				Msg:          []byte("{}"),
+			},
+			expErr: true,
+		},
+		"invalid instantiate permission": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:             goodAddress,
+				WASMByteCode:          []byte("foo"),
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: "cosmos1invalid"},
+				Label:                 "foo",
+				Msg:                   []byte("{}"),
+			},
+			expErr: true,
+		},
+		"non json msg": {
+			src: MsgStoreAndInstantiateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Label:        "foo",
+				Msg:          []byte("invalid json"),
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgStoreAndMigrateContractValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgStoreAndMigrateContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgStoreAndMigrateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contract:     anotherGoodAddress,
+				Msg:          []byte("{}"),
+			},
+		},
+		"bad authority": {
+			src: MsgStoreAndMigrateContract{
+				Authority:    "cosmos1invalid",
+				WASMByteCode: []byte("foo"),
+				Contract:     anotherGoodAddress,
+				Msg:          []byte("{}"),
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgStoreAndMigrateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contract:     "cosmos1invalid",
+				Msg:          []byte("{}"),
+			},
+			expErr: true,
+		},
+		"missing code": {
+			src: MsgStoreAndMigrateContract{
+				Authority: goodAddress,
+				Contract:  anotherGoodAddress,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgStoreAndMigrateContract{
+				Authority:    goodAddress,
+				WASMByteCode: []byte("foo"),
+				Contract:     anotherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateInstantiateConfigValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateInstantiateConfig
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{goodAddress}},
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   "cosmos1invalid",
+				CodeID:                   1,
+				NewInstantiatePermission: &AllowNobody,
+			},
+			expErr: true,
+		},
+		"missing code id": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				NewInstantiatePermission: &AllowNobody,
+			},
+			expErr: true,
+		},
+		"missing instantiate config": {
+			src: MsgUpdateInstantiateConfig{
+				Sender: goodAddress,
+				CodeID: 1,
+			},
+			expErr: true,
+		},
+		"invalid instantiate config": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses},
+			},
+			expErr: true,
+		},
+		"deprecated only address instantiate config": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: goodAddress},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -21,6 +21,10 @@ const (
 	ContractAddrLen = 32
 	// SDKAddrLen defines a valid address length that was used in sdk address generation
 	SDKAddrLen = 20
+
+	// CodeRemovalDelay is the number of blocks a removed wasm code is kept in the wasmvm cache, so that
+	// snapshots and queries of earlier heights can still read it. It matches the sdk default pruning window.
+	CodeRemovalDelay int64 = 362_880
 )
 
 func (m Model) ValidateBasic() error {
@@ -312,6 +316,9 @@ type WasmConfig struct {
 	MemoryCacheSize uint32
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// ContractTraceCacheSize is the number of txs for which the contract call traces are kept in memory.
+	// Tracing is disabled when 0.
+	ContractTraceCacheSize uint32
 }
 
 // DefaultWasmConfig returns the default settings for WasmConfig
@@ -35,10 +35,13 @@ const (
 	AccessTypeUnspecified AccessType = 0
 	// AccessTypeNobody forbidden
 	AccessTypeNobody AccessType = 1
-	// AccessTypeOnlyAddress restricted to an address
+	// AccessTypeOnlyAddress restricted to a single address
+	// Deprecated: use AccessTypeAnyOfAddresses instead
 	AccessTypeOnlyAddress AccessType = 2
 	// AccessTypeEverybody unrestricted
 	AccessTypeEverybody AccessType = 3
+	// AccessTypeAnyOfAddresses allow any of the addresses
+	AccessTypeAnyOfAddresses AccessType = 4
 )
 
 var AccessType_name = map[int32]string{
@@ -46,13 +49,15 @@ var AccessType_name = map[int32]string{
 	1: "ACCESS_TYPE_NOBODY",
 	2: "ACCESS_TYPE_ONLY_ADDRESS",
 	3: "ACCESS_TYPE_EVERYBODY",
+	4: "ACCESS_TYPE_ANY_OF_ADDRESSES",
 }
 
 var AccessType_value = map[string]int32{
-	"ACCESS_TYPE_UNSPECIFIED":  0,
-	"ACCESS_TYPE_NOBODY":       1,
-	"ACCESS_TYPE_ONLY_ADDRESS": 2,
-	"ACCESS_TYPE_EVERYBODY":    3,
+	"ACCESS_TYPE_UNSPECIFIED":      0,
+	"ACCESS_TYPE_NOBODY":           1,
+	"ACCESS_TYPE_ONLY_ADDRESS":     2,
+	"ACCESS_TYPE_EVERYBODY":        3,
+	"ACCESS_TYPE_ANY_OF_ADDRESSES": 4,
 }
 
 func (AccessType) EnumDescriptor() ([]byte, []int) {
@@ -136,7 +141,10 @@ var xxx_messageInfo_AccessTypeParam proto.InternalMessageInfo
 // AccessConfig access control type.
 type AccessConfig struct {
 	Permission AccessType `protobuf:"varint,1,opt,name=permission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"permission,omitempty" yaml:"permission"`
-	Address    string     `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty" yaml:"address"`
+	// Address
+	// Deprecated: replaced by addresses
+	Address   string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty" yaml:"address"`
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty" yaml:"addresses"`
 }
 
 func (m *AccessConfig) Reset()         { *m = AccessConfig{} }
@@ -177,6 +185,16 @@ type Params struct {
 	CodeUploadAccess             AccessConfig `protobuf:"bytes,1,opt,name=code_upload_access,json=codeUploadAccess,proto3" json:"code_upload_access" yaml:"code_upload_access"`
 	InstantiateDefaultPermission AccessType   `protobuf:"varint,2,opt,name=instantiate_default_permission,json=instantiateDefaultPermission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"instantiate_default_permission,omitempty" yaml:"instantiate_default_permission"`
 	MaxWasmCodeSize              uint64       `protobuf:"varint,3,opt,name=max_wasm_code_size,json=maxWasmCodeSize,proto3" json:"max_wasm_code_size,omitempty" yaml:"max_wasm_code_size"`
+	// ics721_contract is the address of the contract that handles the ICS-721
+	// nft-transfer port. The port is disabled when empty.
+	ICS721Contract string `protobuf:"bytes,4,opt,name=ics721_contract,json=ics721Contract,proto3" json:"ics721_contract,omitempty" yaml:"ics721_contract"`
+	// error_details_version selects the registry of deterministic messages that
+	// are passed to contracts for well known submessage and query errors. The
+	// errors are redacted to codespace and code only when 0.
+	ErrorDetailsVersion uint32 `protobuf:"varint,5,opt,name=error_details_version,json=errorDetailsVersion,proto3" json:"error_details_version,omitempty" yaml:"error_details_version"`
+	// gas_schedule are the gas costs for contract interactions. The default gas
+	// schedule of the node binary is used when not set.
+	GasSchedule *GasSchedule `protobuf:"bytes,6,opt,name=gas_schedule,json=gasSchedule,proto3" json:"gas_schedule,omitempty" yaml:"gas_schedule"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -211,6 +229,72 @@ func (m *Params) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Params proto.InternalMessageInfo
 
+// GasSchedule are the gas costs charged for contract interactions. All costs
+// are in SDK gas.
+type GasSchedule struct {
+	// instance_cost is charged each time a contract instance is loaded from a
+	// code that is not pinned
+	InstanceCost uint64 `protobuf:"varint,1,opt,name=instance_cost,json=instanceCost,proto3" json:"instance_cost,omitempty"`
+	// compile_cost is charged per byte of an uploaded wasm code
+	CompileCost uint64 `protobuf:"varint,2,opt,name=compile_cost,json=compileCost,proto3" json:"compile_cost,omitempty"`
+	// gas_multiplier is how many CosmWasm gas points are 1 SDK gas point
+	GasMultiplier uint64 `protobuf:"varint,3,opt,name=gas_multiplier,json=gasMultiplier,proto3" json:"gas_multiplier,omitempty"`
+	// event_per_attribute_cost is charged per event attribute
+	EventPerAttributeCost uint64 `protobuf:"varint,4,opt,name=event_per_attribute_cost,json=eventPerAttributeCost,proto3" json:"event_per_attribute_cost,omitempty"`
+	// event_attribute_data_cost is charged per byte of event types and
+	// attribute keys and values
+	EventAttributeDataCost uint64 `protobuf:"varint,5,opt,name=event_attribute_data_cost,json=eventAttributeDataCost,proto3" json:"event_attribute_data_cost,omitempty"`
+	// event_attribute_data_free_tier is the number of bytes of attribute data
+	// that is free of charge
+	EventAttributeDataFreeTier uint64 `protobuf:"varint,6,opt,name=event_attribute_data_free_tier,json=eventAttributeDataFreeTier,proto3" json:"event_attribute_data_free_tier,omitempty"`
+	// contract_message_data_cost is charged per byte of the message that goes to
+	// the contract
+	ContractMessageDataCost uint64 `protobuf:"varint,7,opt,name=contract_message_data_cost,json=contractMessageDataCost,proto3" json:"contract_message_data_cost,omitempty"`
+	// custom_event_cost is charged per custom event
+	CustomEventCost uint64 `protobuf:"varint,8,opt,name=custom_event_cost,json=customEventCost,proto3" json:"custom_event_cost,omitempty"`
+	// humanize_address_cost is charged by the api to convert to a human address
+	HumanizeAddressCost uint64 `protobuf:"varint,9,opt,name=humanize_address_cost,json=humanizeAddressCost,proto3" json:"humanize_address_cost,omitempty"`
+	// canonicalize_address_cost is charged by the api to convert to a canonical
+	// address
+	CanonicalizeAddressCost uint64 `protobuf:"varint,10,opt,name=canonicalize_address_cost,json=canonicalizeAddressCost,proto3" json:"canonicalize_address_cost,omitempty"`
+	// deserialization_cost_per_byte is charged per byte of JSON that is
+	// deserialized for the contract
+	DeserializationCostPerByte uint64 `protobuf:"varint,11,opt,name=deserialization_cost_per_byte,json=deserializationCostPerByte,proto3" json:"deserialization_cost_per_byte,omitempty"`
+}
+
+func (m *GasSchedule) Reset()         { *m = GasSchedule{} }
+func (m *GasSchedule) String() string { return proto.CompactTextString(m) }
+func (*GasSchedule) ProtoMessage()    {}
+func (*GasSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{3}
+}
+func (m *GasSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GasSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GasSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GasSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GasSchedule.Merge(m, src)
+}
+func (m *GasSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *GasSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_GasSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GasSchedule proto.InternalMessageInfo
+
 // CodeInfo is data for the uploaded contract WASM code
 type CodeInfo struct {
 	// CodeHash is the unique identifier created by wasmvm
@@ -225,7 +309,7 @@ func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
 func (m *CodeInfo) String() string { return proto.CompactTextString(m) }
 func (*CodeInfo) ProtoMessage()    {}
 func (*CodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{3}
+	return fileDescriptor_e6155d98fa173e02, []int{4}
 }
 func (m *CodeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -278,7 +362,7 @@ func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
 func (m *ContractInfo) String() string { return proto.CompactTextString(m) }
 func (*ContractInfo) ProtoMessage()    {}
 func (*ContractInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{4}
+	return fileDescriptor_e6155d98fa173e02, []int{5}
 }
 func (m *ContractInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -321,7 +405,7 @@ func (m *ContractCodeHistoryEntry) Reset()         { *m = ContractCodeHistoryEnt
 func (m *ContractCodeHistoryEntry) String() string { return proto.CompactTextString(m) }
 func (*ContractCodeHistoryEntry) ProtoMessage()    {}
 func (*ContractCodeHistoryEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{5}
+	return fileDescriptor_e6155d98fa173e02, []int{6}
 }
 func (m *ContractCodeHistoryEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -364,7 +448,7 @@ func (m *AbsoluteTxPosition) Reset()         { *m = AbsoluteTxPosition{} }
 func (m *AbsoluteTxPosition) String() string { return proto.CompactTextString(m) }
 func (*AbsoluteTxPosition) ProtoMessage()    {}
 func (*AbsoluteTxPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{6}
+	return fileDescriptor_e6155d98fa173e02, []int{7}
 }
 func (m *AbsoluteTxPosition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -405,7 +489,7 @@ func (m *Model) Reset()         { *m = Model{} }
 func (m *Model) String() string { return proto.CompactTextString(m) }
 func (*Model) ProtoMessage()    {}
 func (*Model) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{7}
+	return fileDescriptor_e6155d98fa173e02, []int{8}
 }
 func (m *Model) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -434,96 +518,217 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
+// ClassTrace contains the base class id of an ICS-721 NFT class and the
+// port/channel path it was transferred over to this chain
+type ClassTrace struct {
+	// path defines the chain of port/channel identifiers used for tracing the
+	// source of the class
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// base_class_id is the class id on the source chain
+	BaseClassID string `protobuf:"bytes,2,opt,name=base_class_id,json=baseClassId,proto3" json:"base_class_id,omitempty"`
+}
+
+func (m *ClassTrace) Reset()         { *m = ClassTrace{} }
+func (m *ClassTrace) String() string { return proto.CompactTextString(m) }
+func (*ClassTrace) ProtoMessage()    {}
+func (*ClassTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{9}
+}
+func (m *ClassTrace) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClassTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClassTrace.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ClassTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClassTrace.Merge(m, src)
+}
+func (m *ClassTrace) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClassTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClassTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClassTrace proto.InternalMessageInfo
+
+// TokenTrace references an ICS-721 NFT that was received over IBC and is held
+// on this chain
+type TokenTrace struct {
+	// class_id is the full class id on this chain including the trace path
+	ClassID string `protobuf:"bytes,1,opt,name=class_id,json=classId,proto3" json:"class_id,omitempty"`
+	// token_id is the id of the token within the class
+	TokenID string `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+}
+
+func (m *TokenTrace) Reset()         { *m = TokenTrace{} }
+func (m *TokenTrace) String() string { return proto.CompactTextString(m) }
+func (*TokenTrace) ProtoMessage()    {}
+func (*TokenTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{10}
+}
+func (m *TokenTrace) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokenTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenTrace.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TokenTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTrace.Merge(m, src)
+}
+func (m *TokenTrace) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTrace proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
 	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
 	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1.AccessTypeParam")
 	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1.AccessConfig")
 	proto.RegisterType((*Params)(nil), "cosmwasm.wasm.v1.Params")
+	proto.RegisterType((*GasSchedule)(nil), "cosmwasm.wasm.v1.GasSchedule")
 	proto.RegisterType((*CodeInfo)(nil), "cosmwasm.wasm.v1.CodeInfo")
 	proto.RegisterType((*ContractInfo)(nil), "cosmwasm.wasm.v1.ContractInfo")
 	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
 	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
 	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+	proto.RegisterType((*ClassTrace)(nil), "cosmwasm.wasm.v1.ClassTrace")
+	proto.RegisterType((*TokenTrace)(nil), "cosmwasm.wasm.v1.TokenTrace")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
 
 var fileDescriptor_e6155d98fa173e02 = []byte{
-	// 1164 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcb, 0x6f, 0x1b, 0xc5,
-	0x1f, 0xf7, 0xda, 0xce, 0xc3, 0xd3, 0xfc, 0x5a, 0x77, 0x7e, 0x09, 0x75, 0x4c, 0xb1, 0xdd, 0xa5,
-	0x40, 0xfa, 0xb2, 0x69, 0x40, 0x80, 0x7a, 0xa8, 0xe4, 0xc7, 0xd2, 0x6c, 0x44, 0x6c, 0x6b, 0xec,
-	0x52, 0x05, 0xa9, 0x5a, 0x8d, 0x77, 0x27, 0xce, 0xa8, 0xbb, 0x3b, 0xd6, 0xce, 0x38, 0xf5, 0xf6,
-	0x2f, 0x40, 0x91, 0x90, 0xb8, 0xc1, 0x25, 0x12, 0x02, 0x84, 0xfa, 0x07, 0x70, 0xe5, 0x5e, 0x71,
-	0xea, 0x11, 0x09, 0xc9, 0x82, 0xf4, 0x02, 0xd7, 0x1c, 0xcb, 0x05, 0xed, 0x4c, 0x2c, 0xaf, 0x9a,
-	0xb6, 0x31, 0x97, 0xd5, 0x7c, 0x1f, 0x9f, 0xcf, 0xf7, 0x35, 0xf3, 0xd5, 0x82, 0x8b, 0x36, 0xe3,
-	0xde, 0x43, 0xcc, 0xbd, 0x8a, 0xfc, 0xec, 0xdd, 0xac, 0x88, 0x70, 0x40, 0x78, 0x79, 0x10, 0x30,
-	0xc1, 0x60, 0x76, 0x62, 0x2d, 0xcb, 0xcf, 0xde, 0xcd, 0xfc, 0x6a, 0xa4, 0x61, 0xdc, 0x92, 0xf6,
-	0x8a, 0x12, 0x94, 0x73, 0x7e, 0xb9, 0xcf, 0xfa, 0x4c, 0xe9, 0xa3, 0xd3, 0xb1, 0x76, 0xb5, 0xcf,
-	0x58, 0xdf, 0x25, 0x15, 0x29, 0xf5, 0x86, 0x3b, 0x15, 0xec, 0x87, 0xca, 0xa4, 0xdf, 0x07, 0xe7,
-	0xaa, 0xb6, 0x4d, 0x38, 0xef, 0x86, 0x03, 0xd2, 0xc6, 0x01, 0xf6, 0x60, 0x03, 0xcc, 0xed, 0x61,
-	0x77, 0x48, 0x72, 0x5a, 0x49, 0x5b, 0x3b, 0xbb, 0x7e, 0xb1, 0xfc, 0x62, 0x02, 0xe5, 0x29, 0xa2,
-	0x96, 0x3d, 0x1a, 0x17, 0x97, 0x42, 0xec, 0xb9, 0xb7, 0x74, 0x09, 0xd2, 0x91, 0x02, 0xdf, 0x4a,
-	0x7f, 0xfb, 0x5d, 0x51, 0xd3, 0xbf, 0xd1, 0xc0, 0x92, 0xf2, 0xae, 0x33, 0x7f, 0x87, 0xf6, 0x61,
-	0x07, 0x80, 0x01, 0x09, 0x3c, 0xca, 0x39, 0x65, 0xfe, 0x4c, 0x11, 0x56, 0x8e, 0xc6, 0xc5, 0xf3,
-	0x2a, 0xc2, 0x14, 0xa9, 0xa3, 0x18, 0x0d, 0xbc, 0x0e, 0x16, 0xb0, 0xe3, 0x04, 0x84, 0xf3, 0x5c,
-	0xb2, 0xa4, 0xad, 0x65, 0x6a, 0xf0, 0x68, 0x5c, 0x3c, 0xab, 0x30, 0xc7, 0x06, 0x1d, 0x4d, 0x5c,
-	0x8e, 0x33, 0xfb, 0x3d, 0x09, 0xe6, 0x65, 0xbd, 0x1c, 0x32, 0x00, 0x6d, 0xe6, 0x10, 0x6b, 0x38,
-	0x70, 0x19, 0x76, 0x2c, 0x2c, 0x63, 0xcb, 0xdc, 0xce, 0xac, 0x17, 0x5e, 0x95, 0x9b, 0xaa, 0xa7,
-	0x76, 0xe9, 0xc9, 0xb8, 0x98, 0x38, 0x1a, 0x17, 0x57, 0x55, 0xb4, 0x93, 0x3c, 0x3a, 0xca, 0x46,
-	0xca, 0xbb, 0x52, 0xa7, 0xa0, 0xf0, 0x2b, 0x0d, 0x14, 0xa8, 0xcf, 0x05, 0xf6, 0x05, 0xc5, 0x82,
-	0x58, 0x0e, 0xd9, 0xc1, 0x43, 0x57, 0x58, 0xb1, 0xce, 0x24, 0x67, 0xe8, 0xcc, 0x95, 0xa3, 0x71,
-	0xf1, 0x1d, 0x15, 0xf7, 0xf5, 0x6c, 0x3a, 0xba, 0x18, 0x73, 0x68, 0x28, 0x7b, 0x7b, 0xda, 0xbf,
-	0x4d, 0x00, 0x3d, 0x3c, 0xb2, 0xa2, 0x10, 0x96, 0xac, 0x80, 0xd3, 0x47, 0x24, 0x97, 0x2a, 0x69,
-	0x6b, 0xe9, 0xda, 0x5b, 0xd3, 0xe2, 0x4e, 0xfa, 0xe8, 0xe8, 0x9c, 0x87, 0x47, 0xf7, 0x30, 0xf7,
-	0xea, 0xcc, 0x21, 0x1d, 0xfa, 0x48, 0xcd, 0x3d, 0xa1, 0x7f, 0xaf, 0x81, 0xc5, 0x48, 0x65, 0xfa,
-	0x3b, 0x0c, 0xbe, 0x09, 0x32, 0x12, 0xb1, 0x8b, 0xf9, 0xae, 0x6c, 0xeb, 0x12, 0x5a, 0x8c, 0x14,
-	0x1b, 0x98, 0xef, 0xc2, 0x1c, 0x58, 0xb0, 0x03, 0x82, 0x05, 0x0b, 0xd4, 0xec, 0xd0, 0x44, 0x84,
-	0x1d, 0x00, 0xe3, 0x65, 0xd9, 0xb2, 0xe1, 0xb9, 0xb9, 0x99, 0xc6, 0x92, 0x8e, 0xc6, 0x82, 0xce,
-	0xc7, 0xf0, 0xca, 0xb0, 0x99, 0x5e, 0x4c, 0x65, 0xd3, 0x9b, 0xe9, 0xc5, 0x74, 0x76, 0x4e, 0xff,
-	0x25, 0x09, 0x96, 0xea, 0xcc, 0x17, 0x01, 0xb6, 0x85, 0x4c, 0xf4, 0x6d, 0xb0, 0x20, 0x13, 0xa5,
-	0x8e, 0x4c, 0x33, 0x5d, 0x03, 0x87, 0xe3, 0xe2, 0xbc, 0xac, 0xa3, 0x81, 0xe6, 0x23, 0x93, 0xe9,
-	0xbc, 0x26, 0xe1, 0x65, 0x30, 0x87, 0x1d, 0x8f, 0xfa, 0xb2, 0x73, 0x19, 0xa4, 0x84, 0x48, 0xeb,
-	0xe2, 0x1e, 0x71, 0x73, 0x69, 0xa5, 0x95, 0x02, 0xbc, 0x7d, 0xcc, 0x42, 0x9c, 0xe3, 0x8a, 0x2e,
-	0xbf, 0xa4, 0xa2, 0x1e, 0x67, 0xee, 0x50, 0x90, 0xee, 0xa8, 0xcd, 0x38, 0x15, 0x94, 0xf9, 0x68,
-	0x02, 0x82, 0x37, 0xc0, 0x19, 0xda, 0xb3, 0xad, 0x01, 0x0b, 0x44, 0x94, 0xee, 0xbc, 0xbc, 0xf6,
-	0xff, 0x3b, 0x1c, 0x17, 0x33, 0x66, 0xad, 0xde, 0x66, 0x81, 0x30, 0x1b, 0x28, 0x43, 0x7b, 0xb6,
-	0x3c, 0x3a, 0x70, 0x0b, 0x64, 0xc8, 0x48, 0x10, 0x5f, 0xde, 0xad, 0x05, 0x19, 0x70, 0xb9, 0xac,
-	0xb6, 0x42, 0x79, 0xb2, 0x15, 0xca, 0x55, 0x3f, 0xac, 0xad, 0xfe, 0xfa, 0xf3, 0x8d, 0x95, 0x78,
-	0x53, 0x8c, 0x09, 0x0c, 0x4d, 0x19, 0x6e, 0xa5, 0xff, 0x8a, 0x9e, 0xd0, 0x3f, 0x1a, 0xc8, 0x4d,
-	0x5c, 0xa3, 0x26, 0x6d, 0x50, 0x2e, 0x58, 0x10, 0x1a, 0xbe, 0x08, 0x42, 0xd8, 0x06, 0x19, 0x36,
-	0x20, 0x01, 0x16, 0xd3, 0x77, 0xbe, 0x7e, 0xb2, 0xc4, 0x97, 0xc0, 0x5b, 0x13, 0x54, 0x74, 0xc7,
-	0xd1, 0x94, 0x24, 0x3e, 0x9d, 0xe4, 0x2b, 0xa7, 0x73, 0x1b, 0x2c, 0x0c, 0x07, 0x8e, 0xec, 0x6b,
-	0xea, 0xbf, 0xf4, 0xf5, 0x18, 0x04, 0xd7, 0x40, 0xca, 0xe3, 0x7d, 0x39, 0xab, 0xa5, 0xda, 0x1b,
-	0xcf, 0xc7, 0x45, 0x88, 0xf0, 0xc3, 0x49, 0x96, 0x5b, 0x84, 0x73, 0xdc, 0x27, 0x28, 0x72, 0xd1,
-	0x11, 0x80, 0x27, 0x89, 0xe0, 0x25, 0xb0, 0xd4, 0x73, 0x99, 0xfd, 0xc0, 0xda, 0x25, 0xb4, 0xbf,
-	0x2b, 0xd4, 0x3d, 0x42, 0x67, 0xa4, 0x6e, 0x43, 0xaa, 0xe0, 0x2a, 0x58, 0x14, 0x23, 0x8b, 0xfa,
-	0x0e, 0x19, 0xa9, 0x42, 0xd0, 0x82, 0x18, 0x99, 0x91, 0xa8, 0x53, 0x30, 0xb7, 0xc5, 0x1c, 0xe2,
-	0xc2, 0x4d, 0x90, 0x7a, 0x40, 0x42, 0xf5, 0x58, 0x6a, 0x9f, 0x3c, 0x1f, 0x17, 0x3f, 0xec, 0x53,
-	0xb1, 0x3b, 0xec, 0x95, 0x6d, 0xe6, 0x55, 0x04, 0xf1, 0x9d, 0xe8, 0xf1, 0xfa, 0x22, 0x7e, 0x74,
-	0x69, 0x8f, 0x57, 0x7a, 0xa1, 0x20, 0xbc, 0xbc, 0x41, 0x46, 0xb5, 0xe8, 0x80, 0x22, 0x92, 0xe8,
-	0x02, 0xaa, 0x7d, 0x9e, 0x94, 0x4f, 0x4f, 0x09, 0x57, 0xff, 0xd6, 0x00, 0x98, 0xee, 0x12, 0xf8,
-	0x11, 0xb8, 0x50, 0xad, 0xd7, 0x8d, 0x4e, 0xc7, 0xea, 0x6e, 0xb7, 0x0d, 0xeb, 0x6e, 0xb3, 0xd3,
-	0x36, 0xea, 0xe6, 0xa7, 0xa6, 0xd1, 0xc8, 0x26, 0xf2, 0xab, 0xfb, 0x07, 0xa5, 0x95, 0xa9, 0xf3,
-	0x5d, 0x9f, 0x0f, 0x88, 0x4d, 0x77, 0x28, 0x71, 0xe0, 0x75, 0x00, 0xe3, 0xb8, 0x66, 0xab, 0xd6,
-	0x6a, 0x6c, 0x67, 0xb5, 0xfc, 0xf2, 0xfe, 0x41, 0x29, 0x3b, 0x85, 0x34, 0x59, 0x8f, 0x39, 0x21,
-	0xfc, 0x18, 0xe4, 0xe2, 0xde, 0xad, 0xe6, 0x67, 0xdb, 0x56, 0xb5, 0xd1, 0x40, 0x46, 0xa7, 0x93,
-	0x4d, 0xbe, 0x18, 0xa6, 0xe5, 0xbb, 0x61, 0x55, 0xed, 0x6c, 0xb8, 0x0e, 0x56, 0xe2, 0x40, 0xe3,
-	0x73, 0x03, 0x6d, 0xcb, 0x48, 0xa9, 0xfc, 0x85, 0xfd, 0x83, 0xd2, 0xff, 0xa7, 0x28, 0x63, 0x8f,
-	0x04, 0x61, 0x14, 0x2c, 0xbf, 0xf8, 0xe5, 0x0f, 0x85, 0xc4, 0xe3, 0x1f, 0x0b, 0x89, 0xab, 0x3f,
-	0xa5, 0x40, 0xe9, 0xb4, 0x9b, 0x06, 0x09, 0x78, 0xbf, 0xde, 0x6a, 0x76, 0x51, 0xb5, 0xde, 0xb5,
-	0xea, 0xad, 0x86, 0x61, 0x6d, 0x98, 0x9d, 0x6e, 0x0b, 0x6d, 0x5b, 0xad, 0xb6, 0x81, 0xaa, 0x5d,
-	0xb3, 0xd5, 0x7c, 0x59, 0x6b, 0x2a, 0xfb, 0x07, 0xa5, 0x6b, 0xa7, 0x71, 0xc7, 0x1b, 0x76, 0x0f,
-	0x5c, 0x99, 0x29, 0x8c, 0xd9, 0x34, 0xbb, 0x59, 0x2d, 0xbf, 0xb6, 0x7f, 0x50, 0xba, 0x7c, 0x1a,
-	0xbf, 0xe9, 0x53, 0x01, 0xef, 0x83, 0xeb, 0x33, 0x11, 0x6f, 0x99, 0x77, 0x50, 0xb5, 0x6b, 0x64,
-	0x93, 0xf9, 0x6b, 0xfb, 0x07, 0xa5, 0xf7, 0x4e, 0xe3, 0xde, 0xa2, 0xfd, 0x00, 0x0b, 0x32, 0x33,
-	0xfd, 0x1d, 0xa3, 0x69, 0x74, 0xcc, 0x4e, 0x36, 0x35, 0x1b, 0xfd, 0x1d, 0xe2, 0x13, 0x4e, 0x79,
-	0x3e, 0x1d, 0x0d, 0xab, 0xb6, 0xf1, 0xe4, 0xcf, 0x42, 0xe2, 0xf1, 0x61, 0x41, 0x7b, 0x72, 0x58,
-	0xd0, 0x9e, 0x1e, 0x16, 0xb4, 0x3f, 0x0e, 0x0b, 0xda, 0xd7, 0xcf, 0x0a, 0x89, 0xa7, 0xcf, 0x0a,
-	0x89, 0xdf, 0x9e, 0x15, 0x12, 0x5f, 0xbc, 0x1b, 0x7b, 0x07, 0x75, 0xc6, 0xbd, 0x7b, 0x93, 0xdf,
-	0x26, 0xa7, 0x32, 0x52, 0xbf, 0x4f, 0xf2, 0xdf, 0xa9, 0x37, 0x2f, 0xb7, 0xda, 0x07, 0xff, 0x06,
-	0x00, 0x00, 0xff, 0xff, 0x18, 0x23, 0xa5, 0x9f, 0x5c, 0x09, 0x00, 0x00,
+	// 1683 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x97, 0xcf, 0x6f, 0xdb, 0xc8,
+	0x15, 0xc7, 0xf5, 0xcb, 0x96, 0x35, 0xf2, 0x0f, 0x65, 0x62, 0x27, 0xb2, 0xea, 0x88, 0x5a, 0xee,
+	0x8f, 0x7a, 0xb3, 0x59, 0x69, 0xe3, 0x2d, 0x1a, 0x34, 0x05, 0x02, 0x88, 0x92, 0x12, 0x2b, 0xa8,
+	0x25, 0x61, 0x24, 0x6f, 0xe0, 0xa2, 0x0b, 0x62, 0x44, 0x8e, 0x65, 0x22, 0x24, 0x47, 0xe0, 0x8c,
+	0xbc, 0x52, 0xfe, 0x82, 0xc2, 0x40, 0x81, 0x1e, 0x7b, 0x31, 0x50, 0xb4, 0x45, 0xb1, 0xbd, 0xf7,
+	0xda, 0x7b, 0xd0, 0x5e, 0x72, 0xec, 0x49, 0x68, 0x95, 0x4b, 0xcf, 0x3a, 0x6e, 0x2f, 0x05, 0x67,
+	0x48, 0x8b, 0x89, 0xbd, 0x6b, 0xf7, 0x22, 0x70, 0xde, 0xfb, 0x7e, 0xde, 0x9b, 0x79, 0x33, 0xf3,
+	0x48, 0x81, 0x1d, 0x83, 0x32, 0xe7, 0x1b, 0xcc, 0x9c, 0x8a, 0xf8, 0x39, 0x7d, 0x58, 0xe1, 0x93,
+	0x21, 0x61, 0xe5, 0xa1, 0x47, 0x39, 0x85, 0xb9, 0xd0, 0x5b, 0x16, 0x3f, 0xa7, 0x0f, 0x0b, 0xdb,
+	0xbe, 0x85, 0x32, 0x5d, 0xf8, 0x2b, 0x72, 0x20, 0xc5, 0x85, 0xcd, 0x01, 0x1d, 0x50, 0x69, 0xf7,
+	0x9f, 0x02, 0xeb, 0xf6, 0x80, 0xd2, 0x81, 0x4d, 0x2a, 0x62, 0xd4, 0x1f, 0x1d, 0x57, 0xb0, 0x3b,
+	0x91, 0x2e, 0xf5, 0x6b, 0xb0, 0x51, 0x35, 0x0c, 0xc2, 0x58, 0x6f, 0x32, 0x24, 0x1d, 0xec, 0x61,
+	0x07, 0xd6, 0xc1, 0xd2, 0x29, 0xb6, 0x47, 0x24, 0x1f, 0x2f, 0xc5, 0x77, 0xd7, 0xf7, 0x76, 0xca,
+	0xef, 0x4f, 0xa0, 0xbc, 0x20, 0xb4, 0xdc, 0x7c, 0xaa, 0xac, 0x4e, 0xb0, 0x63, 0x3f, 0x56, 0x05,
+	0xa4, 0x22, 0x09, 0x3f, 0x4e, 0xfd, 0xee, 0xf7, 0x4a, 0x5c, 0xfd, 0x47, 0x1c, 0xac, 0x4a, 0x75,
+	0x8d, 0xba, 0xc7, 0xd6, 0x00, 0x76, 0x01, 0x18, 0x12, 0xcf, 0xb1, 0x18, 0xb3, 0xa8, 0x7b, 0xa3,
+	0x0c, 0x5b, 0xf3, 0xa9, 0x72, 0x4b, 0x66, 0x58, 0x90, 0x2a, 0x8a, 0x84, 0x81, 0x0f, 0x40, 0x1a,
+	0x9b, 0xa6, 0x47, 0x18, 0xcb, 0x27, 0x4a, 0xf1, 0xdd, 0x8c, 0x06, 0xe7, 0x53, 0x65, 0x5d, 0x32,
+	0x81, 0x43, 0x45, 0xa1, 0x04, 0xee, 0x81, 0x4c, 0xf0, 0x48, 0x58, 0x3e, 0x59, 0x4a, 0xee, 0x66,
+	0xb4, 0xcd, 0xf9, 0x54, 0xc9, 0xbd, 0xa3, 0x27, 0x4c, 0x45, 0x0b, 0x59, 0xb0, 0x9a, 0xb7, 0x29,
+	0xb0, 0x2c, 0x6a, 0xc4, 0x20, 0x05, 0xd0, 0xa0, 0x26, 0xd1, 0x47, 0x43, 0x9b, 0x62, 0x53, 0xc7,
+	0x62, 0xbe, 0x62, 0x3d, 0xd9, 0xbd, 0xe2, 0xf7, 0xad, 0x47, 0xd6, 0x40, 0xfb, 0xe0, 0xf5, 0x54,
+	0x89, 0xcd, 0xa7, 0xca, 0xb6, 0xcc, 0x78, 0x39, 0x8e, 0x8a, 0x72, 0xbe, 0xf1, 0x50, 0xd8, 0x24,
+	0x0a, 0x7f, 0x13, 0x07, 0x45, 0xcb, 0x65, 0x1c, 0xbb, 0xdc, 0xc2, 0x9c, 0xe8, 0x26, 0x39, 0xc6,
+	0x23, 0x9b, 0xeb, 0x91, 0x6a, 0x26, 0x6e, 0x50, 0xcd, 0x4f, 0xe7, 0x53, 0xe5, 0x63, 0x99, 0xf7,
+	0x87, 0xa3, 0xa9, 0x68, 0x27, 0x22, 0xa8, 0x4b, 0x7f, 0x67, 0x51, 0xf3, 0xe7, 0x00, 0x3a, 0x78,
+	0xac, 0xfb, 0x29, 0x74, 0xb1, 0x02, 0x66, 0xbd, 0x22, 0xf9, 0x64, 0x29, 0xbe, 0x9b, 0xd2, 0xee,
+	0x2d, 0x16, 0x77, 0x59, 0xa3, 0xa2, 0x0d, 0x07, 0x8f, 0x5f, 0x60, 0xe6, 0xd4, 0xa8, 0x49, 0xba,
+	0xd6, 0x2b, 0x02, 0x0f, 0xc1, 0x86, 0x65, 0xb0, 0x47, 0x7b, 0x0f, 0x75, 0x83, 0xba, 0xdc, 0xc3,
+	0x06, 0xcf, 0xa7, 0xc4, 0x3e, 0x3e, 0x98, 0x4d, 0x95, 0xf5, 0x66, 0xad, 0xfb, 0x68, 0xef, 0x61,
+	0x2d, 0xf0, 0xcc, 0xa7, 0xca, 0x9d, 0x60, 0xfe, 0xef, 0x22, 0x2a, 0x5a, 0x97, 0x96, 0x50, 0x09,
+	0x7b, 0x60, 0x8b, 0x78, 0x1e, 0xf5, 0x74, 0x93, 0x70, 0x6c, 0xd9, 0x4c, 0x3f, 0x25, 0x9e, 0x28,
+	0xd4, 0x52, 0x29, 0xbe, 0xbb, 0xa6, 0x95, 0xe6, 0x53, 0x65, 0x47, 0x86, 0xba, 0x52, 0xa6, 0xa2,
+	0xdb, 0xc2, 0x5e, 0x97, 0xe6, 0xaf, 0xa4, 0x15, 0x1e, 0x81, 0xd5, 0x01, 0x66, 0x3a, 0x33, 0x4e,
+	0x88, 0x39, 0xb2, 0x49, 0x7e, 0x59, 0xec, 0xf9, 0xbd, 0xcb, 0x55, 0x7f, 0x86, 0x59, 0x37, 0x10,
+	0x69, 0x77, 0xe7, 0x53, 0xe5, 0xb6, 0xcc, 0x15, 0x85, 0x55, 0x94, 0x1d, 0x2c, 0x54, 0xe2, 0x94,
+	0xc5, 0xd4, 0x37, 0x29, 0x90, 0x8d, 0xb0, 0xf0, 0x43, 0xb0, 0x26, 0x77, 0xc2, 0x20, 0xba, 0x41,
+	0x19, 0x17, 0xa7, 0x2c, 0x85, 0x56, 0x43, 0x63, 0x8d, 0x32, 0x0e, 0x3f, 0x00, 0xab, 0x06, 0x75,
+	0x86, 0x96, 0x1d, 0x68, 0x12, 0x42, 0x93, 0x0d, 0x6c, 0x42, 0xf2, 0x31, 0x58, 0xf7, 0x73, 0x3b,
+	0x23, 0x9b, 0x5b, 0x43, 0xdb, 0x22, 0x9e, 0xdc, 0x2d, 0xb4, 0x36, 0xc0, 0xec, 0xe0, 0xc2, 0x08,
+	0x1f, 0x81, 0x3c, 0x39, 0x25, 0xae, 0x38, 0x0b, 0x3a, 0xe6, 0xdc, 0xb3, 0xfa, 0x23, 0x1e, 0x44,
+	0x4d, 0x09, 0x60, 0x4b, 0xf8, 0x3b, 0xc4, 0xab, 0x86, 0x5e, 0x11, 0xff, 0x67, 0x60, 0x5b, 0x82,
+	0x0b, 0xc8, 0xc4, 0x1c, 0x4b, 0x72, 0x49, 0x90, 0x77, 0x84, 0xe0, 0x02, 0xab, 0x63, 0x8e, 0x05,
+	0xaa, 0x81, 0xe2, 0x95, 0xe8, 0xb1, 0x47, 0x88, 0xce, 0xfd, 0xa9, 0x2e, 0x0b, 0xbe, 0x70, 0x99,
+	0x7f, 0xea, 0x11, 0xd2, 0xf3, 0xe7, 0xfd, 0x73, 0x50, 0x08, 0x8f, 0x82, 0xee, 0x10, 0xc6, 0xf0,
+	0x20, 0x9a, 0x3f, 0x2d, 0xf8, 0xbb, 0xa1, 0xe2, 0x40, 0x0a, 0x2e, 0x26, 0x70, 0x1f, 0xdc, 0x32,
+	0x46, 0x8c, 0x53, 0x47, 0x97, 0xf3, 0x10, 0xcc, 0x8a, 0x60, 0x36, 0xa4, 0xa3, 0xe1, 0xdb, 0x85,
+	0x76, 0x0f, 0x6c, 0x9d, 0x8c, 0x1c, 0xec, 0x5a, 0xaf, 0x88, 0x1e, 0x74, 0x08, 0xa9, 0xcf, 0x08,
+	0xfd, 0xed, 0xd0, 0x59, 0x95, 0x3e, 0xc1, 0x3c, 0x06, 0xdb, 0x06, 0x76, 0xa9, 0x6b, 0x19, 0xd8,
+	0xbe, 0xc4, 0x81, 0x60, 0x6e, 0x11, 0x41, 0x94, 0xad, 0x82, 0x7b, 0x26, 0x61, 0xc4, 0xb3, 0x7c,
+	0x0f, 0xe6, 0x16, 0x75, 0x05, 0x26, 0xf6, 0xa7, 0x3f, 0xe1, 0x24, 0x9f, 0x95, 0xb5, 0x79, 0x4f,
+	0xe4, 0xb3, 0x1d, 0xe2, 0x69, 0x13, 0x4e, 0xd4, 0x3f, 0xc4, 0xc1, 0x8a, 0x7f, 0xdb, 0x9a, 0xee,
+	0x31, 0x85, 0x3f, 0x02, 0x19, 0x71, 0x19, 0x4f, 0x30, 0x3b, 0x11, 0x67, 0x69, 0x15, 0xad, 0xf8,
+	0x86, 0x7d, 0xcc, 0x4e, 0x60, 0x1e, 0xa4, 0x0d, 0x8f, 0x60, 0x4e, 0x3d, 0xd9, 0x4a, 0x51, 0x38,
+	0x84, 0x5d, 0x00, 0xa3, 0x1d, 0xc3, 0x10, 0xbd, 0x4c, 0xec, 0xeb, 0xf5, 0x1d, 0x2f, 0xe5, 0x77,
+	0x3c, 0x74, 0x2b, 0xc2, 0x4b, 0xc7, 0xf3, 0xd4, 0x4a, 0x32, 0x97, 0x7a, 0x9e, 0x5a, 0x49, 0xe5,
+	0x96, 0xd4, 0xbf, 0x25, 0xc0, 0x6a, 0x78, 0x77, 0xc5, 0x44, 0x3f, 0x04, 0x69, 0x31, 0x51, 0xcb,
+	0x94, 0x47, 0x5e, 0x03, 0xb3, 0xa9, 0xb2, 0x2c, 0xd6, 0x51, 0x47, 0xcb, 0xbe, 0xab, 0x69, 0xfe,
+	0xc0, 0x84, 0x37, 0xc1, 0x12, 0x36, 0x1d, 0xcb, 0x15, 0xc7, 0x3c, 0x83, 0xe4, 0xc0, 0xb7, 0xda,
+	0xb8, 0x4f, 0x6c, 0xd9, 0x61, 0x90, 0x1c, 0xc0, 0x27, 0x41, 0x14, 0x62, 0x06, 0x2b, 0xfa, 0xe8,
+	0x8a, 0x15, 0xf5, 0x19, 0xb5, 0x47, 0x9c, 0xf4, 0xc6, 0x1d, 0xca, 0x2c, 0xbf, 0xc4, 0x28, 0x84,
+	0xe0, 0xe7, 0x20, 0x6b, 0xf5, 0x0d, 0x7d, 0x48, 0x3d, 0xee, 0x4f, 0x77, 0x59, 0x74, 0xaf, 0xb5,
+	0xd9, 0x54, 0xc9, 0x34, 0xb5, 0x5a, 0x87, 0x7a, 0xbc, 0x59, 0x47, 0x19, 0xab, 0x6f, 0x88, 0x47,
+	0x13, 0x1e, 0x80, 0x0c, 0x19, 0x73, 0xe2, 0x8a, 0x6e, 0x94, 0x16, 0x09, 0x37, 0xcb, 0xf2, 0x25,
+	0x5d, 0x0e, 0x5f, 0xd2, 0xe5, 0xaa, 0x3b, 0xd1, 0xb6, 0xff, 0xfe, 0xd7, 0xcf, 0xb7, 0xa2, 0x45,
+	0x69, 0x84, 0x18, 0x5a, 0x44, 0x78, 0x9c, 0xfa, 0x8f, 0xff, 0x76, 0xfa, 0x6f, 0x1c, 0xe4, 0x43,
+	0xa9, 0x5f, 0xa4, 0x7d, 0x8b, 0x71, 0xea, 0x4d, 0x1a, 0x2e, 0xf7, 0x26, 0xb0, 0x03, 0x32, 0x74,
+	0x48, 0x3c, 0x71, 0x32, 0x82, 0xd7, 0xee, 0xde, 0xe5, 0x25, 0x5e, 0x81, 0xb7, 0x43, 0xca, 0x7f,
+	0x7d, 0xa0, 0x45, 0x90, 0xe8, 0xee, 0x24, 0xbe, 0x77, 0x77, 0x9e, 0x80, 0xf4, 0x68, 0x68, 0x8a,
+	0xba, 0x26, 0xff, 0x9f, 0xba, 0x06, 0x10, 0xdc, 0x05, 0x49, 0x87, 0x0d, 0xc4, 0x5e, 0xad, 0x6a,
+	0x77, 0xbe, 0x9b, 0x2a, 0x10, 0xe1, 0x6f, 0x6a, 0xef, 0x5e, 0x62, 0xe4, 0x4b, 0x54, 0x04, 0xe0,
+	0xe5, 0x40, 0x7e, 0x5b, 0xec, 0xdb, 0xd4, 0x78, 0xa9, 0x9f, 0x10, 0x6b, 0x70, 0x12, 0xb6, 0xce,
+	0xac, 0xb0, 0xed, 0x0b, 0x13, 0xdc, 0x06, 0x2b, 0x7c, 0xac, 0x5b, 0xae, 0x49, 0xc6, 0x41, 0xd7,
+	0x4c, 0xf3, 0x71, 0xd3, 0x1f, 0xaa, 0x04, 0x2c, 0x1d, 0x50, 0x93, 0xd8, 0xf0, 0x29, 0x48, 0xbe,
+	0x24, 0x13, 0x79, 0x59, 0xb4, 0x9f, 0x7c, 0x37, 0x55, 0xbe, 0x18, 0x58, 0xfc, 0x64, 0xd4, 0x2f,
+	0x1b, 0xd4, 0xa9, 0x18, 0xd4, 0x21, 0xbc, 0x7f, 0xcc, 0x17, 0x0f, 0xb6, 0xd5, 0x67, 0x15, 0xff,
+	0x6a, 0xb2, 0xf2, 0x3e, 0x19, 0xfb, 0x37, 0x90, 0x21, 0x3f, 0x80, 0x7f, 0xf8, 0xe4, 0xa7, 0x55,
+	0x42, 0x5c, 0x3b, 0x39, 0x50, 0x0f, 0x01, 0xa8, 0xd9, 0x98, 0xb1, 0x9e, 0x87, 0x0d, 0x02, 0x21,
+	0x48, 0x0d, 0x31, 0x97, 0x37, 0x33, 0x83, 0xc4, 0x33, 0xfc, 0x12, 0xac, 0xf5, 0x31, 0x23, 0xba,
+	0xe1, 0xcb, 0xc2, 0x8a, 0x67, 0xb4, 0x8d, 0xd9, 0x54, 0xc9, 0x6a, 0x98, 0x11, 0x81, 0x37, 0xeb,
+	0x28, 0xdb, 0xbf, 0x18, 0x98, 0xea, 0xaf, 0x00, 0xe8, 0xd1, 0x97, 0xc4, 0x95, 0x61, 0x3f, 0x01,
+	0x2b, 0x17, 0xb4, 0x08, 0xad, 0x65, 0x67, 0x53, 0x25, 0x1d, 0x92, 0x69, 0x43, 0x52, 0xbe, 0x8e,
+	0xfb, 0xd4, 0x22, 0x8b, 0xd0, 0x89, 0x48, 0xbe, 0x4e, 0x38, 0x9b, 0xe6, 0xfd, 0xbf, 0x24, 0x00,
+	0x58, 0x7c, 0x57, 0xc0, 0x9f, 0x82, 0xbb, 0xd5, 0x5a, 0xad, 0xd1, 0xed, 0xea, 0xbd, 0xa3, 0x4e,
+	0x43, 0x3f, 0x6c, 0x75, 0x3b, 0x8d, 0x5a, 0xf3, 0x69, 0xb3, 0x51, 0xcf, 0xc5, 0x0a, 0xdb, 0x67,
+	0xe7, 0xa5, 0xad, 0x85, 0xf8, 0xd0, 0x65, 0x43, 0x62, 0x58, 0xc7, 0x16, 0x31, 0xe1, 0x03, 0x00,
+	0xa3, 0x5c, 0xab, 0xad, 0xb5, 0xeb, 0x47, 0xb9, 0x78, 0x61, 0xf3, 0xec, 0xbc, 0x94, 0x5b, 0x20,
+	0x2d, 0xda, 0xa7, 0xe6, 0xc4, 0x7f, 0x37, 0x45, 0xd5, 0xed, 0xd6, 0x2f, 0x8e, 0xf4, 0x6a, 0xbd,
+	0x8e, 0x1a, 0xdd, 0x6e, 0x2e, 0xf1, 0x7e, 0x9a, 0xb6, 0x6b, 0x4f, 0xaa, 0x17, 0xdf, 0x7c, 0x5b,
+	0x51, 0xb0, 0xf1, 0x55, 0x03, 0x1d, 0x89, 0x4c, 0xc9, 0xc2, 0xdd, 0xb3, 0xf3, 0xd2, 0xed, 0x05,
+	0xd5, 0x38, 0x25, 0xde, 0x44, 0x24, 0x7b, 0x02, 0x76, 0xa2, 0x4c, 0xb5, 0x75, 0xa4, 0xb7, 0x9f,
+	0x86, 0xe9, 0x1a, 0xdd, 0x5c, 0xaa, 0xb0, 0x73, 0x76, 0x5e, 0xca, 0x2f, 0xd0, 0xaa, 0x3b, 0x69,
+	0x1f, 0x57, 0xc3, 0x6f, 0xc6, 0xc2, 0xca, 0xaf, 0xff, 0x58, 0x8c, 0x7d, 0xfb, 0xa7, 0x62, 0xec,
+	0xfe, 0x9f, 0x93, 0xa0, 0x74, 0xdd, 0xd5, 0x82, 0x04, 0x7c, 0x51, 0x6b, 0xb7, 0x7a, 0xa8, 0x5a,
+	0xeb, 0xe9, 0xb5, 0x76, 0xbd, 0xa1, 0xef, 0x37, 0xbb, 0xbd, 0x36, 0x3a, 0xd2, 0xdb, 0x9d, 0x06,
+	0xaa, 0xf6, 0x9a, 0xed, 0xd6, 0x55, 0xa5, 0xad, 0x9c, 0x9d, 0x97, 0x3e, 0xbb, 0x2e, 0x76, 0xb4,
+	0xe0, 0x2f, 0xc0, 0xa7, 0x37, 0x4a, 0xd3, 0x6c, 0x35, 0x7b, 0xb9, 0x78, 0x61, 0xf7, 0xec, 0xbc,
+	0xf4, 0xd1, 0x75, 0xf1, 0x9b, 0xae, 0xc5, 0xe1, 0xd7, 0xe0, 0xc1, 0x8d, 0x02, 0x1f, 0x34, 0x9f,
+	0xa1, 0x6a, 0xaf, 0x91, 0x4b, 0x14, 0x3e, 0x3b, 0x3b, 0x2f, 0xfd, 0xf8, 0xba, 0xd8, 0x07, 0xd6,
+	0xc0, 0xc3, 0x9c, 0xdc, 0x38, 0xfc, 0xb3, 0x46, 0xab, 0xd1, 0x6d, 0x76, 0x73, 0xc9, 0x9b, 0x85,
+	0x7f, 0x46, 0x5c, 0xc2, 0x2c, 0x56, 0x48, 0xf9, 0x9b, 0xa5, 0xed, 0xbf, 0xfe, 0x77, 0x31, 0xf6,
+	0xed, 0xac, 0x18, 0x7f, 0x3d, 0x2b, 0xc6, 0xdf, 0xcc, 0x8a, 0xf1, 0x7f, 0xcd, 0x8a, 0xf1, 0xdf,
+	0xbe, 0x2d, 0xc6, 0xde, 0xbc, 0x2d, 0xc6, 0xfe, 0xf9, 0xb6, 0x18, 0xfb, 0xe5, 0x27, 0x91, 0x8b,
+	0x5f, 0xa3, 0xcc, 0x79, 0x11, 0xfe, 0x6d, 0x33, 0x2b, 0x63, 0xf9, 0xf7, 0x4d, 0xfc, 0x77, 0xeb,
+	0x2f, 0x8b, 0x36, 0xfe, 0xe5, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xf9, 0x1a, 0x8b, 0x2f, 0xdc,
+	0x0d, 0x00, 0x00,
 }
 
 func (this *AccessTypeParam) Equal(that interface{}) bool {
@@ -575,6 +780,14 @@ func (this *AccessConfig) Equal(that interface{}) bool {
 	if this.Address != that1.Address {
 		return false
 	}
+	if len(this.Addresses) != len(that1.Addresses) {
+		return false
+	}
+	for i := range this.Addresses {
+		if this.Addresses[i] != that1.Addresses[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *Params) Equal(that interface{}) bool {
@@ -605,6 +818,69 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.MaxWasmCodeSize != that1.MaxWasmCodeSize {
 		return false
 	}
+	if this.ICS721Contract != that1.ICS721Contract {
+		return false
+	}
+	if this.ErrorDetailsVersion != that1.ErrorDetailsVersion {
+		return false
+	}
+	if !this.GasSchedule.Equal(that1.GasSchedule) {
+		return false
+	}
+	return true
+}
+func (this *GasSchedule) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GasSchedule)
+	if !ok {
+		that2, ok := that.(GasSchedule)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.InstanceCost != that1.InstanceCost {
+		return false
+	}
+	if this.CompileCost != that1.CompileCost {
+		return false
+	}
+	if this.GasMultiplier != that1.GasMultiplier {
+		return false
+	}
+	if this.EventPerAttributeCost != that1.EventPerAttributeCost {
+		return false
+	}
+	if this.EventAttributeDataCost != that1.EventAttributeDataCost {
+		return false
+	}
+	if this.EventAttributeDataFreeTier != that1.EventAttributeDataFreeTier {
+		return false
+	}
+	if this.ContractMessageDataCost != that1.ContractMessageDataCost {
+		return false
+	}
+	if this.CustomEventCost != that1.CustomEventCost {
+		return false
+	}
+	if this.HumanizeAddressCost != that1.HumanizeAddressCost {
+		return false
+	}
+	if this.CanonicalizeAddressCost != that1.CanonicalizeAddressCost {
+		return false
+	}
+	if this.DeserializationCostPerByte != that1.DeserializationCostPerByte {
+		return false
+	}
 	return true
 }
 func (this *CodeInfo) Equal(that interface{}) bool {
@@ -766,6 +1042,60 @@ func (this *Model) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ClassTrace) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ClassTrace)
+	if !ok {
+		that2, ok := that.(ClassTrace)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.BaseClassID != that1.BaseClassID {
+		return false
+	}
+	return true
+}
+func (this *TokenTrace) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TokenTrace)
+	if !ok {
+		that2, ok := that.(TokenTrace)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ClassID != that1.ClassID {
+		return false
+	}
+	if this.TokenID != that1.TokenID {
+		return false
+	}
+	return true
+}
 func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -814,6 +1144,15 @@ func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Addresses) > 0 {
+		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addresses[iNdEx])
+			copy(dAtA[i:], m.Addresses[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Addresses[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Address) > 0 {
 		i -= len(m.Address)
 		copy(dAtA[i:], m.Address)
@@ -849,6 +1188,30 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.GasSchedule != nil {
+		{
+			size, err := m.GasSchedule.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ErrorDetailsVersion != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ErrorDetailsVersion))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ICS721Contract) > 0 {
+		i -= len(m.ICS721Contract)
+		copy(dAtA[i:], m.ICS721Contract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ICS721Contract)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.MaxWasmCodeSize != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmCodeSize))
 		i--
@@ -872,6 +1235,84 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GasSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GasSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GasSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DeserializationCostPerByte != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DeserializationCostPerByte))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.CanonicalizeAddressCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CanonicalizeAddressCost))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.HumanizeAddressCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.HumanizeAddressCost))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.CustomEventCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CustomEventCost))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ContractMessageDataCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ContractMessageDataCost))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.EventAttributeDataFreeTier != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventAttributeDataFreeTier))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EventAttributeDataCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventAttributeDataCost))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.EventPerAttributeCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventPerAttributeCost))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.GasMultiplier != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasMultiplier))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CompileCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CompileCost))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.InstanceCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.InstanceCost))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CodeInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1121,27 +1562,101 @@ func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ClassTrace) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *AccessTypeParam) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != 0 {
-		n += 1 + sovTypes(uint64(m.Value))
-	}
-	return n
+
+func (m *ClassTrace) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClassTrace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BaseClassID) > 0 {
+		i -= len(m.BaseClassID)
+		copy(dAtA[i:], m.BaseClassID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.BaseClassID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokenTrace) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenTrace) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenTrace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TokenID) > 0 {
+		i -= len(m.TokenID)
+		copy(dAtA[i:], m.TokenID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClassID) > 0 {
+		i -= len(m.ClassID)
+		copy(dAtA[i:], m.ClassID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ClassID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AccessTypeParam) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != 0 {
+		n += 1 + sovTypes(uint64(m.Value))
+	}
+	return n
 }
 
 func (m *AccessConfig) Size() (n int) {
@@ -1157,6 +1672,12 @@ func (m *AccessConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.Addresses) > 0 {
+		for _, s := range m.Addresses {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1174,6 +1695,59 @@ func (m *Params) Size() (n int) {
 	if m.MaxWasmCodeSize != 0 {
 		n += 1 + sovTypes(uint64(m.MaxWasmCodeSize))
 	}
+	l = len(m.ICS721Contract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ErrorDetailsVersion != 0 {
+		n += 1 + sovTypes(uint64(m.ErrorDetailsVersion))
+	}
+	if m.GasSchedule != nil {
+		l = m.GasSchedule.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *GasSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InstanceCost != 0 {
+		n += 1 + sovTypes(uint64(m.InstanceCost))
+	}
+	if m.CompileCost != 0 {
+		n += 1 + sovTypes(uint64(m.CompileCost))
+	}
+	if m.GasMultiplier != 0 {
+		n += 1 + sovTypes(uint64(m.GasMultiplier))
+	}
+	if m.EventPerAttributeCost != 0 {
+		n += 1 + sovTypes(uint64(m.EventPerAttributeCost))
+	}
+	if m.EventAttributeDataCost != 0 {
+		n += 1 + sovTypes(uint64(m.EventAttributeDataCost))
+	}
+	if m.EventAttributeDataFreeTier != 0 {
+		n += 1 + sovTypes(uint64(m.EventAttributeDataFreeTier))
+	}
+	if m.ContractMessageDataCost != 0 {
+		n += 1 + sovTypes(uint64(m.ContractMessageDataCost))
+	}
+	if m.CustomEventCost != 0 {
+		n += 1 + sovTypes(uint64(m.CustomEventCost))
+	}
+	if m.HumanizeAddressCost != 0 {
+		n += 1 + sovTypes(uint64(m.HumanizeAddressCost))
+	}
+	if m.CanonicalizeAddressCost != 0 {
+		n += 1 + sovTypes(uint64(m.CanonicalizeAddressCost))
+	}
+	if m.DeserializationCostPerByte != 0 {
+		n += 1 + sovTypes(uint64(m.DeserializationCostPerByte))
+	}
 	return n
 }
 
@@ -1287,6 +1861,40 @@ func (m *Model) Size() (n int) {
 	return n
 }
 
+func (m *ClassTrace) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.BaseClassID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *TokenTrace) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClassID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.TokenID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1442,6 +2050,38 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.Address = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1496,7 +2136,348 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
 			}
-			var msglen int
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			}
+			m.InstantiateDefaultPermission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+			}
+			m.MaxWasmCodeSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ICS721Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ICS721Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorDetailsVersion", wireType)
+			}
+			m.ErrorDetailsVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorDetailsVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasSchedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GasSchedule == nil {
+				m.GasSchedule = &GasSchedule{}
+			}
+			if err := m.GasSchedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GasSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GasSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GasSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceCost", wireType)
+			}
+			m.InstanceCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstanceCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompileCost", wireType)
+			}
+			m.CompileCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompileCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasMultiplier", wireType)
+			}
+			m.GasMultiplier = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasMultiplier |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventPerAttributeCost", wireType)
+			}
+			m.EventPerAttributeCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventPerAttributeCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventAttributeDataCost", wireType)
+			}
+			m.EventAttributeDataCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventAttributeDataCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventAttributeDataFreeTier", wireType)
+			}
+			m.EventAttributeDataFreeTier = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventAttributeDataFreeTier |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractMessageDataCost", wireType)
+			}
+			m.ContractMessageDataCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContractMessageDataCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CustomEventCost", wireType)
+			}
+			m.CustomEventCost = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1506,30 +2487,35 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CustomEventCost |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HumanizeAddressCost", wireType)
 			}
-			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.HumanizeAddressCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HumanizeAddressCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CanonicalizeAddressCost", wireType)
 			}
-			m.InstantiateDefaultPermission = 0
+			m.CanonicalizeAddressCost = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1539,16 +2525,16 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				m.CanonicalizeAddressCost |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 11:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeserializationCostPerByte", wireType)
 			}
-			m.MaxWasmCodeSize = 0
+			m.DeserializationCostPerByte = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1558,7 +2544,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
+				m.DeserializationCostPerByte |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2366,6 +3352,234 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ClassTrace) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClassTrace: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClassTrace: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseClassID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BaseClassID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenTrace) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenTrace: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenTrace: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClassID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClassID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
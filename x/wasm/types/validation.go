@@ -9,6 +9,12 @@ const (
 
 	// MaxLabelSize is the longest label that can be used when Instantiating a contract
 	MaxLabelSize = 128
+
+	// MaxSaltSize is the longest salt that can be used when instantiating a contract
+	MaxSaltSize = 64
+
+	// ChecksumLen is the length of a wasm code checksum (sha256)
+	ChecksumLen = 32
 )
 
 func validateWasmCode(s []byte) error {
@@ -30,3 +36,14 @@ func validateLabel(label string) error {
 	}
 	return nil
 }
+
+// ValidateSalt ensure salt constraints
+func ValidateSalt(salt []byte) error {
+	switch n := len(salt); {
+	case n == 0:
+		return errors.Wrap(ErrEmpty, "is required")
+	case n > MaxSaltSize:
+		return errors.Wrapf(ErrLimit, "cannot be longer than %d bytes", MaxSaltSize)
+	}
+	return nil
+}
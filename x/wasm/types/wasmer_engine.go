@@ -6,24 +6,43 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 )
 
+var _ WasmerEngine = &wasmvm.VM{}
+
 // WasmerEngine defines the WASM contract runtime engine.
+// The method set matches the wasmvm v2 *wasmvm.VM so that the VM can be replaced
+// by mocks, decorators or alternative implementations.
 type WasmerEngine interface {
-
-	// Create will compile the wasm code, and store the resulting pre-compile
-	// as well as the original code. Both can be referenced later via CodeID
+	// StoreCode will compile the wasm code, and store the resulting pre-compile
+	// as well as the original code. Both can be referenced later via Checksum.
 	// This must be done one time for given code, after which it can be
 	// instatitated many times, and each instance called many times.
 	//
 	// For example, the code for all ERC-20 contracts should be the same.
 	// This function stores the code for that contract only once, but it can
 	// be instantiated with custom inputs in the future.
-	Create(code wasmvm.WasmCode) (wasmvm.Checksum, error)
+	//
+	// Returns both the checksum, as well as the gas cost of compilation (in CosmWasm Gas) or an error.
+	StoreCode(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error)
+
+	// SimulateStoreCode works like StoreCode, but does not actually store the code.
+	// Instead, it just does all the validation and compilation steps without storing the result on disk.
+	SimulateStoreCode(code wasmvm.WasmCode, gasLimit uint64) (wasmvm.Checksum, uint64, error)
+
+	// StoreCodeUnchecked will compile the wasm code, and store the resulting pre-compile
+	// as well as the original code. Both can be referenced later via Checksum.
+	// This does the same as StoreCode but skips static validation checks.
+	// Use this for adding code that was checked before, particularly in the case of state sync.
+	StoreCodeUnchecked(code wasmvm.WasmCode) (wasmvm.Checksum, error)
+
+	// RemoveCode removes the wasm code and the compiled module for the given checksum
+	// from the file system cache.
+	RemoveCode(checksum wasmvm.Checksum) error
 
 	// AnalyzeCode will statically analyze the code.
 	// Currently just reports if it exposes all IBC entry points.
 	AnalyzeCode(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error)
 
-	// Instantiate will create a new contract based on the given codeID.
+	// Instantiate will create a new contract based on the given Checksum.
 	// We can set the initMsg (contract "genesis") here, and it then receives
 	// an account and address and can be invoked (Execute) many times.
 	//
@@ -42,9 +61,9 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.Response, uint64, error)
+	) (*wasmvmtypes.ContractResult, uint64, error)
 
-	// Execute calls a given contract. Since the only difference between contracts with the same CodeID is the
+	// Execute calls a given contract. Since the only difference between contracts with the same Checksum is the
 	// data in their local storage, and their address in the outside world, we need no ContractID here.
 	// (That is a detail for the external, sdk-facing, side).
 	//
@@ -61,7 +80,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.Response, uint64, error)
+	) (*wasmvmtypes.ContractResult, uint64, error)
 
 	// Query allows a client to execute a contract-specific query. If the result is not empty, it should be
 	// valid json-encoded data to return to the client.
@@ -76,10 +95,10 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) ([]byte, uint64, error)
+	) (*wasmvmtypes.QueryResult, uint64, error)
 
 	// Migrate will migrate an existing contract to a new code binary.
-	// This takes storage of the data from the original contract and the CodeID of the new contract that should
+	// This takes storage of the data from the original contract and the Checksum of the new contract that should
 	// replace it. This allows it to run a migration step if needed, or return an error if unable to migrate
 	// the given data.
 	//
@@ -94,7 +113,22 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.Response, uint64, error)
+	) (*wasmvmtypes.ContractResult, uint64, error)
+
+	// MigrateWithInfo works like Migrate but passes the sender and the code id
+	// that the contract is migrating from to the contract.
+	MigrateWithInfo(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		migrateMsg []byte,
+		migrateInfo wasmvmtypes.MigrateInfo,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.ContractResult, uint64, error)
 
 	// Sudo runs an existing contract in read/write mode (like Execute), but is never exposed to external callers
 	// (either transactions or government proposals), but can only be called by other native Go modules directly.
@@ -111,7 +145,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.Response, uint64, error)
+	) (*wasmvmtypes.ContractResult, uint64, error)
 
 	// Reply is called on the original dispatching contract after running a submessage
 	Reply(
@@ -124,16 +158,16 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.Response, uint64, error)
+	) (*wasmvmtypes.ContractResult, uint64, error)
 
-	// GetCode will load the original wasm code for the given code id.
-	// This will only succeed if that code id was previously returned from
-	// a call to Create.
+	// GetCode will load the original wasm code for the given checksum.
+	// This will only succeed if that checksum was previously returned from
+	// a call to StoreCode.
 	//
-	// This can be used so that the (short) code id (hash) is stored in the iavl tree
+	// This can be used so that the (short) checksum is stored in the iavl tree
 	// and the larger binary blobs (wasm and pre-compiles) are all managed by the
 	// rust library
-	GetCode(code wasmvm.Checksum) (wasmvm.WasmCode, error)
+	GetCode(checksum wasmvm.Checksum) (wasmvm.WasmCode, error)
 
 	// Cleanup should be called when no longer using this to free resources on the rust-side
 	Cleanup()
@@ -150,7 +184,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.IBC3ChannelOpenResponse, uint64, error)
+	) (*wasmvmtypes.IBCChannelOpenResult, uint64, error)
 
 	// IBCChannelConnect is available on IBC-enabled contracts and is a hook to call into
 	// during the handshake pahse
@@ -164,7 +198,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
 
 	// IBCChannelClose is available on IBC-enabled contracts and is a hook to call into
 	// at the end of the channel lifetime
@@ -178,7 +212,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
 
 	// IBCPacketReceive is available on IBC-enabled contracts and is called when an incoming
 	// packet is received on a channel belonging to this contract
@@ -207,7 +241,7 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
 
 	// IBCPacketTimeout is available on IBC-enabled contracts and is called when an
 	// outgoing packet (previously sent by this contract) will provably never be executed.
@@ -222,7 +256,35 @@ type WasmerEngine interface {
 		gasMeter wasmvm.GasMeter,
 		gasLimit uint64,
 		deserCost wasmvmtypes.UFraction,
-	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
+
+	// IBCSourceCallback is available on IBC-enabled contracts with the corresponding entrypoint
+	// and is called when an ack or timeout for a packet sent by this contract is received
+	IBCSourceCallback(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		msg wasmvmtypes.IBCSourceCallbackMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
+
+	// IBCDestinationCallback is available on IBC-enabled contracts with the corresponding entrypoint
+	// and is called when a packet addressed to this contract was received and acknowledged
+	IBCDestinationCallback(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		msg wasmvmtypes.IBCDestinationCallbackMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResult, uint64, error)
 
 	// Pin pins a code to an in-memory cache, such that is
 	// always loaded quickly when executed.
@@ -237,6 +299,10 @@ type WasmerEngine interface {
 
 	// GetMetrics some internal metrics for monitoring purposes.
 	GetMetrics() (*wasmvmtypes.Metrics, error)
+
+	// GetPinnedMetrics returns some internal metrics of pinned contracts for monitoring purposes.
+	// The order of entries is non-deterministic and the values are node-specific.
+	GetPinnedMetrics() (*wasmvmtypes.PinnedMetrics, error)
 }
 
 // StoreAdapter bridges SDK KVStore to wasmvm KVStore interface.